@@ -16,16 +16,65 @@ package fileexporter // import "github.com/open-telemetry/opentelemetry-collecto
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"go.opentelemetry.io/collector/config"
 )
 
+const (
+	formatTypeJSON  = "json"
+	formatTypeProto = "proto"
+
+	compressionGzip = "gzip"
+	compressionZstd = "zstd"
+)
+
 // Config defines configuration for file exporter.
 type Config struct {
 	config.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
 
 	// Path of the file to write to. Path is relative to current directory.
 	Path string `mapstructure:"path"`
+
+	// Rotation defines an option about rotation of telemetry files. Ignored
+	// when nil, in which case the file is truncated on start and grows for ever.
+	Rotation *Rotation `mapstructure:"rotation"`
+
+	// FormatType defines the data format of encoded telemetry data.
+	// Options:
+	// - json[default]: OTLP json bytes, one message per line.
+	// - proto: OTLP binary protobuf bytes, each message prefixed with its
+	//   length as a 4-byte big-endian unsigned integer.
+	FormatType string `mapstructure:"format"`
+
+	// Compression compresses the file with the given algorithm.
+	// Options: gzip, zstd. No compression when empty.
+	Compression string `mapstructure:"compression"`
+
+	// FlushInterval is the interval at which buffered data is flushed to the file.
+	// When zero, the data is flushed after every write.
+	FlushInterval time.Duration `mapstructure:"flush_interval"`
+}
+
+// Rotation an option to rolling log files
+type Rotation struct {
+	// MaxMegabytes is the maximum size in megabytes of the file before it gets
+	// rotated. No size based rotation when zero.
+	MaxMegabytes int `mapstructure:"max_megabytes"`
+
+	// MaxAge is the maximum amount of time the same file is written to before it
+	// gets rotated. The age is checked on every write. No age based rotation when zero.
+	MaxAge time.Duration `mapstructure:"max_age"`
+
+	// MaxBackups is the maximum number of rotated files to retain. The oldest
+	// files are removed first. All rotated files are retained when zero.
+	MaxBackups int `mapstructure:"max_backups"`
+
+	// LocalTime determines if the time used for formatting the timestamps in
+	// the names of rotated files is the computer's local time. The default is
+	// to use UTC time.
+	LocalTime bool `mapstructure:"localtime"`
 }
 
 var _ config.Exporter = (*Config)(nil)
@@ -35,6 +84,26 @@ func (cfg *Config) Validate() error {
 	if cfg.Path == "" {
 		return errors.New("path must be non-empty")
 	}
+	if cfg.FormatType != formatTypeJSON && cfg.FormatType != formatTypeProto {
+		return fmt.Errorf("format type %q is not supported", cfg.FormatType)
+	}
+	if cfg.Compression != "" && cfg.Compression != compressionGzip && cfg.Compression != compressionZstd {
+		return fmt.Errorf("compression %q is not supported", cfg.Compression)
+	}
+	if cfg.FlushInterval < 0 {
+		return errors.New("flush_interval must not be negative")
+	}
+	if cfg.Rotation != nil {
+		if cfg.Rotation.MaxMegabytes < 0 {
+			return errors.New("rotation max_megabytes must not be negative")
+		}
+		if cfg.Rotation.MaxAge < 0 {
+			return errors.New("rotation max_age must not be negative")
+		}
+		if cfg.Rotation.MaxBackups < 0 {
+			return errors.New("rotation max_backups must not be negative")
+		}
+	}
 
 	return nil
 }
@@ -17,6 +17,7 @@ package fileexporter
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -43,5 +44,69 @@ func TestLoadConfig(t *testing.T) {
 		&Config{
 			ExporterSettings: config.NewExporterSettings(config.NewComponentIDWithName(typeStr, "2")),
 			Path:             "./filename.json",
+			FormatType:       formatTypeJSON,
+			FlushInterval:    time.Second,
 		})
+
+	e2 := cfg.Exporters[config.NewComponentIDWithName(typeStr, "3")]
+	assert.Equal(t, e2,
+		&Config{
+			ExporterSettings: config.NewExporterSettings(config.NewComponentIDWithName(typeStr, "3")),
+			Path:             "./filename.json",
+			Rotation: &Rotation{
+				MaxMegabytes: 10,
+				MaxAge:       24 * time.Hour,
+				MaxBackups:   100,
+				LocalTime:    true,
+			},
+			FormatType:    formatTypeProto,
+			Compression:   compressionZstd,
+			FlushInterval: 5 * time.Second,
+		})
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "valid",
+			modify: func(*Config) {},
+		},
+		{
+			name:   "unknown format",
+			modify: func(cfg *Config) { cfg.FormatType = "xml" },
+			err:    `format type "xml" is not supported`,
+		},
+		{
+			name:   "unknown compression",
+			modify: func(cfg *Config) { cfg.Compression = "lz4" },
+			err:    `compression "lz4" is not supported`,
+		},
+		{
+			name:   "negative flush interval",
+			modify: func(cfg *Config) { cfg.FlushInterval = -time.Second },
+			err:    "flush_interval must not be negative",
+		},
+		{
+			name:   "negative max backups",
+			modify: func(cfg *Config) { cfg.Rotation = &Rotation{MaxBackups: -1} },
+			err:    "rotation max_backups must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Path = "./filename.json"
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
 }
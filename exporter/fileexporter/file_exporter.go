@@ -17,8 +17,8 @@ package fileexporter // import "github.com/open-telemetry/opentelemetry-collecto
 import (
 	"context"
 	"io"
-	"os"
 	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
@@ -27,17 +27,36 @@ import (
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
-// Marshaler configuration used for marhsaling Protobuf to JSON.
-var tracesMarshaler = ptrace.NewJSONMarshaler()
-var metricsMarshaler = pmetric.NewJSONMarshaler()
-var logsMarshaler = plog.NewJSONMarshaler()
-
 // fileExporter is the implementation of file exporter that writes telemetry data to a file
-// in Protobuf-JSON format.
+// in Protobuf-JSON or Protobuf format.
 type fileExporter struct {
 	path  string
 	file  io.WriteCloser
 	mutex sync.Mutex
+
+	tracesMarshaler  ptrace.Marshaler
+	metricsMarshaler pmetric.Marshaler
+	logsMarshaler    plog.Marshaler
+	frame            frameFunc
+
+	compression   string
+	rotation      *Rotation
+	flushInterval time.Duration
+	stopFlushing  chan struct{}
+	flushingDone  sync.WaitGroup
+}
+
+func newFileExporter(cfg *Config) *fileExporter {
+	return &fileExporter{
+		path:             cfg.Path,
+		tracesMarshaler:  tracesMarshalers[cfg.FormatType],
+		metricsMarshaler: metricsMarshalers[cfg.FormatType],
+		logsMarshaler:    logsMarshalers[cfg.FormatType],
+		frame:            framers[cfg.FormatType],
+		compression:      cfg.Compression,
+		rotation:         cfg.Rotation,
+		flushInterval:    cfg.FlushInterval,
+	}
 }
 
 func (e *fileExporter) Capabilities() consumer.Capabilities {
@@ -45,49 +64,95 @@ func (e *fileExporter) Capabilities() consumer.Capabilities {
 }
 
 func (e *fileExporter) ConsumeTraces(_ context.Context, td ptrace.Traces) error {
-	buf, err := tracesMarshaler.MarshalTraces(td)
+	buf, err := e.tracesMarshaler.MarshalTraces(td)
 	if err != nil {
 		return err
 	}
-	return exportMessageAsLine(e, buf)
+	return e.export(buf)
 }
 
 func (e *fileExporter) ConsumeMetrics(_ context.Context, md pmetric.Metrics) error {
-	buf, err := metricsMarshaler.MarshalMetrics(md)
+	buf, err := e.metricsMarshaler.MarshalMetrics(md)
 	if err != nil {
 		return err
 	}
-	return exportMessageAsLine(e, buf)
+	return e.export(buf)
 }
 
 func (e *fileExporter) ConsumeLogs(_ context.Context, ld plog.Logs) error {
-	buf, err := logsMarshaler.MarshalLogs(ld)
+	buf, err := e.logsMarshaler.MarshalLogs(ld)
 	if err != nil {
 		return err
 	}
-	return exportMessageAsLine(e, buf)
+	return e.export(buf)
 }
 
-func exportMessageAsLine(e *fileExporter, buf []byte) error {
+func (e *fileExporter) export(buf []byte) error {
 	// Ensure only one write operation happens at a time.
 	e.mutex.Lock()
 	defer e.mutex.Unlock()
-	if _, err := e.file.Write(buf); err != nil {
+	// The whole framed message is written at once, so that it is never split
+	// between two files by a rotation.
+	if _, err := e.file.Write(e.frame(buf)); err != nil {
 		return err
 	}
-	if _, err := io.WriteString(e.file, "\n"); err != nil {
-		return err
+	if e.flushInterval == 0 {
+		return e.flush()
+	}
+	return nil
+}
+
+// flush must be called with e.mutex held.
+func (e *fileExporter) flush() error {
+	if f, ok := e.file.(flusher); ok {
+		return f.Flush()
 	}
 	return nil
 }
 
+func (e *fileExporter) startFlusher() {
+	e.stopFlushing = make(chan struct{})
+	e.flushingDone.Add(1)
+	go func() {
+		defer e.flushingDone.Done()
+		ticker := time.NewTicker(e.flushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				e.mutex.Lock()
+				// An error is reported again by the next write or on shutdown.
+				_ = e.flush()
+				e.mutex.Unlock()
+			case <-e.stopFlushing:
+				return
+			}
+		}
+	}()
+}
+
 func (e *fileExporter) Start(context.Context, component.Host) error {
 	var err error
-	e.file, err = os.OpenFile(e.path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
-	return err
+	e.file, err = newFileWriter(e.path, e.compression, e.rotation)
+	if err != nil {
+		return err
+	}
+	if e.flushInterval > 0 {
+		e.startFlusher()
+	}
+	return nil
 }
 
 // Shutdown stops the exporter and is invoked during shutdown.
 func (e *fileExporter) Shutdown(context.Context) error {
+	if e.stopFlushing != nil {
+		close(e.stopFlushing)
+		e.flushingDone.Wait()
+	}
+	if e.file == nil {
+		return nil
+	}
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
 	return e.file.Close()
 }
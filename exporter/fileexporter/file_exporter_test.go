@@ -14,11 +14,17 @@
 package fileexporter
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/binary"
 	"errors"
+	"io"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
@@ -30,7 +36,7 @@ import (
 )
 
 func TestFileTracesExporter(t *testing.T) {
-	fe := &fileExporter{path: tempFileName(t)}
+	fe := newFileExporter(&Config{Path: tempFileName(t), FormatType: formatTypeJSON})
 	require.NotNil(t, fe)
 
 	td := testdata.GenerateTracesTwoSpansSameResource()
@@ -48,7 +54,8 @@ func TestFileTracesExporter(t *testing.T) {
 
 func TestFileTracesExporterError(t *testing.T) {
 	mf := &errorWriter{}
-	fe := &fileExporter{file: mf}
+	fe := newFileExporter(&Config{FormatType: formatTypeJSON})
+	fe.file = mf
 	require.NotNil(t, fe)
 
 	td := testdata.GenerateTracesTwoSpansSameResource()
@@ -58,7 +65,7 @@ func TestFileTracesExporterError(t *testing.T) {
 }
 
 func TestFileMetricsExporter(t *testing.T) {
-	fe := &fileExporter{path: tempFileName(t)}
+	fe := newFileExporter(&Config{Path: tempFileName(t), FormatType: formatTypeJSON})
 	require.NotNil(t, fe)
 
 	md := testdata.GenerateMetricsTwoMetrics()
@@ -76,7 +83,8 @@ func TestFileMetricsExporter(t *testing.T) {
 
 func TestFileMetricsExporterError(t *testing.T) {
 	mf := &errorWriter{}
-	fe := &fileExporter{file: mf}
+	fe := newFileExporter(&Config{FormatType: formatTypeJSON})
+	fe.file = mf
 	require.NotNil(t, fe)
 
 	md := testdata.GenerateMetricsTwoMetrics()
@@ -86,7 +94,7 @@ func TestFileMetricsExporterError(t *testing.T) {
 }
 
 func TestFileLogsExporter(t *testing.T) {
-	fe := &fileExporter{path: tempFileName(t)}
+	fe := newFileExporter(&Config{Path: tempFileName(t), FormatType: formatTypeJSON})
 	require.NotNil(t, fe)
 
 	ld := testdata.GenerateLogsTwoLogRecordsSameResource()
@@ -104,7 +112,8 @@ func TestFileLogsExporter(t *testing.T) {
 
 func TestFileLogsExporterErrors(t *testing.T) {
 	mf := &errorWriter{}
-	fe := &fileExporter{file: mf}
+	fe := newFileExporter(&Config{FormatType: formatTypeJSON})
+	fe.file = mf
 	require.NotNil(t, fe)
 
 	ld := testdata.GenerateLogsTwoLogRecordsSameResource()
@@ -113,6 +122,107 @@ func TestFileLogsExporterErrors(t *testing.T) {
 	assert.NoError(t, fe.Shutdown(context.Background()))
 }
 
+func TestFileTracesExporterProto(t *testing.T) {
+	fe := newFileExporter(&Config{Path: tempFileName(t), FormatType: formatTypeProto})
+	require.NotNil(t, fe)
+
+	td := testdata.GenerateTracesTwoSpansSameResource()
+	assert.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, fe.ConsumeTraces(context.Background(), td))
+	assert.NoError(t, fe.ConsumeTraces(context.Background(), td))
+	assert.NoError(t, fe.Shutdown(context.Background()))
+
+	buf, err := os.ReadFile(fe.path)
+	assert.NoError(t, err)
+	unmarshaler := ptrace.NewProtoUnmarshaler()
+	messages := splitLengthPrefixed(t, buf)
+	require.Len(t, messages, 2)
+	for _, msg := range messages {
+		got, err := unmarshaler.UnmarshalTraces(msg)
+		assert.NoError(t, err)
+		assert.EqualValues(t, td, got)
+	}
+}
+
+func TestFileLogsExporterCompression(t *testing.T) {
+	tests := []struct {
+		compression string
+		decompress  func(t *testing.T, r io.Reader) []byte
+	}{
+		{
+			compression: compressionGzip,
+			decompress: func(t *testing.T, r io.Reader) []byte {
+				zr, err := gzip.NewReader(r)
+				require.NoError(t, err)
+				buf, err := io.ReadAll(zr)
+				require.NoError(t, err)
+				return buf
+			},
+		},
+		{
+			compression: compressionZstd,
+			decompress: func(t *testing.T, r io.Reader) []byte {
+				zr, err := zstd.NewReader(r)
+				require.NoError(t, err)
+				defer zr.Close()
+				buf, err := io.ReadAll(zr)
+				require.NoError(t, err)
+				return buf
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.compression, func(t *testing.T) {
+			fe := newFileExporter(&Config{
+				Path:          tempFileName(t),
+				FormatType:    formatTypeJSON,
+				Compression:   tt.compression,
+				FlushInterval: time.Hour,
+			})
+			ld := testdata.GenerateLogsTwoLogRecordsSameResource()
+			assert.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+			assert.NoError(t, fe.ConsumeLogs(context.Background(), ld))
+			assert.NoError(t, fe.Shutdown(context.Background()))
+
+			f, err := os.Open(fe.path)
+			require.NoError(t, err)
+			defer f.Close()
+			got, err := plog.NewJSONUnmarshaler().UnmarshalLogs(tt.decompress(t, f))
+			assert.NoError(t, err)
+			assert.EqualValues(t, ld, got)
+		})
+	}
+}
+
+func TestFileExporterFlushInterval(t *testing.T) {
+	fe := newFileExporter(&Config{
+		Path:          tempFileName(t),
+		FormatType:    formatTypeJSON,
+		FlushInterval: 10 * time.Millisecond,
+	})
+	assert.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, fe.ConsumeLogs(context.Background(), testdata.GenerateLogsOneLogRecord()))
+	assert.Eventually(t, func() bool {
+		info, err := os.Stat(fe.path)
+		return err == nil && info.Size() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.NoError(t, fe.Shutdown(context.Background()))
+}
+
+func splitLengthPrefixed(t *testing.T, buf []byte) [][]byte {
+	var messages [][]byte
+	r := bytes.NewReader(buf)
+	for r.Len() > 0 {
+		var size uint32
+		require.NoError(t, binary.Read(r, binary.BigEndian, &size))
+		msg := make([]byte, size)
+		_, err := io.ReadFull(r, msg)
+		require.NoError(t, err)
+		messages = append(messages, msg)
+	}
+	return messages
+}
+
 // tempFileName provides a temporary file name for testing.
 func tempFileName(t *testing.T) string {
 	tmpfile, err := os.CreateTemp("", "*.json")
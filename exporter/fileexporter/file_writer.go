@@ -0,0 +1,247 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"go.uber.org/multierr"
+)
+
+const (
+	megabyte = 1024 * 1024
+	// backupTimeFormat is the format of the timestamp in the name of rotated files.
+	// It sorts lexicographically in time order.
+	backupTimeFormat = "2006-01-02T15-04-05.000000000"
+)
+
+type flusher interface {
+	Flush() error
+}
+
+// countingWriter keeps track of the number of bytes written to the underlying writer.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// fileWriter is an io.WriteCloser that writes to a file through a buffer and,
+// optionally, a compressor, and rotates the file according to a Rotation.
+//
+// When compressing, every time the file is opened a new compressed stream is
+// started. Both gzip and zstd allow several streams to be concatenated, so the
+// file can be decompressed as a whole.
+type fileWriter struct {
+	path        string
+	compression string
+	rotation    *Rotation
+	maxSize     int64
+	now         func() time.Time
+
+	file     *os.File
+	counter  *countingWriter
+	buffer   *bufio.Writer
+	encoder  io.WriteCloser
+	openedAt time.Time
+}
+
+var _ io.WriteCloser = (*fileWriter)(nil)
+
+func newFileWriter(path string, compression string, rotation *Rotation) (*fileWriter, error) {
+	w := &fileWriter{
+		path:        path,
+		compression: compression,
+		rotation:    rotation,
+		now:         time.Now,
+	}
+	if rotation != nil {
+		w.maxSize = int64(rotation.MaxMegabytes) * megabyte
+		// Keep the data written by a previous run instead of truncating it.
+		if info, err := os.Stat(path); err == nil && info.Size() > 0 {
+			if err := w.backup(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Write writes p to the file, rotating it first if needed. p is never split
+// across two files.
+func (w *fileWriter) Write(p []byte) (int, error) {
+	if w.shouldRotate() {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	if w.encoder != nil {
+		return w.encoder.Write(p)
+	}
+	return w.buffer.Write(p)
+}
+
+// Flush writes the buffered and compressed data to the file.
+func (w *fileWriter) Flush() error {
+	if w.encoder != nil {
+		if err := w.encoder.(flusher).Flush(); err != nil {
+			return err
+		}
+	}
+	return w.buffer.Flush()
+}
+
+func (w *fileWriter) Close() error {
+	if w.file == nil {
+		return nil
+	}
+	return w.closeFile()
+}
+
+func (w *fileWriter) open() error {
+	file, err := os.OpenFile(w.path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	w.file = file
+	w.counter = &countingWriter{w: file}
+	w.buffer = bufio.NewWriter(w.counter)
+	w.openedAt = w.now()
+
+	switch w.compression {
+	case compressionGzip:
+		w.encoder = gzip.NewWriter(w.buffer)
+	case compressionZstd:
+		encoder, err := zstd.NewWriter(w.buffer)
+		if err != nil {
+			return multierr.Append(err, file.Close())
+		}
+		w.encoder = encoder
+	default:
+		w.encoder = nil
+	}
+	return nil
+}
+
+func (w *fileWriter) closeFile() error {
+	var err error
+	if w.encoder != nil {
+		err = w.encoder.Close()
+	}
+	err = multierr.Append(err, w.buffer.Flush())
+	err = multierr.Append(err, w.file.Close())
+	w.file = nil
+	return err
+}
+
+func (w *fileWriter) shouldRotate() bool {
+	if w.rotation == nil {
+		return false
+	}
+	if w.maxSize > 0 && w.counter.n+int64(w.buffer.Buffered()) >= w.maxSize {
+		return true
+	}
+	return w.rotation.MaxAge > 0 && w.now().Sub(w.openedAt) >= w.rotation.MaxAge
+}
+
+func (w *fileWriter) rotate() error {
+	if err := w.closeFile(); err != nil {
+		return err
+	}
+	if err := w.backup(); err != nil {
+		return err
+	}
+	return w.open()
+}
+
+// backup moves the file at path to a name containing the current time and
+// removes the oldest backups beyond MaxBackups.
+func (w *fileWriter) backup() error {
+	prefix, ext := w.backupNameParts()
+	t := w.now()
+	if !w.rotation.LocalTime {
+		t = t.UTC()
+	}
+	if err := os.Rename(w.path, prefix+t.Format(backupTimeFormat)+ext); err != nil {
+		return err
+	}
+	return w.removeOldBackups()
+}
+
+func (w *fileWriter) removeOldBackups() error {
+	if w.rotation.MaxBackups <= 0 {
+		return nil
+	}
+	backups, err := w.backups()
+	if err != nil {
+		return err
+	}
+	if len(backups) <= w.rotation.MaxBackups {
+		return nil
+	}
+	var errs error
+	for _, b := range backups[:len(backups)-w.rotation.MaxBackups] {
+		errs = multierr.Append(errs, os.Remove(b))
+	}
+	return errs
+}
+
+// backups returns the rotated files of path, oldest first.
+func (w *fileWriter) backups() ([]string, error) {
+	prefix, ext := w.backupNameParts()
+	dir := filepath.Dir(w.path)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	namePrefix := filepath.Base(prefix)
+	var backups []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, namePrefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		ts := strings.TrimSuffix(strings.TrimPrefix(name, namePrefix), ext)
+		if _, err := time.Parse(backupTimeFormat, ts); err != nil {
+			continue
+		}
+		backups = append(backups, filepath.Join(dir, name))
+	}
+	sort.Strings(backups)
+	return backups, nil
+}
+
+// backupNameParts returns what comes before and after the timestamp in the
+// name of a rotated file: "dir/name.json" is rotated to "dir/name-<timestamp>.json".
+func (w *fileWriter) backupNameParts() (string, string) {
+	ext := filepath.Ext(w.path)
+	return strings.TrimSuffix(w.path, ext) + "-", ext
+}
@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileexporter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock returns a clock that advances by one millisecond every time it is read,
+// so that rotated files always get distinct names.
+func fakeClock() func() time.Time {
+	now := time.Date(2022, 8, 25, 10, 0, 0, 0, time.UTC)
+	return func() time.Time {
+		now = now.Add(time.Millisecond)
+		return now
+	}
+}
+
+func newTestFileWriter(t *testing.T, path string, rotation *Rotation) *fileWriter {
+	w, err := newFileWriter(path, "", rotation)
+	require.NoError(t, err)
+	w.now = fakeClock()
+	return w
+}
+
+func TestFileWriterRotatesBySize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.json")
+	w := newTestFileWriter(t, path, &Rotation{})
+	w.maxSize = 10
+
+	for _, line := range []string{"first line\n", "second line\n", "third line\n"} {
+		_, err := w.Write([]byte(line))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+
+	backups, err := w.backups()
+	require.NoError(t, err)
+	require.Len(t, backups, 2)
+	assertFileContent(t, backups[0], "first line\n")
+	assertFileContent(t, backups[1], "second line\n")
+	assertFileContent(t, path, "third line\n")
+}
+
+func TestFileWriterRotatesByAge(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.json")
+	w := newTestFileWriter(t, path, &Rotation{MaxAge: time.Minute})
+	now := time.Now()
+	w.now = func() time.Time { return now }
+	w.openedAt = now
+
+	_, err := w.Write([]byte("first line\n"))
+	require.NoError(t, err)
+	now = now.Add(time.Minute)
+	_, err = w.Write([]byte("second line\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	backups, err := w.backups()
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	assertFileContent(t, backups[0], "first line\n")
+	assertFileContent(t, path, "second line\n")
+}
+
+func TestFileWriterMaxBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.json")
+	w := newTestFileWriter(t, path, &Rotation{MaxBackups: 2})
+	w.maxSize = 1
+
+	for _, line := range []string{"1\n", "2\n", "3\n", "4\n", "5\n"} {
+		_, err := w.Write([]byte(line))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+
+	backups, err := w.backups()
+	require.NoError(t, err)
+	require.Len(t, backups, 2)
+	assertFileContent(t, backups[0], "3\n")
+	assertFileContent(t, backups[1], "4\n")
+	assertFileContent(t, path, "5\n")
+}
+
+func TestFileWriterKeepsPreviousFileOnStart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.json")
+	require.NoError(t, os.WriteFile(path, []byte("previous run\n"), 0600))
+
+	w := newTestFileWriter(t, path, &Rotation{})
+	require.NoError(t, w.Close())
+
+	backups, err := w.backups()
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	assertFileContent(t, backups[0], "previous run\n")
+	assertFileContent(t, path, "")
+}
+
+func TestFileWriterWithoutRotationTruncates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.json")
+	require.NoError(t, os.WriteFile(path, []byte("previous run\n"), 0600))
+
+	w := newTestFileWriter(t, path, nil)
+	_, err := w.Write([]byte("new line\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	assertFileContent(t, path, "new line\n")
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func assertFileContent(t *testing.T, path string, expected string) {
+	buf, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(buf))
+}
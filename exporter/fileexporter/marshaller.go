@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+
+import (
+	"encoding/binary"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var tracesMarshalers = map[string]ptrace.Marshaler{
+	formatTypeJSON:  ptrace.NewJSONMarshaler(),
+	formatTypeProto: ptrace.NewProtoMarshaler(),
+}
+var metricsMarshalers = map[string]pmetric.Marshaler{
+	formatTypeJSON:  pmetric.NewJSONMarshaler(),
+	formatTypeProto: pmetric.NewProtoMarshaler(),
+}
+var logsMarshalers = map[string]plog.Marshaler{
+	formatTypeJSON:  plog.NewJSONMarshaler(),
+	formatTypeProto: plog.NewProtoMarshaler(),
+}
+
+// frameFunc turns a marshaled message into the bytes written to the file for it.
+type frameFunc func(buf []byte) []byte
+
+var framers = map[string]frameFunc{
+	formatTypeJSON:  frameAsLine,
+	formatTypeProto: frameWithLengthPrefix,
+}
+
+// frameAsLine terminates the message with a newline.
+func frameAsLine(buf []byte) []byte {
+	return append(buf, '\n')
+}
+
+// frameWithLengthPrefix prefixes the message with its length as a 4-byte
+// big-endian unsigned integer, so that a reader can split the binary stream.
+func frameWithLengthPrefix(buf []byte) []byte {
+	framed := make([]byte, 4, 4+len(buf))
+	binary.BigEndian.PutUint32(framed, uint32(len(buf)))
+	return append(framed, buf...)
+}
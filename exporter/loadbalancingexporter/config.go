@@ -24,6 +24,8 @@ type routingKey int
 const (
 	traceIDRouting routingKey = iota
 	svcRouting
+	attrRouting
+	streamIDRouting
 )
 
 // Config defines configuration for the exporter.
@@ -32,6 +34,9 @@ type Config struct {
 	Protocol                Protocol         `mapstructure:"protocol"`
 	Resolver                ResolverSettings `mapstructure:"resolver"`
 	RoutingKey              string           `mapstructure:"routing_key"`
+	// RoutingAttributes are the attributes whose values are used for routing when RoutingKey is "attributes".
+	// Each attribute is looked up in the resource attributes first, then in the span or log record attributes.
+	RoutingAttributes []string `mapstructure:"routing_attributes"`
 }
 
 // Protocol holds the individual protocol-specific settings. Only OTLP is supported at the moment.
@@ -43,6 +48,7 @@ type Protocol struct {
 type ResolverSettings struct {
 	Static *StaticResolver `mapstructure:"static"`
 	DNS    *DNSResolver    `mapstructure:"dns"`
+	K8sSvc *K8sSvcResolver `mapstructure:"k8s"`
 }
 
 // StaticResolver defines the configuration for the resolver providing a fixed list of backends
@@ -55,3 +61,12 @@ type DNSResolver struct {
 	Hostname string `mapstructure:"hostname"`
 	Port     string `mapstructure:"port"`
 }
+
+// K8sSvcResolver defines the configuration for the resolver watching the EndpointSlices of a Kubernetes service
+type K8sSvcResolver struct {
+	// Service is the name of the service, optionally followed by its namespace, e.g. "lb-svc.observability".
+	// The "default" namespace is used when none is specified.
+	Service string `mapstructure:"service"`
+	// Ports are the ports to use for each of the resolved endpoints. Defaults to 4317.
+	Ports []int32 `mapstructure:"ports"`
+}
@@ -24,6 +24,8 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 )
 
 const (
@@ -39,6 +41,9 @@ var _ loadBalancer = (*loadBalancerImp)(nil)
 
 type componentFactory func(ctx context.Context, endpoint string) (component.Exporter, error)
 
+// newK8sClient creates the client used by the k8s resolver, it can be replaced in tests
+var newK8sClient = k8sconfig.MakeClient
+
 type loadBalancer interface {
 	component.Component
 	Endpoint(identifier []byte) string
@@ -63,7 +68,7 @@ type loadBalancerImp struct {
 func newLoadBalancer(params component.ExporterCreateSettings, cfg config.Exporter, factory componentFactory) (*loadBalancerImp, error) {
 	oCfg := cfg.(*Config)
 
-	if oCfg.Resolver.DNS != nil && oCfg.Resolver.Static != nil {
+	if countResolvers(oCfg.Resolver) > 1 {
 		return nil, errMultipleResolversProvided
 	}
 
@@ -85,6 +90,19 @@ func newLoadBalancer(params component.ExporterCreateSettings, cfg config.Exporte
 		}
 	}
 
+	if oCfg.Resolver.K8sSvc != nil {
+		k8sLogger := params.Logger.With(zap.String("resolver", "k8s service"))
+
+		clt, err := newK8sClient(k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount})
+		if err != nil {
+			return nil, err
+		}
+		res, err = newK8sResolver(clt, k8sLogger, oCfg.Resolver.K8sSvc.Service, oCfg.Resolver.K8sSvc.Ports)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if res == nil {
 		return nil, errNoResolver
 	}
@@ -97,6 +115,20 @@ func newLoadBalancer(params component.ExporterCreateSettings, cfg config.Exporte
 	}, nil
 }
 
+func countResolvers(cfg ResolverSettings) int {
+	count := 0
+	if cfg.Static != nil {
+		count++
+	}
+	if cfg.DNS != nil {
+		count++
+	}
+	if cfg.K8sSvc != nil {
+		count++
+	}
+	return count
+}
+
 func (lb *loadBalancerImp) Start(ctx context.Context, host component.Host) error {
 	lb.res.onChange(lb.onBackendChanges)
 	lb.host = host
@@ -167,9 +199,10 @@ func endpointFound(endpoint string, endpoints []string) bool {
 	return false
 }
 
-func (lb *loadBalancerImp) Shutdown(context.Context) error {
+func (lb *loadBalancerImp) Shutdown(ctx context.Context) error {
+	err := lb.res.shutdown(ctx)
 	lb.stopped = true
-	return nil
+	return err
 }
 
 func (lb *loadBalancerImp) Endpoint(identifier []byte) string {
@@ -25,6 +25,10 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 )
 
 func TestNewLoadBalancerNoResolver(t *testing.T) {
@@ -110,6 +114,35 @@ func TestWithDNSResolver(t *testing.T) {
 	assert.True(t, ok)
 }
 
+func TestWithK8sResolver(t *testing.T) {
+	// prepare
+	defer func(orig func(k8sconfig.APIConfig) (kubernetes.Interface, error)) {
+		newK8sClient = orig
+	}(newK8sClient)
+	newK8sClient = func(k8sconfig.APIConfig) (kubernetes.Interface, error) {
+		return fake.NewSimpleClientset(), nil
+	}
+
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			K8sSvc: &K8sSvcResolver{
+				Service: "lb.observability",
+			},
+		},
+	}
+	p, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), cfg, nil)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// test
+	res, ok := p.res.(*k8sResolver)
+
+	// verify
+	require.True(t, ok)
+	assert.Equal(t, "lb", res.svcName)
+	assert.Equal(t, "observability", res.svcNs)
+}
+
 func TestMultipleResolvers(t *testing.T) {
 	cfg := &Config{
 		Resolver: ResolverSettings{
@@ -165,6 +198,30 @@ func TestLoadBalancerShutdown(t *testing.T) {
 	assert.Nil(t, res)
 }
 
+func TestLoadBalancerShutdownStopsResolver(t *testing.T) {
+	// prepare
+	cfg := simpleConfig()
+	p, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), cfg, nil)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	expectedErr := errors.New("some expected err")
+	shutdownCalled := false
+	p.res = &mockResolver{
+		onShutdown: func(context.Context) error {
+			shutdownCalled = true
+			return expectedErr
+		},
+	}
+
+	// test
+	res := p.Shutdown(context.Background())
+
+	// verify
+	assert.True(t, shutdownCalled)
+	assert.Equal(t, expectedErr, res)
+}
+
 func TestOnBackendChanges(t *testing.T) {
 	// prepare
 	cfg := simpleConfig()
@@ -38,6 +38,8 @@ var _ component.LogsExporter = (*logExporterImp)(nil)
 
 type logExporterImp struct {
 	loadBalancer loadBalancer
+	routingKey   routingKey
+	routingAttrs []string
 
 	stopped    bool
 	shutdownWg sync.WaitGroup
@@ -55,9 +57,17 @@ func newLogsExporter(params component.ExporterCreateSettings, cfg config.Exporte
 		return nil, err
 	}
 
-	return &logExporterImp{
-		loadBalancer: lb,
-	}, nil
+	logExporter := logExporterImp{loadBalancer: lb, routingKey: traceIDRouting}
+
+	// other routing keys are only supported for traces, logs fall back to the trace ID
+	if cfg.(*Config).RoutingKey == "attributes" {
+		if len(cfg.(*Config).RoutingAttributes) == 0 {
+			return nil, errNoRoutingAttributes
+		}
+		logExporter.routingKey = attrRouting
+		logExporter.routingAttrs = cfg.(*Config).RoutingAttributes
+	}
+	return &logExporter, nil
 }
 
 func (e *logExporterImp) Capabilities() consumer.Capabilities {
@@ -68,14 +78,22 @@ func (e *logExporterImp) Start(ctx context.Context, host component.Host) error {
 	return e.loadBalancer.Start(ctx, host)
 }
 
-func (e *logExporterImp) Shutdown(context.Context) error {
+func (e *logExporterImp) Shutdown(ctx context.Context) error {
+	err := e.loadBalancer.Shutdown(ctx)
 	e.stopped = true
 	e.shutdownWg.Wait()
-	return nil
+	return err
 }
 
 func (e *logExporterImp) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 	var errs error
+	if e.routingKey == attrRouting {
+		for key, batch := range splitLogsByAttributes(ld, e.routingAttrs) {
+			errs = multierr.Append(errs, e.exportLog(ctx, batch, []byte(key)))
+		}
+		return errs
+	}
+
 	batches := batchpersignal.SplitLogs(ld)
 	for _, batch := range batches {
 		errs = multierr.Append(errs, e.consumeLog(ctx, batch))
@@ -95,7 +113,12 @@ func (e *logExporterImp) consumeLog(ctx context.Context, ld plog.Logs) error {
 	}
 
 	tid := balancingKey.Bytes()
-	endpoint := e.loadBalancer.Endpoint(tid[:])
+	return e.exportLog(ctx, ld, tid[:])
+}
+
+// exportLog sends the logs to the backend responsible for the given routing identifier.
+func (e *logExporterImp) exportLog(ctx context.Context, ld plog.Logs, identifier []byte) error {
+	endpoint := e.loadBalancer.Endpoint(identifier)
 	exp, err := e.loadBalancer.Exporter(endpoint)
 	if err != nil {
 		return err
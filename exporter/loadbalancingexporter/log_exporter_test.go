@@ -54,6 +54,15 @@ func TestNewLogsExporter(t *testing.T) {
 			},
 			errNoResolver,
 		},
+		{
+			"attributes without routing attributes",
+			func() *Config {
+				cfg := simpleConfig()
+				cfg.RoutingKey = "attributes"
+				return cfg
+			}(),
+			errNoRoutingAttributes,
+		},
 	} {
 		t.Run(tt.desc, func(t *testing.T) {
 			// test
@@ -333,6 +342,49 @@ func TestLogsWithoutTraceID(t *testing.T) {
 	assert.Len(t, sink.AllLogs(), 1)
 }
 
+func TestConsumeLogsAttributeBased(t *testing.T) {
+	componentFactory := func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		return newNopMockLogsExporter(), nil
+	}
+	lb, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), attributeBasedRoutingConfig(), componentFactory)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newLogsExporter(componenttest.NewNopExporterCreateSettings(), attributeBasedRoutingConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+	assert.Equal(t, attrRouting, p.routingKey)
+
+	// pre-load an exporter here, so that we don't use the actual OTLP exporter
+	sink := new(consumertest.LogsSink)
+	lb.exporters["endpoint-1"] = newMockLogsExporter(sink.ConsumeLogs)
+	p.loadBalancer = lb
+
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString("tenant", "acme")
+	logs := rl.ScopeLogs().AppendEmpty().LogRecords()
+	logs.AppendEmpty().Attributes().InsertString("http.method", "GET")
+	logs.AppendEmpty().Attributes().InsertString("http.method", "POST")
+	rl = ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString("tenant", "globex")
+	rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Attributes().InsertString("http.method", "GET")
+
+	// test
+	err = p.ConsumeLogs(context.Background(), ld)
+
+	// verify
+	assert.NoError(t, err)
+	assert.Len(t, sink.AllLogs(), 3, "logs should be split per routing key")
+	assert.Equal(t, 3, sink.LogRecordCount())
+}
+
 func TestRollingUpdatesWhenConsumeLogs(t *testing.T) {
 	t.Skip("Flaky Test - See https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/13331")
 
@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
+)
+
+var _ component.MetricsExporter = (*metricExporterImp)(nil)
+
+type metricExporterImp struct {
+	loadBalancer loadBalancer
+	routingKey   routingKey
+	routingAttrs []string
+
+	stopped    bool
+	shutdownWg sync.WaitGroup
+}
+
+// Create new metrics exporter
+func newMetricsExporter(params component.ExporterCreateSettings, cfg config.Exporter) (*metricExporterImp, error) {
+	exporterFactory := otlpexporter.NewFactory()
+
+	lb, err := newLoadBalancer(params, cfg, func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		oCfg := buildExporterConfig(cfg.(*Config), endpoint)
+		return exporterFactory.CreateMetricsExporter(ctx, params, &oCfg)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metricExporter := metricExporterImp{loadBalancer: lb, routingKey: svcRouting}
+
+	switch cfg.(*Config).RoutingKey {
+	case "streamID":
+		metricExporter.routingKey = streamIDRouting
+	case "attributes":
+		if len(cfg.(*Config).RoutingAttributes) == 0 {
+			return nil, errNoRoutingAttributes
+		}
+		metricExporter.routingKey = attrRouting
+		metricExporter.routingAttrs = cfg.(*Config).RoutingAttributes
+	case "service", "traceID", "":
+		// metrics have no trace ID, they are routed based on the service name by default
+	default:
+		return nil, fmt.Errorf("unsupported routing_key: %s", cfg.(*Config).RoutingKey)
+	}
+	return &metricExporter, nil
+}
+
+func (e *metricExporterImp) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (e *metricExporterImp) Start(ctx context.Context, host component.Host) error {
+	return e.loadBalancer.Start(ctx, host)
+}
+
+func (e *metricExporterImp) Shutdown(ctx context.Context) error {
+	err := e.loadBalancer.Shutdown(ctx)
+	e.stopped = true
+	e.shutdownWg.Wait()
+	return err
+}
+
+func (e *metricExporterImp) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	var errs error
+	for key, batch := range splitMetrics(md, e.routingKeyFunc()) {
+		errs = multierr.Append(errs, e.exportMetrics(ctx, batch, key))
+	}
+
+	return errs
+}
+
+// routingKeyFunc returns the function computing the routing key of each data point, based on the configured routing key
+func (e *metricExporterImp) routingKeyFunc() metricsKeyFunc {
+	switch e.routingKey {
+	case streamIDRouting:
+		return streamIDKey
+	case attrRouting:
+		// only the resource attributes are used, along with the metric name
+		noAttrs := pcommon.NewMap()
+		return func(resource pcommon.Resource, metric pmetric.Metric, _ pcommon.Map) string {
+			return routingKeyFromAttributes(e.routingAttrs, resource.Attributes(), noAttrs) + attributesKeySeparator + metric.Name()
+		}
+	default:
+		return serviceKey
+	}
+}
+
+// serviceKey routes the data points based on the service name of their resource
+func serviceKey(resource pcommon.Resource, _ pmetric.Metric, _ pcommon.Map) string {
+	svc, ok := resource.Attributes().Get("service.name")
+	if !ok {
+		return ""
+	}
+	return svc.AsString()
+}
+
+// streamIDKey routes the data points based on the identity of their stream: the resource, the metric name and the data point attributes
+func streamIDKey(resource pcommon.Resource, metric pmetric.Metric, attrs pcommon.Map) string {
+	return attributesKey(resource.Attributes()) + attributesKeySeparator + metric.Name() + attributesKeySeparator + attributesKey(attrs)
+}
+
+// exportMetrics sends the metrics to the backend responsible for the given routing identifier.
+func (e *metricExporterImp) exportMetrics(ctx context.Context, md pmetric.Metrics, rid string) error {
+	endpoint := e.loadBalancer.Endpoint([]byte(rid))
+	exp, err := e.loadBalancer.Exporter(endpoint)
+	if err != nil {
+		return err
+	}
+
+	me, ok := exp.(component.MetricsExporter)
+	if !ok {
+		expectType := (*component.MetricsExporter)(nil)
+		return fmt.Errorf("unable to export metrics, unexpected exporter type: expected %T but got %T", expectType, exp)
+	}
+
+	start := time.Now()
+	err = me.ConsumeMetrics(ctx, md)
+	duration := time.Since(start)
+	ctx, _ = tag.New(ctx, tag.Upsert(tag.MustNewKey("endpoint"), endpoint))
+
+	if err == nil {
+		sCtx, _ := tag.New(ctx, tag.Upsert(tag.MustNewKey("success"), "true"))
+		stats.Record(sCtx, mBackendLatency.M(duration.Milliseconds()))
+	} else {
+		fCtx, _ := tag.New(ctx, tag.Upsert(tag.MustNewKey("success"), "false"))
+		stats.Record(fCtx, mBackendLatency.M(duration.Milliseconds()))
+	}
+
+	return err
+}
@@ -0,0 +1,278 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestNewMetricsExporter(t *testing.T) {
+	for _, tt := range []struct {
+		desc   string
+		config *Config
+		err    error
+	}{
+		{
+			"simple",
+			simpleConfig(),
+			nil,
+		},
+		{
+			"empty",
+			&Config{
+				ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+			},
+			errNoResolver,
+		},
+		{
+			"attributes without routing attributes",
+			func() *Config {
+				cfg := simpleConfig()
+				cfg.RoutingKey = "attributes"
+				return cfg
+			}(),
+			errNoRoutingAttributes,
+		},
+		{
+			"unsupported routing key",
+			func() *Config {
+				cfg := simpleConfig()
+				cfg.RoutingKey = "unknown"
+				return cfg
+			}(),
+			fmt.Errorf("unsupported routing_key: unknown"),
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// test
+			_, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), tt.config)
+
+			// verify
+			require.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestMetricsExporterRoutingKey(t *testing.T) {
+	for _, tt := range []struct {
+		routingKey string
+		expected   routingKey
+	}{
+		{"", svcRouting},
+		{"traceID", svcRouting},
+		{"service", svcRouting},
+		{"streamID", streamIDRouting},
+	} {
+		t.Run(tt.routingKey, func(t *testing.T) {
+			cfg := simpleConfig()
+			cfg.RoutingKey = tt.routingKey
+
+			// test
+			p, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), cfg)
+
+			// verify
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, p.routingKey)
+		})
+	}
+}
+
+func TestMetricsExporterStart(t *testing.T) {
+	// prepare
+	lb, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), simpleConfig(), nil)
+	require.NoError(t, err)
+	p, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), simpleConfig())
+	require.NoError(t, err)
+
+	lb.res = &mockResolver{
+		onStart: func(context.Context) error {
+			return errors.New("some expected err")
+		},
+	}
+	p.loadBalancer = lb
+
+	// test
+	res := p.Start(context.Background(), componenttest.NewNopHost())
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	// verify
+	assert.Equal(t, errors.New("some expected err"), res)
+}
+
+func TestConsumeMetrics(t *testing.T) {
+	for _, tt := range []struct {
+		desc       string
+		routingKey string
+		attributes []string
+		batches    int
+	}{
+		{
+			desc:    "service",
+			batches: 2,
+		},
+		{
+			desc:       "stream ID",
+			routingKey: "streamID",
+			batches:    4,
+		},
+		{
+			desc:       "attributes",
+			routingKey: "attributes",
+			attributes: []string{"service.name"},
+			batches:    3,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			cfg := simpleConfig()
+			cfg.RoutingKey = tt.routingKey
+			cfg.RoutingAttributes = tt.attributes
+
+			componentFactory := func(ctx context.Context, endpoint string) (component.Exporter, error) {
+				return newNopMockMetricsExporter(), nil
+			}
+			lb, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), cfg, componentFactory)
+			require.NotNil(t, lb)
+			require.NoError(t, err)
+
+			p, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), cfg)
+			require.NotNil(t, p)
+			require.NoError(t, err)
+
+			// pre-load an exporter here, so that we don't use the actual OTLP exporter
+			sink := new(consumertest.MetricsSink)
+			lb.exporters["endpoint-1"] = newMockMetricsExporter(sink.ConsumeMetrics)
+			p.loadBalancer = lb
+
+			err = p.Start(context.Background(), componenttest.NewNopHost())
+			require.NoError(t, err)
+			defer func() {
+				require.NoError(t, p.Shutdown(context.Background()))
+			}()
+
+			// test
+			err = p.ConsumeMetrics(context.Background(), twoServicesMetrics())
+
+			// verify
+			assert.NoError(t, err)
+			assert.Len(t, sink.AllMetrics(), tt.batches)
+			assert.Equal(t, 4, sink.DataPointCount())
+		})
+	}
+}
+
+func TestConsumeMetricsUnexpectedExporterType(t *testing.T) {
+	componentFactory := func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		return newNopMockMetricsExporter(), nil
+	}
+	lb, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), simpleConfig(), componentFactory)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), simpleConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// pre-load an exporter here, so that we don't use the actual OTLP exporter
+	lb.exporters["endpoint-1"] = newNopMockExporter()
+	p.loadBalancer = lb
+
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	// test
+	res := p.ConsumeMetrics(context.Background(), twoServicesMetrics())
+
+	// verify
+	assert.Error(t, res)
+}
+
+// twoServicesMetrics returns two services, with the first one having a sum with
+// two data points, each one with its own attributes, and a gauge with a single data point
+func twoServicesMetrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("service.name", "service-1")
+	metrics := rm.ScopeMetrics().AppendEmpty().Metrics()
+	sum := metrics.AppendEmpty()
+	sum.SetName("requests")
+	sum.SetDataType(pmetric.MetricDataTypeSum)
+	sum.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	sum.Sum().SetIsMonotonic(true)
+	sum.Sum().DataPoints().AppendEmpty().Attributes().InsertString("http.method", "GET")
+	sum.Sum().DataPoints().AppendEmpty().Attributes().InsertString("http.method", "POST")
+	gauge := metrics.AppendEmpty()
+	gauge.SetName("memory")
+	gauge.SetDataType(pmetric.MetricDataTypeGauge)
+	gauge.Gauge().DataPoints().AppendEmpty()
+
+	rm = md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("service.name", "service-2")
+	gauge = rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	gauge.SetName("memory")
+	gauge.SetDataType(pmetric.MetricDataTypeGauge)
+	gauge.Gauge().DataPoints().AppendEmpty()
+
+	return md
+}
+
+type mockMetricsExporter struct {
+	component.Component
+	consumeMetricsFn func(ctx context.Context, md pmetric.Metrics) error
+}
+
+func newMockMetricsExporter(consumeMetricsFn func(ctx context.Context, md pmetric.Metrics) error) component.MetricsExporter {
+	return &mockMetricsExporter{
+		Component:        mockComponent{},
+		consumeMetricsFn: consumeMetricsFn,
+	}
+}
+
+func newNopMockMetricsExporter() component.MetricsExporter {
+	return &mockMetricsExporter{
+		Component: mockComponent{},
+		consumeMetricsFn: func(ctx context.Context, md pmetric.Metrics) error {
+			return nil
+		},
+	}
+}
+
+func (e *mockMetricsExporter) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (e *mockMetricsExporter) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if e.consumeMetricsFn == nil {
+		return nil
+	}
+	return e.consumeMetricsFn(ctx, md)
+}
@@ -0,0 +1,236 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+var _ resolver = (*k8sResolver)(nil)
+
+const (
+	defaultK8sNamespace = "default"
+	defaultSyncTimeout  = 5 * time.Second
+	defaultK8sPort      = 4317
+)
+
+var (
+	errNoSvc        = errors.New("no service specified to resolve the backends")
+	errInvalidPort  = errors.New("invalid port specified for the k8s resolver")
+	k8sResolverTags = tag.Upsert(tag.MustNewKey("resolver"), "k8s")
+)
+
+// k8sResolver watches the EndpointSlices of a Kubernetes service and resolves
+// the backends to the addresses of the ready endpoints, one for each configured port.
+type k8sResolver struct {
+	logger *zap.Logger
+
+	svcName     string
+	svcNs       string
+	ports       []int32
+	syncTimeout time.Duration
+
+	informer cache.SharedInformer
+
+	endpoints         []string
+	onChangeCallbacks []func([]string)
+
+	stopCh             chan struct{}
+	updateLock         sync.Mutex
+	shutdownWg         sync.WaitGroup
+	stopLock           sync.Mutex
+	stopped            bool
+	changeCallbackLock sync.RWMutex
+}
+
+func newK8sResolver(clt kubernetes.Interface, logger *zap.Logger, service string, ports []int32) (*k8sResolver, error) {
+	if len(service) == 0 {
+		return nil, errNoSvc
+	}
+
+	// the service is specified as "name" or "name.namespace"
+	nAddr := strings.SplitN(service, ".", 2)
+	name, namespace := nAddr[0], defaultK8sNamespace
+	if len(nAddr) > 1 && len(nAddr[1]) > 0 {
+		namespace = nAddr[1]
+	}
+
+	for _, port := range ports {
+		if port <= 0 || port > 65535 {
+			return nil, fmt.Errorf("%w: %d", errInvalidPort, port)
+		}
+	}
+	if len(ports) == 0 {
+		ports = []int32{defaultK8sPort}
+	}
+
+	selector := fmt.Sprintf("%s=%s", discoveryv1.LabelServiceName, name)
+	lw := &cache.ListWatch{
+		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			opts.LabelSelector = selector
+			return clt.DiscoveryV1().EndpointSlices(namespace).List(context.Background(), opts)
+		},
+		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+			opts.LabelSelector = selector
+			return clt.DiscoveryV1().EndpointSlices(namespace).Watch(context.Background(), opts)
+		},
+	}
+
+	r := &k8sResolver{
+		logger:      logger,
+		svcName:     name,
+		svcNs:       namespace,
+		ports:       ports,
+		syncTimeout: defaultSyncTimeout,
+		informer:    cache.NewSharedInformer(lw, &discoveryv1.EndpointSlice{}, 0),
+		stopCh:      make(chan struct{}),
+	}
+	r.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    r.handleChange,
+		UpdateFunc: func(_, obj interface{}) { r.handleChange(obj) },
+		DeleteFunc: r.handleChange,
+	})
+
+	return r, nil
+}
+
+func (r *k8sResolver) start(ctx context.Context) error {
+	go r.informer.Run(r.stopCh)
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, r.syncTimeout)
+	defer cancel()
+	if !cache.WaitForCacheSync(timeoutCtx.Done(), r.informer.HasSynced) {
+		r.logger.Warn("timed out waiting for the EndpointSlices of the service to be synced",
+			zap.String("service", r.svcName), zap.String("namespace", r.svcNs))
+	}
+
+	if _, err := r.resolve(ctx); err != nil {
+		r.logger.Warn("failed to resolve", zap.Error(err))
+	}
+
+	return nil
+}
+
+func (r *k8sResolver) shutdown(ctx context.Context) error {
+	r.changeCallbackLock.Lock()
+	r.onChangeCallbacks = nil
+	r.changeCallbackLock.Unlock()
+
+	// the informer might still trigger resolutions until it is stopped, no new
+	// resolution can start once we begin waiting for the ongoing ones
+	r.stopLock.Lock()
+	r.stopped = true
+	r.stopLock.Unlock()
+
+	close(r.stopCh)
+	r.shutdownWg.Wait()
+	return nil
+}
+
+func (r *k8sResolver) handleChange(interface{}) {
+	if _, err := r.resolve(context.Background()); err != nil {
+		r.logger.Warn("failed to resolve", zap.Error(err))
+	}
+}
+
+func (r *k8sResolver) resolve(ctx context.Context) ([]string, error) {
+	r.stopLock.Lock()
+	if r.stopped {
+		r.stopLock.Unlock()
+		return nil, nil
+	}
+	r.shutdownWg.Add(1)
+	r.stopLock.Unlock()
+	defer r.shutdownWg.Done()
+
+	// the context to use for all metrics in this function
+	mCtx, _ := tag.New(ctx, k8sResolverTags)
+	successCtx, _ := tag.New(mCtx, tag.Upsert(tag.MustNewKey("success"), "true"))
+	stats.Record(successCtx, mNumResolutions.M(1))
+
+	// the same address might show up in more than one slice while endpoints are being moved around
+	unique := map[string]struct{}{}
+	for _, obj := range r.informer.GetStore().List() {
+		slice, ok := obj.(*discoveryv1.EndpointSlice)
+		if !ok || slice.Labels[discoveryv1.LabelServiceName] != r.svcName {
+			continue
+		}
+		for _, endpoint := range slice.Endpoints {
+			// endpoints without a ready condition should be considered ready
+			if endpoint.Conditions.Ready != nil && !*endpoint.Conditions.Ready {
+				continue
+			}
+			for _, address := range endpoint.Addresses {
+				for _, port := range r.ports {
+					unique[net.JoinHostPort(address, strconv.Itoa(int(port)))] = struct{}{}
+				}
+			}
+		}
+	}
+
+	backends := make([]string, 0, len(unique))
+	for backend := range unique {
+		backends = append(backends, backend)
+	}
+
+	// keep it always in the same order
+	sort.Strings(backends)
+
+	// resolutions are triggered by the informer as well, make sure the callbacks
+	// see the changes in the order they happened
+	r.updateLock.Lock()
+	defer r.updateLock.Unlock()
+
+	if equalStringSlice(r.endpoints, backends) {
+		return r.endpoints, nil
+	}
+
+	// the list has changed!
+	r.endpoints = backends
+	stats.Record(mCtx, mNumBackends.M(int64(len(backends))))
+
+	// propagate the change
+	r.changeCallbackLock.RLock()
+	for _, callback := range r.onChangeCallbacks {
+		callback(r.endpoints)
+	}
+	r.changeCallbackLock.RUnlock()
+
+	return r.endpoints, nil
+}
+
+func (r *k8sResolver) onChange(f func([]string)) {
+	r.changeCallbackLock.Lock()
+	defer r.changeCallbackLock.Unlock()
+	r.onChangeCallbacks = append(r.onChangeCallbacks, f)
+}
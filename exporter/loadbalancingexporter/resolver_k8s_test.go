@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newEndpointSlice(name, namespace, service string, addresses ...string) *discoveryv1.EndpointSlice {
+	slice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				discoveryv1.LabelServiceName: service,
+			},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+	}
+	for _, address := range addresses {
+		slice.Endpoints = append(slice.Endpoints, discoveryv1.Endpoint{
+			Addresses: []string{address},
+		})
+	}
+	return slice
+}
+
+func TestInitialK8sResolution(t *testing.T) {
+	// prepare
+	clt := fake.NewSimpleClientset(
+		newEndpointSlice("lb-abc", "monitoring", "lb", "10.0.0.2", "10.0.0.1"),
+		newEndpointSlice("lb-def", "monitoring", "lb", "10.0.0.3"),
+		newEndpointSlice("other-abc", "monitoring", "other", "10.0.1.1"),
+		newEndpointSlice("lb-abc", "default", "lb", "10.0.2.1"),
+	)
+	res, err := newK8sResolver(clt, zap.NewNop(), "lb.monitoring", []int32{4317, 55690})
+	require.NoError(t, err)
+
+	// test
+	var resolved []string
+	res.onChange(func(endpoints []string) {
+		resolved = endpoints
+	})
+	require.NoError(t, res.start(context.Background()))
+	defer func() {
+		require.NoError(t, res.shutdown(context.Background()))
+	}()
+
+	// verify
+	assert.Equal(t, []string{
+		"10.0.0.1:4317", "10.0.0.1:55690",
+		"10.0.0.2:4317", "10.0.0.2:55690",
+		"10.0.0.3:4317", "10.0.0.3:55690",
+	}, resolved)
+}
+
+func TestK8sResolutionSkipsEndpointsNotReady(t *testing.T) {
+	// prepare
+	slice := newEndpointSlice("lb-abc", "default", "lb", "10.0.0.1", "10.0.0.2")
+	notReady := false
+	slice.Endpoints[1].Conditions.Ready = &notReady
+
+	res, err := newK8sResolver(fake.NewSimpleClientset(slice), zap.NewNop(), "lb", nil)
+	require.NoError(t, err)
+
+	// test
+	require.NoError(t, res.start(context.Background()))
+	defer func() {
+		require.NoError(t, res.shutdown(context.Background()))
+	}()
+	resolved, err := res.resolve(context.Background())
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1:4317"}, resolved)
+}
+
+func TestK8sResolverOnChange(t *testing.T) {
+	// prepare
+	slice := newEndpointSlice("lb-abc", "default", "lb", "10.0.0.1")
+	clt := fake.NewSimpleClientset(slice)
+	res, err := newK8sResolver(clt, zap.NewNop(), "lb", nil)
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var resolved []string
+	res.onChange(func(endpoints []string) {
+		mu.Lock()
+		defer mu.Unlock()
+		resolved = endpoints
+	})
+	latest := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return resolved
+	}
+
+	require.NoError(t, res.start(context.Background()))
+	defer func() {
+		require.NoError(t, res.shutdown(context.Background()))
+	}()
+	require.Equal(t, []string{"10.0.0.1:4317"}, latest())
+
+	// test: a new pod becomes part of the service
+	updated := newEndpointSlice("lb-abc", "default", "lb", "10.0.0.1", "10.0.0.2")
+	_, err = clt.DiscoveryV1().EndpointSlices("default").Update(context.Background(), updated, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	// verify
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{"10.0.0.1:4317", "10.0.0.2:4317"}, latest())
+	}, time.Second, 10*time.Millisecond)
+
+	// test: the slice is removed
+	err = clt.DiscoveryV1().EndpointSlices("default").Delete(context.Background(), "lb-abc", metav1.DeleteOptions{})
+	require.NoError(t, err)
+
+	// verify
+	assert.Eventually(t, func() bool {
+		return len(latest()) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestErrNoSvc(t *testing.T) {
+	// test
+	res, err := newK8sResolver(fake.NewSimpleClientset(), zap.NewNop(), "", nil)
+
+	// verify
+	assert.Nil(t, res)
+	assert.Equal(t, errNoSvc, err)
+}
+
+func TestK8sResolverInvalidPort(t *testing.T) {
+	// test
+	res, err := newK8sResolver(fake.NewSimpleClientset(), zap.NewNop(), "lb", []int32{0})
+
+	// verify
+	assert.Nil(t, res)
+	assert.ErrorIs(t, err, errInvalidPort)
+}
+
+func TestK8sResolverDefaultNamespace(t *testing.T) {
+	// test
+	res, err := newK8sResolver(fake.NewSimpleClientset(), zap.NewNop(), "lb", nil)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, "lb", res.svcName)
+	assert.Equal(t, defaultK8sNamespace, res.svcNs)
+	assert.Equal(t, []int32{defaultK8sPort}, res.ports)
+}
+
+func TestK8sResolverNoResolutionAfterShutdown(t *testing.T) {
+	// prepare
+	slice := newEndpointSlice("lb-abc", "default", "lb", "10.0.0.1")
+	res, err := newK8sResolver(fake.NewSimpleClientset(slice), zap.NewNop(), "lb", nil)
+	require.NoError(t, err)
+
+	require.NoError(t, res.start(context.Background()))
+	require.NoError(t, res.shutdown(context.Background()))
+
+	var called bool
+	res.onChange(func([]string) {
+		called = true
+	})
+
+	// test
+	res.endpoints = nil
+	resolved, err := res.resolve(context.Background())
+
+	// verify
+	require.NoError(t, err)
+	assert.Empty(t, resolved)
+	assert.False(t, called)
+}
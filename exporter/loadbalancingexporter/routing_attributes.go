@@ -0,0 +1,256 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// attributesKeySeparator separates the values of the different routing attributes in a routing key
+const attributesKeySeparator = "\x00"
+
+// routingKeyFromAttributes builds the routing key out of the values of the given attributes.
+// Each attribute is looked up in the resource attributes first, then in the record (span or log record) attributes.
+// Attributes that can't be found contribute an empty value to the key.
+func routingKeyFromAttributes(names []string, resource pcommon.Map, record pcommon.Map) string {
+	values := make([]string, len(names))
+	for i, name := range names {
+		if v, ok := resource.Get(name); ok {
+			values[i] = v.AsString()
+			continue
+		}
+		if v, ok := record.Get(name); ok {
+			values[i] = v.AsString()
+		}
+	}
+	return strings.Join(values, attributesKeySeparator)
+}
+
+// attributesKey builds a key out of all the given attributes, in a stable order
+func attributesKey(attrs pcommon.Map) string {
+	pairs := make([]string, 0, attrs.Len())
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		pairs = append(pairs, k+"="+v.AsString())
+		return true
+	})
+	sort.Strings(pairs)
+	return strings.Join(pairs, attributesKeySeparator)
+}
+
+// splitTracesByAttributes splits the given traces into batches holding the spans
+// that share the same routing key, which is then used as the key of the returned map.
+func splitTracesByAttributes(td ptrace.Traces, names []string) map[string]ptrace.Traces {
+	batches := map[string]ptrace.Traces{}
+
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		newRSs := map[string]ptrace.ResourceSpans{}
+
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			newSSs := map[string]ptrace.ScopeSpans{}
+
+			for k := 0; k < ss.Spans().Len(); k++ {
+				span := ss.Spans().At(k)
+				key := routingKeyFromAttributes(names, rs.Resource().Attributes(), span.Attributes())
+
+				newSS, ok := newSSs[key]
+				if !ok {
+					newRS, found := newRSs[key]
+					if !found {
+						batch, exists := batches[key]
+						if !exists {
+							batch = ptrace.NewTraces()
+							batches[key] = batch
+						}
+						newRS = batch.ResourceSpans().AppendEmpty()
+						rs.Resource().CopyTo(newRS.Resource())
+						newRS.SetSchemaUrl(rs.SchemaUrl())
+						newRSs[key] = newRS
+					}
+					newSS = newRS.ScopeSpans().AppendEmpty()
+					ss.Scope().CopyTo(newSS.Scope())
+					newSS.SetSchemaUrl(ss.SchemaUrl())
+					newSSs[key] = newSS
+				}
+				span.CopyTo(newSS.Spans().AppendEmpty())
+			}
+		}
+	}
+
+	return batches
+}
+
+// splitLogsByAttributes splits the given logs into batches holding the log records
+// that share the same routing key, which is then used as the key of the returned map.
+func splitLogsByAttributes(ld plog.Logs, names []string) map[string]plog.Logs {
+	batches := map[string]plog.Logs{}
+
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		newRLs := map[string]plog.ResourceLogs{}
+
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			newSLs := map[string]plog.ScopeLogs{}
+
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				log := sl.LogRecords().At(k)
+				key := routingKeyFromAttributes(names, rl.Resource().Attributes(), log.Attributes())
+
+				newSL, ok := newSLs[key]
+				if !ok {
+					newRL, found := newRLs[key]
+					if !found {
+						batch, exists := batches[key]
+						if !exists {
+							batch = plog.NewLogs()
+							batches[key] = batch
+						}
+						newRL = batch.ResourceLogs().AppendEmpty()
+						rl.Resource().CopyTo(newRL.Resource())
+						newRL.SetSchemaUrl(rl.SchemaUrl())
+						newRLs[key] = newRL
+					}
+					newSL = newRL.ScopeLogs().AppendEmpty()
+					sl.Scope().CopyTo(newSL.Scope())
+					newSL.SetSchemaUrl(sl.SchemaUrl())
+					newSLs[key] = newSL
+				}
+				log.CopyTo(newSL.LogRecords().AppendEmpty())
+			}
+		}
+	}
+
+	return batches
+}
+
+// metricsKeyFunc computes the routing key for a data point of the given metric
+type metricsKeyFunc func(resource pcommon.Resource, metric pmetric.Metric, attrs pcommon.Map) string
+
+// splitMetrics splits the given metrics into batches holding the data points
+// that share the same routing key, which is then used as the key of the returned map.
+func splitMetrics(md pmetric.Metrics, keyFn metricsKeyFunc) map[string]pmetric.Metrics {
+	batches := map[string]pmetric.Metrics{}
+
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		newRMs := map[string]pmetric.ResourceMetrics{}
+
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			newSMs := map[string]pmetric.ScopeMetrics{}
+
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				metric := sm.Metrics().At(k)
+				newMetrics := map[string]pmetric.Metric{}
+
+				// metricFor returns the metric the data points with the given key should be copied to
+				metricFor := func(key string) pmetric.Metric {
+					if newMetric, ok := newMetrics[key]; ok {
+						return newMetric
+					}
+					newSM, ok := newSMs[key]
+					if !ok {
+						newRM, found := newRMs[key]
+						if !found {
+							batch, exists := batches[key]
+							if !exists {
+								batch = pmetric.NewMetrics()
+								batches[key] = batch
+							}
+							newRM = batch.ResourceMetrics().AppendEmpty()
+							rm.Resource().CopyTo(newRM.Resource())
+							newRM.SetSchemaUrl(rm.SchemaUrl())
+							newRMs[key] = newRM
+						}
+						newSM = newRM.ScopeMetrics().AppendEmpty()
+						sm.Scope().CopyTo(newSM.Scope())
+						newSM.SetSchemaUrl(sm.SchemaUrl())
+						newSMs[key] = newSM
+					}
+					newMetric := newSM.Metrics().AppendEmpty()
+					copyMetricDescription(metric, newMetric)
+					newMetrics[key] = newMetric
+					return newMetric
+				}
+
+				switch metric.DataType() {
+				case pmetric.MetricDataTypeGauge:
+					dps := metric.Gauge().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						key := keyFn(rm.Resource(), metric, dps.At(l).Attributes())
+						dps.At(l).CopyTo(metricFor(key).Gauge().DataPoints().AppendEmpty())
+					}
+				case pmetric.MetricDataTypeSum:
+					dps := metric.Sum().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						key := keyFn(rm.Resource(), metric, dps.At(l).Attributes())
+						dps.At(l).CopyTo(metricFor(key).Sum().DataPoints().AppendEmpty())
+					}
+				case pmetric.MetricDataTypeHistogram:
+					dps := metric.Histogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						key := keyFn(rm.Resource(), metric, dps.At(l).Attributes())
+						dps.At(l).CopyTo(metricFor(key).Histogram().DataPoints().AppendEmpty())
+					}
+				case pmetric.MetricDataTypeExponentialHistogram:
+					dps := metric.ExponentialHistogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						key := keyFn(rm.Resource(), metric, dps.At(l).Attributes())
+						dps.At(l).CopyTo(metricFor(key).ExponentialHistogram().DataPoints().AppendEmpty())
+					}
+				case pmetric.MetricDataTypeSummary:
+					dps := metric.Summary().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						key := keyFn(rm.Resource(), metric, dps.At(l).Attributes())
+						dps.At(l).CopyTo(metricFor(key).Summary().DataPoints().AppendEmpty())
+					}
+				case pmetric.MetricDataTypeNone:
+				}
+			}
+		}
+	}
+
+	return batches
+}
+
+// copyMetricDescription copies everything but the data points from the source metric to the destination metric
+func copyMetricDescription(src, dest pmetric.Metric) {
+	dest.SetName(src.Name())
+	dest.SetDescription(src.Description())
+	dest.SetUnit(src.Unit())
+	dest.SetDataType(src.DataType())
+
+	switch src.DataType() {
+	case pmetric.MetricDataTypeSum:
+		dest.Sum().SetAggregationTemporality(src.Sum().AggregationTemporality())
+		dest.Sum().SetIsMonotonic(src.Sum().IsMonotonic())
+	case pmetric.MetricDataTypeHistogram:
+		dest.Histogram().SetAggregationTemporality(src.Histogram().AggregationTemporality())
+	case pmetric.MetricDataTypeExponentialHistogram:
+		dest.ExponentialHistogram().SetAggregationTemporality(src.ExponentialHistogram().AggregationTemporality())
+	case pmetric.MetricDataTypeGauge, pmetric.MetricDataTypeSummary, pmetric.MetricDataTypeNone:
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestRoutingKeyFromAttributes(t *testing.T) {
+	resource := pcommon.NewMap()
+	resource.InsertString("tenant", "acme")
+	resource.InsertString("shared", "from-resource")
+	record := pcommon.NewMap()
+	record.InsertString("http.method", "GET")
+	record.InsertString("shared", "from-record")
+	record.InsertInt("http.status_code", 200)
+
+	for _, tt := range []struct {
+		desc  string
+		names []string
+		key   string
+	}{
+		{
+			"resource attribute",
+			[]string{"tenant"},
+			"acme",
+		},
+		{
+			"resource and record attributes",
+			[]string{"tenant", "http.method", "http.status_code"},
+			"acme\x00GET\x00200",
+		},
+		{
+			"resource attributes take precedence",
+			[]string{"shared"},
+			"from-resource",
+		},
+		{
+			"missing attribute",
+			[]string{"tenant", "missing"},
+			"acme\x00",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.key, routingKeyFromAttributes(tt.names, resource, record))
+		})
+	}
+}
+
+func TestSplitTracesByAttributes(t *testing.T) {
+	// prepare
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("tenant", "acme")
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("scope-1")
+	ss.Spans().AppendEmpty().SetName("span-1")
+	ss.Spans().AppendEmpty().SetName("span-2")
+	ss = rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("scope-2")
+	ss.Spans().AppendEmpty().SetName("span-3")
+
+	rs = td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("tenant", "globex")
+	rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span-4")
+
+	// test
+	batches := splitTracesByAttributes(td, []string{"tenant"})
+
+	// verify
+	require.Len(t, batches, 2)
+
+	acme := batches["acme"]
+	require.Equal(t, 1, acme.ResourceSpans().Len())
+	assert.Equal(t, 3, acme.SpanCount())
+	require.Equal(t, 2, acme.ResourceSpans().At(0).ScopeSpans().Len())
+	assert.Equal(t, "scope-1", acme.ResourceSpans().At(0).ScopeSpans().At(0).Scope().Name())
+	assert.Equal(t, "scope-2", acme.ResourceSpans().At(0).ScopeSpans().At(1).Scope().Name())
+
+	globex := batches["globex"]
+	require.Equal(t, 1, globex.ResourceSpans().Len())
+	assert.Equal(t, 1, globex.SpanCount())
+	tenant, ok := globex.ResourceSpans().At(0).Resource().Attributes().Get("tenant")
+	require.True(t, ok)
+	assert.Equal(t, "globex", tenant.StringVal())
+}
+
+func TestSplitLogsByAttributes(t *testing.T) {
+	// prepare
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString("tenant", "acme")
+	logs := rl.ScopeLogs().AppendEmpty().LogRecords()
+	logs.AppendEmpty().Attributes().InsertString("level", "info")
+	logs.AppendEmpty().Attributes().InsertString("level", "error")
+	logs.AppendEmpty().Attributes().InsertString("level", "info")
+
+	// test
+	batches := splitLogsByAttributes(ld, []string{"tenant", "level"})
+
+	// verify
+	require.Len(t, batches, 2)
+	assert.Equal(t, 2, batches["acme\x00info"].LogRecordCount())
+	assert.Equal(t, 1, batches["acme\x00error"].LogRecordCount())
+	assert.Equal(t, 1, batches["acme\x00info"].ResourceLogs().Len())
+}
+
+func TestSplitMetricsByStreamID(t *testing.T) {
+	// test
+	batches := splitMetrics(twoServicesMetrics(), streamIDKey)
+
+	// verify
+	require.Len(t, batches, 4)
+	for _, batch := range batches {
+		require.Equal(t, 1, batch.ResourceMetrics().Len())
+		require.Equal(t, 1, batch.ResourceMetrics().At(0).ScopeMetrics().Len())
+		require.Equal(t, 1, batch.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().Len())
+		assert.Equal(t, 1, batch.DataPointCount())
+
+		metric := batch.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+		if metric.Name() == "requests" {
+			require.Equal(t, pmetric.MetricDataTypeSum, metric.DataType())
+			assert.Equal(t, pmetric.MetricAggregationTemporalityCumulative, metric.Sum().AggregationTemporality())
+			assert.True(t, metric.Sum().IsMonotonic())
+		}
+	}
+}
+
+func TestSplitMetricsByService(t *testing.T) {
+	// test
+	batches := splitMetrics(twoServicesMetrics(), serviceKey)
+
+	// verify
+	require.Len(t, batches, 2)
+	assert.Equal(t, 3, batches["service-1"].DataPointCount())
+	assert.Equal(t, 2, batches["service-1"].MetricCount())
+	assert.Equal(t, 1, batches["service-2"].DataPointCount())
+}
@@ -35,9 +35,12 @@ import (
 
 var _ component.TracesExporter = (*traceExporterImp)(nil)
 
+var errNoRoutingAttributes = errors.New("no routing_attributes specified for the attributes routing_key")
+
 type traceExporterImp struct {
 	loadBalancer loadBalancer
 	routingKey   routingKey
+	routingAttrs []string
 
 	stopped    bool
 	shutdownWg sync.WaitGroup
@@ -60,6 +63,12 @@ func newTracesExporter(params component.ExporterCreateSettings, cfg config.Expor
 	switch cfg.(*Config).RoutingKey {
 	case "service":
 		traceExporter.routingKey = svcRouting
+	case "attributes":
+		if len(cfg.(*Config).RoutingAttributes) == 0 {
+			return nil, errNoRoutingAttributes
+		}
+		traceExporter.routingKey = attrRouting
+		traceExporter.routingAttrs = cfg.(*Config).RoutingAttributes
 	case "traceID", "":
 	default:
 		return nil, fmt.Errorf("unsupported routing_key: %s", cfg.(*Config).RoutingKey)
@@ -82,14 +91,22 @@ func (e *traceExporterImp) Start(ctx context.Context, host component.Host) error
 	return e.loadBalancer.Start(ctx, host)
 }
 
-func (e *traceExporterImp) Shutdown(context.Context) error {
+func (e *traceExporterImp) Shutdown(ctx context.Context) error {
+	err := e.loadBalancer.Shutdown(ctx)
 	e.stopped = true
 	e.shutdownWg.Wait()
-	return nil
+	return err
 }
 
 func (e *traceExporterImp) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
 	var errs error
+	if e.routingKey == attrRouting {
+		for key, batch := range splitTracesByAttributes(td, e.routingAttrs) {
+			errs = multierr.Append(errs, e.exportTrace(ctx, batch, key))
+		}
+		return errs
+	}
+
 	batches := batchpersignal.SplitTraces(td)
 	for _, batch := range batches {
 		errs = multierr.Append(errs, e.consumeTrace(ctx, batch))
@@ -99,36 +116,42 @@ func (e *traceExporterImp) ConsumeTraces(ctx context.Context, td ptrace.Traces)
 }
 
 func (e *traceExporterImp) consumeTrace(ctx context.Context, td ptrace.Traces) error {
-	var exp component.Exporter
 	routingIds, err := routingIdentifiersFromTraces(td, e.routingKey)
 	if err != nil {
 		return err
 	}
+	var errs error
 	for rid := range routingIds {
-		endpoint := e.loadBalancer.Endpoint([]byte(rid))
-		exp, err = e.loadBalancer.Exporter(endpoint)
-		if err != nil {
-			return err
-		}
+		errs = multierr.Append(errs, e.exportTrace(ctx, td, rid))
+	}
+	return errs
+}
 
-		te, ok := exp.(component.TracesExporter)
-		if !ok {
-			expectType := (*component.TracesExporter)(nil)
-			return fmt.Errorf("expected %T but got %T", expectType, exp)
-		}
+// exportTrace sends the traces to the backend responsible for the given routing identifier.
+func (e *traceExporterImp) exportTrace(ctx context.Context, td ptrace.Traces, rid string) error {
+	endpoint := e.loadBalancer.Endpoint([]byte(rid))
+	exp, err := e.loadBalancer.Exporter(endpoint)
+	if err != nil {
+		return err
+	}
 
-		start := time.Now()
-		err = te.ConsumeTraces(ctx, td)
-		duration := time.Since(start)
-		ctx, _ = tag.New(ctx, tag.Upsert(tag.MustNewKey("endpoint"), endpoint))
-
-		if err == nil {
-			sCtx, _ := tag.New(ctx, tag.Upsert(tag.MustNewKey("success"), "true"))
-			stats.Record(sCtx, mBackendLatency.M(duration.Milliseconds()))
-		} else {
-			fCtx, _ := tag.New(ctx, tag.Upsert(tag.MustNewKey("success"), "false"))
-			stats.Record(fCtx, mBackendLatency.M(duration.Milliseconds()))
-		}
+	te, ok := exp.(component.TracesExporter)
+	if !ok {
+		expectType := (*component.TracesExporter)(nil)
+		return fmt.Errorf("expected %T but got %T", expectType, exp)
+	}
+
+	start := time.Now()
+	err = te.ConsumeTraces(ctx, td)
+	duration := time.Since(start)
+	ctx, _ = tag.New(ctx, tag.Upsert(tag.MustNewKey("endpoint"), endpoint))
+
+	if err == nil {
+		sCtx, _ := tag.New(ctx, tag.Upsert(tag.MustNewKey("success"), "true"))
+		stats.Record(sCtx, mBackendLatency.M(duration.Milliseconds()))
+	} else {
+		fCtx, _ := tag.New(ctx, tag.Upsert(tag.MustNewKey("success"), "false"))
+		stats.Record(fCtx, mBackendLatency.M(duration.Milliseconds()))
 	}
 	return err
 }
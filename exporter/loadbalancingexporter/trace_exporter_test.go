@@ -56,6 +56,15 @@ func TestNewTracesExporter(t *testing.T) {
 			&Config{},
 			errNoResolver,
 		},
+		{
+			"attributes without routing attributes",
+			func() *Config {
+				cfg := simpleConfig()
+				cfg.RoutingKey = "attributes"
+				return cfg
+			}(),
+			errNoRoutingAttributes,
+		},
 	} {
 		t.Run(tt.desc, func(t *testing.T) {
 			// test
@@ -198,6 +207,53 @@ func TestConsumeTracesServiceBased(t *testing.T) {
 	assert.Nil(t, res)
 }
 
+func TestConsumeTracesAttributeBased(t *testing.T) {
+	componentFactory := func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		return newNopMockTracesExporter(), nil
+	}
+	lb, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), attributeBasedRoutingConfig(), componentFactory)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newTracesExporter(componenttest.NewNopExporterCreateSettings(), attributeBasedRoutingConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+	assert.Equal(t, p.routingKey, attrRouting)
+
+	// pre-load an exporter here, so that we don't use the actual OTLP exporter
+	sink := new(consumertest.TracesSink)
+	lb.exporters["endpoint-1"] = newMockTracesExporter(sink.ConsumeTraces)
+	lb.res = &mockResolver{
+		triggerCallbacks: true,
+		onResolve: func(ctx context.Context) ([]string, error) {
+			return []string{"endpoint-1"}, nil
+		},
+	}
+	p.loadBalancer = lb
+
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("tenant", "acme")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+	spans.AppendEmpty().Attributes().InsertString("http.method", "GET")
+	spans.AppendEmpty().Attributes().InsertString("http.method", "POST")
+	spans.AppendEmpty().Attributes().InsertString("http.method", "GET")
+
+	// test
+	res := p.ConsumeTraces(context.Background(), td)
+
+	// verify
+	assert.Nil(t, res)
+	require.Len(t, sink.AllTraces(), 2, "spans should be split per routing key")
+	assert.Equal(t, 3, sink.SpanCount())
+}
+
 func TestServiceBasedRoutingForSameTraceId(t *testing.T) {
 	b := pcommon.NewTraceID([16]byte{1, 2, 3, 4}).Bytes()
 	for _, tt := range []struct {
@@ -649,6 +705,17 @@ func serviceBasedRoutingConfig() *Config {
 	}
 }
 
+func attributeBasedRoutingConfig() *Config {
+	return &Config{
+		ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+		Resolver: ResolverSettings{
+			Static: &StaticResolver{Hostnames: []string{"endpoint-1"}},
+		},
+		RoutingKey:        "attributes",
+		RoutingAttributes: []string{"tenant", "http.method"},
+	}
+}
+
 type mockTracesExporter struct {
 	component.Component
 	ConsumeTracesFn func(ctx context.Context, td ptrace.Traces) error
@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+const (
+	defaultMaxFileSize      = 128 * 1024 * 1024
+	defaultRolloverInterval = 5 * time.Minute
+)
+
+// Config defines configuration for the Parquet exporter.
+type Config struct {
+	config.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Path is the directory the Parquet files are written to. It is created if it does not exist.
+	Path string `mapstructure:"path"`
+
+	// MaxFileSize is the size in bytes after which the current file is closed and a new one is started.
+	// The size is checked after each batch is written, so a file may exceed it by up to one batch.
+	MaxFileSize int64 `mapstructure:"max_file_size"`
+
+	// RolloverInterval is the maximum amount of time a file is kept open before it is closed,
+	// even if it has not reached MaxFileSize.
+	RolloverInterval time.Duration `mapstructure:"rollover_interval"`
+}
+
+var _ config.Exporter = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Path == "" {
+		return errors.New("path must be non-empty")
+	}
+	if cfg.MaxFileSize <= 0 {
+		return errors.New("max_file_size must be positive")
+	}
+	if cfg.RolloverInterval <= 0 {
+		return errors.New("rollover_interval must be positive")
+	}
+	return nil
+}
@@ -16,33 +16,52 @@ package parquetexporter // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"context"
+	"os"
 
+	"github.com/segmentio/parquet-go"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+const (
+	tracesSignal  = "traces"
+	metricsSignal = "metrics"
+	logsSignal    = "logs"
 )
 
 type parquetExporter struct {
-	path string
+	path   string
+	writer *rollingWriter
+}
+
+// newParquetExporter creates an exporter that writes rows shaped like model to files
+// prefixed with the name of the signal.
+func newParquetExporter(cfg *Config, logger *zap.Logger, signal string, model interface{}) *parquetExporter {
+	return &parquetExporter{
+		path:   cfg.Path,
+		writer: newRollingWriter(cfg.Path, signal, parquet.SchemaOf(model), cfg.MaxFileSize, cfg.RolloverInterval, logger),
+	}
 }
 
-func (e parquetExporter) start(ctx context.Context, host component.Host) error {
-	return nil
+func (e *parquetExporter) start(ctx context.Context, host component.Host) error {
+	return os.MkdirAll(e.path, 0750)
 }
 
-func (e parquetExporter) shutdown(ctx context.Context) error {
-	return nil
+func (e *parquetExporter) shutdown(ctx context.Context) error {
+	return e.writer.close()
 }
 
-func (e parquetExporter) consumeMetrics(ctx context.Context, ld pmetric.Metrics) error {
-	return nil
+func (e *parquetExporter) consumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	return e.writer.write(metricsToRows(md))
 }
 
-func (e parquetExporter) consumeTraces(ctx context.Context, ld ptrace.Traces) error {
-	return nil
+func (e *parquetExporter) consumeTraces(ctx context.Context, td ptrace.Traces) error {
+	return e.writer.write(tracesToRows(td))
 }
 
-func (e parquetExporter) consumeLogs(ctx context.Context, ld plog.Logs) error {
-	return nil
+func (e *parquetExporter) consumeLogs(ctx context.Context, ld plog.Logs) error {
+	return e.writer.write(logsToRows(ld))
 }
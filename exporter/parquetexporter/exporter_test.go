@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/segmentio/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
+)
+
+func newTestExporter(t *testing.T, signal string, model interface{}, modify func(*Config)) *parquetExporter {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Path = filepath.Join(t.TempDir(), "out")
+	if modify != nil {
+		modify(cfg)
+	}
+	e := newParquetExporter(cfg, zap.NewNop(), signal, model)
+	require.NoError(t, e.start(context.Background(), componenttest.NewNopHost()))
+	return e
+}
+
+func completeFiles(t *testing.T, dir string) []string {
+	files, err := filepath.Glob(filepath.Join(dir, "*.parquet"))
+	require.NoError(t, err)
+	return files
+}
+
+func readRows[T any](t *testing.T, path string) []T {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	reader := parquet.NewReader(f)
+	var rows []T
+	for {
+		var row T
+		err = reader.Read(&row)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		rows = append(rows, row)
+	}
+	require.NoError(t, reader.Close())
+	return rows
+}
+
+func TestParquetTracesExporter(t *testing.T) {
+	e := newTestExporter(t, tracesSignal, spanRecord{}, nil)
+
+	td := testdata.GenerateTracesTwoSpansSameResource()
+	require.NoError(t, e.consumeTraces(context.Background(), td))
+	// The file is only visible under its final name once it is complete.
+	assert.Empty(t, completeFiles(t, e.path))
+	require.NoError(t, e.shutdown(context.Background()))
+
+	files := completeFiles(t, e.path)
+	require.Len(t, files, 1)
+	assert.Contains(t, filepath.Base(files[0]), "traces-")
+
+	rows := readRows[spanRecord](t, files[0])
+	require.Len(t, rows, 2)
+	assert.Equal(t, "operationA", rows[0].Name)
+	assert.Equal(t, map[string]string{"resource-attr": "resource-attr-val-1"}, rows[0].ResourceAttributes)
+	assert.Equal(t, int64(testdata.TestSpanStartTimestamp), rows[0].StartTimeUnixNano)
+	assert.Equal(t, "status-cancelled", rows[0].StatusMessage)
+	require.Len(t, rows[0].Events, 2)
+	assert.Equal(t, "event-with-attr", rows[0].Events[0].Name)
+	assert.Equal(t, "operationB", rows[1].Name)
+	require.Len(t, rows[1].Links, 2)
+	assert.Equal(t, int64(3), rows[1].DroppedLinksCount)
+}
+
+func TestParquetMetricsExporter(t *testing.T) {
+	e := newTestExporter(t, metricsSignal, dataPointRecord{}, nil)
+
+	md := testdata.GenerateMetricsAllTypesEmptyDataPoint()
+	require.NoError(t, e.consumeMetrics(context.Background(), md))
+	require.NoError(t, e.shutdown(context.Background()))
+
+	files := completeFiles(t, e.path)
+	require.Len(t, files, 1)
+
+	rows := readRows[dataPointRecord](t, files[0])
+	require.Len(t, rows, md.DataPointCount())
+	for _, row := range rows {
+		assert.NotEmpty(t, row.MetricName)
+		assert.NotEmpty(t, row.MetricType)
+	}
+}
+
+func TestParquetLogsExporter(t *testing.T) {
+	e := newTestExporter(t, logsSignal, logRecord{}, nil)
+
+	ld := testdata.GenerateLogsTwoLogRecordsSameResource()
+	require.NoError(t, e.consumeLogs(context.Background(), ld))
+	require.NoError(t, e.shutdown(context.Background()))
+
+	files := completeFiles(t, e.path)
+	require.Len(t, files, 1)
+
+	rows := readRows[logRecord](t, files[0])
+	require.Len(t, rows, 2)
+	assert.Equal(t, "This is a log message", rows[0].Body)
+	assert.Equal(t, "Info", rows[0].SeverityText)
+	assert.Equal(t, "server", rows[0].Attributes["app"])
+	assert.Equal(t, "something happened", rows[1].Body)
+}
+
+func TestParquetExporterRollsOverBySize(t *testing.T) {
+	e := newTestExporter(t, logsSignal, logRecord{}, func(cfg *Config) {
+		cfg.MaxFileSize = 1
+	})
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, e.consumeLogs(context.Background(), testdata.GenerateLogsOneLogRecord()))
+		// Every batch exceeds the maximum size, so every file is closed right after it is written.
+		assert.Len(t, completeFiles(t, e.path), i+1)
+	}
+	require.NoError(t, e.shutdown(context.Background()))
+	assert.Len(t, completeFiles(t, e.path), 3)
+}
+
+func TestParquetExporterRollsOverByTime(t *testing.T) {
+	e := newTestExporter(t, logsSignal, logRecord{}, func(cfg *Config) {
+		cfg.RolloverInterval = 10 * time.Millisecond
+	})
+
+	require.NoError(t, e.consumeLogs(context.Background(), testdata.GenerateLogsOneLogRecord()))
+	assert.Eventually(t, func() bool {
+		return len(completeFiles(t, e.path)) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, e.consumeLogs(context.Background(), testdata.GenerateLogsOneLogRecord()))
+	require.NoError(t, e.shutdown(context.Background()))
+	assert.Len(t, completeFiles(t, e.path), 2)
+}
+
+func TestParquetExporterEmptyBatch(t *testing.T) {
+	e := newTestExporter(t, tracesSignal, spanRecord{}, nil)
+
+	require.NoError(t, e.consumeTraces(context.Background(), testdata.GenerateTracesOneEmptyResourceSpans()))
+	require.NoError(t, e.shutdown(context.Background()))
+	assert.Empty(t, completeFiles(t, e.path))
+}
@@ -29,11 +29,6 @@ const (
 	stability = component.StabilityLevelInDevelopment
 )
 
-type Config struct {
-	config.ExporterSettings `mapstructure:",squash"`
-	Path                    string `mapstructure:"path"`
-}
-
 // NewFactory creates a factory for the Parquet exporter.
 func NewFactory() component.ExporterFactory {
 	return component.NewExporterFactory(
@@ -47,6 +42,8 @@ func NewFactory() component.ExporterFactory {
 func createDefaultConfig() config.Exporter {
 	return &Config{
 		ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+		MaxFileSize:      defaultMaxFileSize,
+		RolloverInterval: defaultRolloverInterval,
 	}
 }
 
@@ -55,7 +52,7 @@ func createTracesExporter(
 	set component.ExporterCreateSettings,
 	cfg config.Exporter,
 ) (component.TracesExporter, error) {
-	fe := &parquetExporter{path: cfg.(*Config).Path}
+	fe := newParquetExporter(cfg.(*Config), set.Logger, tracesSignal, spanRecord{})
 	return exporterhelper.NewTracesExporter(
 		ctx,
 		set,
@@ -71,7 +68,7 @@ func createMetricsExporter(
 	set component.ExporterCreateSettings,
 	cfg config.Exporter,
 ) (component.MetricsExporter, error) {
-	fe := &parquetExporter{path: cfg.(*Config).Path}
+	fe := newParquetExporter(cfg.(*Config), set.Logger, metricsSignal, dataPointRecord{})
 	return exporterhelper.NewMetricsExporter(
 		ctx,
 		set,
@@ -87,7 +84,7 @@ func createLogsExporter(
 	set component.ExporterCreateSettings,
 	cfg config.Exporter,
 ) (component.LogsExporter, error) {
-	fe := &parquetExporter{path: cfg.(*Config).Path}
+	fe := newParquetExporter(cfg.(*Config), set.Logger, logsSignal, logRecord{})
 	return exporterhelper.NewLogsExporter(
 		ctx,
 		set,
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// logRecord is a row of a logs file. There is one row per log record.
+type logRecord struct {
+	ServiceName            string            `parquet:"service_name"`
+	ResourceAttributes     map[string]string `parquet:"resource_attributes"`
+	ScopeName              string            `parquet:"scope_name"`
+	ScopeVersion           string            `parquet:"scope_version"`
+	TimeUnixNano           int64             `parquet:"time_unix_nano"`
+	ObservedTimeUnixNano   int64             `parquet:"observed_time_unix_nano"`
+	SeverityNumber         int32             `parquet:"severity_number"`
+	SeverityText           string            `parquet:"severity_text"`
+	Body                   string            `parquet:"body"`
+	Attributes             map[string]string `parquet:"attributes"`
+	DroppedAttributesCount int64             `parquet:"dropped_attributes_count"`
+	Flags                  int64             `parquet:"flags"`
+	TraceID                string            `parquet:"trace_id"`
+	SpanID                 string            `parquet:"span_id"`
+}
+
+func logsToRows(ld plog.Logs) []interface{} {
+	rows := make([]interface{}, 0, ld.LogRecordCount())
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resource := rl.Resource()
+		resourceAttributes := attributesToMap(resource.Attributes())
+		service := serviceName(resource)
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
+			scope := sl.Scope()
+			lrs := sl.LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				lr := lrs.At(k)
+				var traceID, spanID string
+				if !lr.TraceID().IsEmpty() {
+					traceID = lr.TraceID().HexString()
+				}
+				if !lr.SpanID().IsEmpty() {
+					spanID = lr.SpanID().HexString()
+				}
+				rows = append(rows, &logRecord{
+					ServiceName:            service,
+					ResourceAttributes:     resourceAttributes,
+					ScopeName:              scope.Name(),
+					ScopeVersion:           scope.Version(),
+					TimeUnixNano:           int64(lr.Timestamp()),
+					ObservedTimeUnixNano:   int64(lr.ObservedTimestamp()),
+					SeverityNumber:         int32(lr.SeverityNumber()),
+					SeverityText:           lr.SeverityText(),
+					Body:                   lr.Body().AsString(),
+					Attributes:             attributesToMap(lr.Attributes()),
+					DroppedAttributesCount: int64(lr.DroppedAttributesCount()),
+					Flags:                  int64(lr.FlagsStruct().AsRaw()),
+					TraceID:                traceID,
+					SpanID:                 spanID,
+				})
+			}
+		}
+	}
+	return rows
+}
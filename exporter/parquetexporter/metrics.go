@@ -0,0 +1,226 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// dataPointRecord is a row of a metrics file. There is one row per data point; the columns
+// that do not apply to the type of the metric are left null.
+type dataPointRecord struct {
+	ServiceName        string            `parquet:"service_name"`
+	ResourceAttributes map[string]string `parquet:"resource_attributes"`
+	ScopeName          string            `parquet:"scope_name"`
+	ScopeVersion       string            `parquet:"scope_version"`
+
+	MetricName             string `parquet:"metric_name"`
+	MetricDescription      string `parquet:"metric_description"`
+	MetricUnit             string `parquet:"metric_unit"`
+	MetricType             string `parquet:"metric_type"`
+	AggregationTemporality string `parquet:"aggregation_temporality"`
+	IsMonotonic            bool   `parquet:"is_monotonic"`
+
+	StartTimeUnixNano int64             `parquet:"start_time_unix_nano"`
+	TimeUnixNano      int64             `parquet:"time_unix_nano"`
+	Attributes        map[string]string `parquet:"attributes"`
+	Flags             int64             `parquet:"flags"`
+
+	// Gauge and Sum.
+	IntValue    *int64   `parquet:"int_value,optional"`
+	DoubleValue *float64 `parquet:"double_value,optional"`
+
+	// Histogram, ExponentialHistogram and Summary.
+	Count *int64   `parquet:"count,optional"`
+	Sum   *float64 `parquet:"sum,optional"`
+	Min   *float64 `parquet:"min,optional"`
+	Max   *float64 `parquet:"max,optional"`
+
+	// Histogram.
+	BucketCounts   []int64   `parquet:"bucket_counts"`
+	ExplicitBounds []float64 `parquet:"explicit_bounds"`
+
+	// ExponentialHistogram.
+	Scale                *int32  `parquet:"scale,optional"`
+	ZeroCount            *int64  `parquet:"zero_count,optional"`
+	PositiveOffset       *int32  `parquet:"positive_offset,optional"`
+	PositiveBucketCounts []int64 `parquet:"positive_bucket_counts"`
+	NegativeOffset       *int32  `parquet:"negative_offset,optional"`
+	NegativeBucketCounts []int64 `parquet:"negative_bucket_counts"`
+
+	// Summary.
+	Quantiles []quantileRecord `parquet:"quantiles"`
+}
+
+type quantileRecord struct {
+	Quantile float64 `parquet:"quantile"`
+	Value    float64 `parquet:"value"`
+}
+
+func metricsToRows(md pmetric.Metrics) []interface{} {
+	rows := make([]interface{}, 0, md.DataPointCount())
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resource := rm.Resource()
+		resourceAttributes := attributesToMap(resource.Attributes())
+		service := serviceName(resource)
+		ilms := rm.ScopeMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			ilm := ilms.At(j)
+			scope := ilm.Scope()
+			metrics := ilm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				newRecord := func() *dataPointRecord {
+					return &dataPointRecord{
+						ServiceName:        service,
+						ResourceAttributes: resourceAttributes,
+						ScopeName:          scope.Name(),
+						ScopeVersion:       scope.Version(),
+						MetricName:         metric.Name(),
+						MetricDescription:  metric.Description(),
+						MetricUnit:         metric.Unit(),
+						MetricType:         metric.DataType().String(),
+					}
+				}
+				rows = appendMetricRows(rows, metric, newRecord)
+			}
+		}
+	}
+	return rows
+}
+
+func appendMetricRows(rows []interface{}, metric pmetric.Metric, newRecord func() *dataPointRecord) []interface{} {
+	switch metric.DataType() {
+	case pmetric.MetricDataTypeGauge:
+		dps := metric.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			rec := newRecord()
+			setNumberDataPoint(rec, dps.At(i))
+			rows = append(rows, rec)
+		}
+	case pmetric.MetricDataTypeSum:
+		sum := metric.Sum()
+		dps := sum.DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			rec := newRecord()
+			rec.AggregationTemporality = sum.AggregationTemporality().String()
+			rec.IsMonotonic = sum.IsMonotonic()
+			setNumberDataPoint(rec, dps.At(i))
+			rows = append(rows, rec)
+		}
+	case pmetric.MetricDataTypeHistogram:
+		histogram := metric.Histogram()
+		dps := histogram.DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			rec := newRecord()
+			rec.AggregationTemporality = histogram.AggregationTemporality().String()
+			setCommonDataPoint(rec, dp.StartTimestamp(), dp.Timestamp(), dp.Attributes(), dp.Flags().AsRaw())
+			rec.Count = int64Ptr(int64(dp.Count()))
+			rec.Sum = float64Ptr(dp.Sum())
+			if dp.HasMin() {
+				rec.Min = float64Ptr(dp.Min())
+			}
+			if dp.HasMax() {
+				rec.Max = float64Ptr(dp.Max())
+			}
+			rec.BucketCounts = uint64sToInt64s(dp.BucketCounts().AsRaw())
+			rec.ExplicitBounds = dp.ExplicitBounds().AsRaw()
+			rows = append(rows, rec)
+		}
+	case pmetric.MetricDataTypeExponentialHistogram:
+		histogram := metric.ExponentialHistogram()
+		dps := histogram.DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			rec := newRecord()
+			rec.AggregationTemporality = histogram.AggregationTemporality().String()
+			setCommonDataPoint(rec, dp.StartTimestamp(), dp.Timestamp(), dp.Attributes(), dp.Flags().AsRaw())
+			rec.Count = int64Ptr(int64(dp.Count()))
+			rec.Sum = float64Ptr(dp.Sum())
+			if dp.HasMin() {
+				rec.Min = float64Ptr(dp.Min())
+			}
+			if dp.HasMax() {
+				rec.Max = float64Ptr(dp.Max())
+			}
+			scale := dp.Scale()
+			rec.Scale = &scale
+			rec.ZeroCount = int64Ptr(int64(dp.ZeroCount()))
+			positiveOffset := dp.Positive().Offset()
+			rec.PositiveOffset = &positiveOffset
+			rec.PositiveBucketCounts = uint64sToInt64s(dp.Positive().BucketCounts().AsRaw())
+			negativeOffset := dp.Negative().Offset()
+			rec.NegativeOffset = &negativeOffset
+			rec.NegativeBucketCounts = uint64sToInt64s(dp.Negative().BucketCounts().AsRaw())
+			rows = append(rows, rec)
+		}
+	case pmetric.MetricDataTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			rec := newRecord()
+			setCommonDataPoint(rec, dp.StartTimestamp(), dp.Timestamp(), dp.Attributes(), dp.Flags().AsRaw())
+			rec.Count = int64Ptr(int64(dp.Count()))
+			rec.Sum = float64Ptr(dp.Sum())
+			qs := dp.QuantileValues()
+			rec.Quantiles = make([]quantileRecord, qs.Len())
+			for j := 0; j < qs.Len(); j++ {
+				rec.Quantiles[j] = quantileRecord{Quantile: qs.At(j).Quantile(), Value: qs.At(j).Value()}
+			}
+			rows = append(rows, rec)
+		}
+	}
+	return rows
+}
+
+func setNumberDataPoint(rec *dataPointRecord, dp pmetric.NumberDataPoint) {
+	setCommonDataPoint(rec, dp.StartTimestamp(), dp.Timestamp(), dp.Attributes(), dp.Flags().AsRaw())
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		rec.IntValue = int64Ptr(dp.IntVal())
+	case pmetric.NumberDataPointValueTypeDouble:
+		rec.DoubleValue = float64Ptr(dp.DoubleVal())
+	}
+}
+
+func setCommonDataPoint(rec *dataPointRecord, start, ts pcommon.Timestamp, attrs pcommon.Map, flags uint32) {
+	rec.StartTimeUnixNano = int64(start)
+	rec.TimeUnixNano = int64(ts)
+	rec.Attributes = attributesToMap(attrs)
+	rec.Flags = int64(flags)
+}
+
+func uint64sToInt64s(in []uint64) []int64 {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]int64, len(in))
+	for i, v := range in {
+		out[i] = int64(v)
+	}
+	return out
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func float64Ptr(v float64) *float64 {
+	return &v
+}
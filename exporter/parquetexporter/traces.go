@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// spanRecord is a row of a traces file. There is one row per span.
+type spanRecord struct {
+	ServiceName            string            `parquet:"service_name"`
+	ResourceAttributes     map[string]string `parquet:"resource_attributes"`
+	ScopeName              string            `parquet:"scope_name"`
+	ScopeVersion           string            `parquet:"scope_version"`
+	TraceID                string            `parquet:"trace_id"`
+	SpanID                 string            `parquet:"span_id"`
+	ParentSpanID           string            `parquet:"parent_span_id"`
+	TraceState             string            `parquet:"trace_state"`
+	Name                   string            `parquet:"name"`
+	Kind                   string            `parquet:"kind"`
+	StartTimeUnixNano      int64             `parquet:"start_time_unix_nano"`
+	EndTimeUnixNano        int64             `parquet:"end_time_unix_nano"`
+	Attributes             map[string]string `parquet:"attributes"`
+	DroppedAttributesCount int64             `parquet:"dropped_attributes_count"`
+	Events                 []spanEventRecord `parquet:"events"`
+	DroppedEventsCount     int64             `parquet:"dropped_events_count"`
+	Links                  []spanLinkRecord  `parquet:"links"`
+	DroppedLinksCount      int64             `parquet:"dropped_links_count"`
+	StatusCode             string            `parquet:"status_code"`
+	StatusMessage          string            `parquet:"status_message"`
+}
+
+type spanEventRecord struct {
+	TimeUnixNano           int64             `parquet:"time_unix_nano"`
+	Name                   string            `parquet:"name"`
+	Attributes             map[string]string `parquet:"attributes"`
+	DroppedAttributesCount int64             `parquet:"dropped_attributes_count"`
+}
+
+type spanLinkRecord struct {
+	TraceID                string            `parquet:"trace_id"`
+	SpanID                 string            `parquet:"span_id"`
+	TraceState             string            `parquet:"trace_state"`
+	Attributes             map[string]string `parquet:"attributes"`
+	DroppedAttributesCount int64             `parquet:"dropped_attributes_count"`
+}
+
+func tracesToRows(td ptrace.Traces) []interface{} {
+	rows := make([]interface{}, 0, td.SpanCount())
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resource := rs.Resource()
+		resourceAttributes := attributesToMap(resource.Attributes())
+		service := serviceName(resource)
+		ilss := rs.ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			scope := ils.Scope()
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				rows = append(rows, &spanRecord{
+					ServiceName:            service,
+					ResourceAttributes:     resourceAttributes,
+					ScopeName:              scope.Name(),
+					ScopeVersion:           scope.Version(),
+					TraceID:                span.TraceID().HexString(),
+					SpanID:                 span.SpanID().HexString(),
+					ParentSpanID:           span.ParentSpanID().HexString(),
+					TraceState:             string(span.TraceState()),
+					Name:                   span.Name(),
+					Kind:                   span.Kind().String(),
+					StartTimeUnixNano:      int64(span.StartTimestamp()),
+					EndTimeUnixNano:        int64(span.EndTimestamp()),
+					Attributes:             attributesToMap(span.Attributes()),
+					DroppedAttributesCount: int64(span.DroppedAttributesCount()),
+					Events:                 spanEventsToRecords(span.Events()),
+					DroppedEventsCount:     int64(span.DroppedEventsCount()),
+					Links:                  spanLinksToRecords(span.Links()),
+					DroppedLinksCount:      int64(span.DroppedLinksCount()),
+					StatusCode:             span.Status().Code().String(),
+					StatusMessage:          span.Status().Message(),
+				})
+			}
+		}
+	}
+	return rows
+}
+
+func spanEventsToRecords(events ptrace.SpanEventSlice) []spanEventRecord {
+	if events.Len() == 0 {
+		return nil
+	}
+	records := make([]spanEventRecord, events.Len())
+	for i := 0; i < events.Len(); i++ {
+		event := events.At(i)
+		records[i] = spanEventRecord{
+			TimeUnixNano:           int64(event.Timestamp()),
+			Name:                   event.Name(),
+			Attributes:             attributesToMap(event.Attributes()),
+			DroppedAttributesCount: int64(event.DroppedAttributesCount()),
+		}
+	}
+	return records
+}
+
+func spanLinksToRecords(links ptrace.SpanLinkSlice) []spanLinkRecord {
+	if links.Len() == 0 {
+		return nil
+	}
+	records := make([]spanLinkRecord, links.Len())
+	for i := 0; i < links.Len(); i++ {
+		link := links.At(i)
+		records[i] = spanLinkRecord{
+			TraceID:                link.TraceID().HexString(),
+			SpanID:                 link.SpanID().HexString(),
+			TraceState:             string(link.TraceState()),
+			Attributes:             attributesToMap(link.Attributes()),
+			DroppedAttributesCount: int64(link.DroppedAttributesCount()),
+		}
+	}
+	return records
+}
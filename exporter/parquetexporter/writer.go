@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/segmentio/parquet-go"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+const (
+	fileExtension = ".parquet"
+	// inProgressSuffix is appended to the name of a file while it is being written.
+	// Together with the leading dot it hides the file from tools that only look at complete
+	// Parquet files (e.g. `*.parquet` globs, Spark, Hive).
+	inProgressSuffix = ".inprogress"
+	timeFormat       = "20060102T150405.000000000Z"
+)
+
+// countingWriter keeps track of the number of bytes written to the underlying writer.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// rollingWriter writes rows of a single schema to a sequence of Parquet files in a directory.
+// A file is written under a temporary name and only renamed to its final name once it is
+// closed, which happens when it grows past maxFileSize, when it has been open for longer than
+// rolloverInterval, or on shutdown.
+type rollingWriter struct {
+	dir              string
+	prefix           string
+	schema           *parquet.Schema
+	maxFileSize      int64
+	rolloverInterval time.Duration
+	now              func() time.Time
+	logger           *zap.Logger
+
+	mu      sync.Mutex
+	current *openFile
+}
+
+type openFile struct {
+	tmpPath   string
+	finalPath string
+	file      *os.File
+	counter   *countingWriter
+	writer    *parquet.Writer
+	timer     *time.Timer
+}
+
+func newRollingWriter(dir string, prefix string, schema *parquet.Schema, maxFileSize int64, rolloverInterval time.Duration, logger *zap.Logger) *rollingWriter {
+	return &rollingWriter{
+		dir:              dir,
+		prefix:           prefix,
+		schema:           schema,
+		maxFileSize:      maxFileSize,
+		rolloverInterval: rolloverInterval,
+		now:              time.Now,
+		logger:           logger,
+	}
+}
+
+// write appends rows to the current file as a single row group, opening a new file first if
+// needed, and closes the file if it has reached the maximum size.
+func (w *rollingWriter) write(rows []interface{}) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.current == nil {
+		if err := w.open(); err != nil {
+			return err
+		}
+	}
+
+	f := w.current
+	for _, row := range rows {
+		if err := f.writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write row to %s: %w", f.tmpPath, err)
+		}
+	}
+	if err := f.writer.Flush(); err != nil {
+		return fmt.Errorf("failed to flush row group to %s: %w", f.tmpPath, err)
+	}
+
+	if f.counter.n >= w.maxFileSize {
+		return w.closeCurrent()
+	}
+	return nil
+}
+
+// close finalizes the current file, if any.
+func (w *rollingWriter) close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.closeCurrent()
+}
+
+func (w *rollingWriter) open() error {
+	name := fmt.Sprintf("%s-%s%s", w.prefix, w.now().UTC().Format(timeFormat), fileExtension)
+	finalPath := filepath.Join(w.dir, name)
+	tmpPath := filepath.Join(w.dir, "."+name+inProgressSuffix)
+
+	file, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	counter := &countingWriter{w: file}
+	f := &openFile{
+		tmpPath:   tmpPath,
+		finalPath: finalPath,
+		file:      file,
+		counter:   counter,
+		writer:    parquet.NewWriter(counter, w.schema),
+	}
+	f.timer = time.AfterFunc(w.rolloverInterval, func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		// The file may already have been closed because it reached the maximum size.
+		if w.current == f {
+			if err := w.closeCurrent(); err != nil {
+				w.logger.Error("Failed to roll over Parquet file", zap.Error(err))
+			}
+		}
+	})
+	w.current = f
+	return nil
+}
+
+// closeCurrent writes the footer of the current file, syncs it to disk and moves it to its
+// final name. It must be called with w.mu held.
+func (w *rollingWriter) closeCurrent() error {
+	f := w.current
+	if f == nil {
+		return nil
+	}
+	w.current = nil
+	f.timer.Stop()
+
+	err := f.writer.Close()
+	err = multierr.Append(err, f.file.Sync())
+	err = multierr.Append(err, f.file.Close())
+	if err != nil {
+		// Leave the incomplete file under its temporary name so that it is never mistaken
+		// for a complete one.
+		return fmt.Errorf("failed to close %s: %w", f.tmpPath, err)
+	}
+	return os.Rename(f.tmpPath, f.finalPath)
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storageutils // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/storageutils"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+var (
+	// ErrNoStorageExtension is returned by GetClient when no storage ID is given and the host has no storage extension.
+	ErrNoStorageExtension = errors.New("no storage extension found")
+	// ErrMultipleStorageExtensions is returned by GetClient when no storage ID is given and the host has several
+	// storage extensions.
+	ErrMultipleStorageExtensions = errors.New("multiple storage extensions found")
+)
+
+// GetClient returns a client of the storage extension with the given ID for the component. When no ID is given,
+// the only storage extension of the host is used.
+func GetClient(ctx context.Context, host component.Host, storageID *config.ComponentID,
+	kind component.Kind, componentID config.ComponentID) (storage.Client, error) {
+	ext, err := storageExtension(host, storageID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ext.GetClient(ctx, kind, componentID, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain the storage client: %w", err)
+	}
+	return client, nil
+}
+
+func storageExtension(host component.Host, storageID *config.ComponentID) (storage.Extension, error) {
+	extensions := host.GetExtensions()
+	if storageID != nil {
+		ext, ok := extensions[*storageID]
+		if !ok {
+			return nil, fmt.Errorf("storage extension %q not found", storageID)
+		}
+		storageExt, ok := ext.(storage.Extension)
+		if !ok {
+			return nil, fmt.Errorf("extension %q is not a storage extension", storageID)
+		}
+		return storageExt, nil
+	}
+
+	var storageExt storage.Extension
+	for _, ext := range extensions {
+		if se, ok := ext.(storage.Extension); ok {
+			if storageExt != nil {
+				return nil, ErrMultipleStorageExtensions
+			}
+			storageExt = se
+		}
+	}
+	if storageExt == nil {
+		return nil, ErrNoStorageExtension
+	}
+	return storageExt, nil
+}
@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storageutils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+func TestGetClient(t *testing.T) {
+	first := config.NewComponentIDWithName("test_storage", "first")
+	second := config.NewComponentIDWithName("test_storage", "second")
+	other := config.NewComponentIDWithName("non_storage", "other")
+	missing := config.NewComponentIDWithName("test_storage", "missing")
+
+	tests := []struct {
+		name       string
+		extensions []config.ComponentID
+		storageID  *config.ComponentID
+		expected   config.ComponentID
+		err        string
+	}{
+		{
+			name:       "configured",
+			extensions: []config.ComponentID{first, second},
+			storageID:  &second,
+			expected:   second,
+		},
+		{
+			name:       "only storage",
+			extensions: []config.ComponentID{first, other},
+			expected:   first,
+		},
+		{
+			name:       "missing",
+			extensions: []config.ComponentID{first},
+			storageID:  &missing,
+			err:        `storage extension "test_storage/missing" not found`,
+		},
+		{
+			name:       "not a storage",
+			extensions: []config.ComponentID{first, other},
+			storageID:  &other,
+			err:        `extension "non_storage/other" is not a storage extension`,
+		},
+		{
+			name:       "no storage",
+			extensions: []config.ComponentID{other},
+			err:        ErrNoStorageExtension.Error(),
+		},
+		{
+			name:       "multiple storages",
+			extensions: []config.ComponentID{first, second},
+			err:        ErrMultipleStorageExtensions.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host := &testHost{Host: componenttest.NewNopHost(), extensions: map[config.ComponentID]component.Extension{}}
+			for _, id := range tt.extensions {
+				if id.Type() == "test_storage" {
+					host.extensions[id] = &testStorage{id: id}
+				} else {
+					host.extensions[id] = &nonStorage{}
+				}
+			}
+
+			client, err := GetClient(context.Background(), host, tt.storageID, component.KindProcessor, config.NewComponentID("test"))
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, client.(*testClient).storageID)
+		})
+	}
+}
+
+type testHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h *testHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+type nonStorage struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+type testStorage struct {
+	component.StartFunc
+	component.ShutdownFunc
+	id config.ComponentID
+}
+
+func (s *testStorage) GetClient(context.Context, component.Kind, config.ComponentID, string) (storage.Client, error) {
+	return &testClient{Client: storage.NewNopClient(), storageID: s.id}, nil
+}
+
+// testClient records the extension it's obtained from, and whether it has been closed.
+type testClient struct {
+	storage.Client
+	storageID config.ComponentID
+	closed    bool
+}
+
+func (c *testClient) Close(context.Context) error {
+	c.closed = true
+	return nil
+}
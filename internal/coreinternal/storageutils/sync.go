@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storageutils // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/storageutils"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// SyncFunc reads or writes the state of a component with a storage client.
+type SyncFunc func(ctx context.Context, client storage.Client) error
+
+// PeriodicSync keeps the state of a component in sync with a storage client. The state is synced at every
+// interval in the background, and one last time when the PeriodicSync is stopped, before the client is closed.
+type PeriodicSync struct {
+	client   storage.Client
+	logger   *zap.Logger
+	interval time.Duration
+	sync     SyncFunc
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewPeriodicSync creates a PeriodicSync calling sync with the client at every interval, once started.
+func NewPeriodicSync(client storage.Client, logger *zap.Logger, interval time.Duration, sync SyncFunc) *PeriodicSync {
+	return &PeriodicSync{
+		client:   client,
+		logger:   logger,
+		interval: interval,
+		sync:     sync,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start calls restore, typically loading the state persisted by a previous run, then starts the background sync.
+// When restore fails, the client is closed and the PeriodicSync must not be stopped.
+func (s *PeriodicSync) Start(ctx context.Context, restore SyncFunc) error {
+	if err := restore(ctx, s.client); err != nil {
+		return multierr.Append(err, s.client.Close(ctx))
+	}
+
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := s.sync(context.Background(), s.client); err != nil {
+					s.logger.Warn("Failed to sync the state with the storage", zap.Error(err))
+				}
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop waits for the background sync in progress, if any, then syncs the state one last time and closes the client.
+func (s *PeriodicSync) Stop(ctx context.Context) error {
+	close(s.stop)
+	<-s.done
+
+	err := s.sync(ctx, s.client)
+	return multierr.Append(err, s.client.Close(ctx))
+}
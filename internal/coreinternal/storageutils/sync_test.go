@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storageutils
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestPeriodicSync(t *testing.T) {
+	// prepare
+	client := &testClient{Client: storage.NewNopClient()}
+	var restores, syncs int64
+	restore := func(context.Context, storage.Client) error {
+		atomic.AddInt64(&restores, 1)
+		return nil
+	}
+	sync := func(context.Context, storage.Client) error {
+		atomic.AddInt64(&syncs, 1)
+		return nil
+	}
+	ps := NewPeriodicSync(client, zap.NewNop(), time.Millisecond, sync)
+
+	// test
+	require.NoError(t, ps.Start(context.Background(), restore))
+
+	// verify
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt64(&syncs) > 1
+	}, 10*time.Second, time.Millisecond)
+
+	require.NoError(t, ps.Stop(context.Background()))
+	assert.Equal(t, int64(1), atomic.LoadInt64(&restores))
+	assert.True(t, client.closed)
+
+	// the state is synced one last time on stop, and never after
+	stopped := atomic.LoadInt64(&syncs)
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, stopped, atomic.LoadInt64(&syncs))
+}
+
+func TestPeriodicSyncFailures(t *testing.T) {
+	// prepare
+	client := &testClient{Client: storage.NewNopClient()}
+	core, logs := observer.New(zap.WarnLevel)
+	errSync := errors.New("sync failed")
+	sync := func(context.Context, storage.Client) error {
+		return errSync
+	}
+	ps := NewPeriodicSync(client, zap.New(core), time.Millisecond, sync)
+
+	// test
+	require.NoError(t, ps.Start(context.Background(), func(context.Context, storage.Client) error {
+		return nil
+	}))
+
+	// verify: the background failures are logged, the last one is returned
+	assert.Eventually(t, func() bool {
+		return logs.Len() > 0
+	}, 10*time.Second, time.Millisecond)
+	assert.ErrorIs(t, ps.Stop(context.Background()), errSync)
+	assert.True(t, client.closed)
+}
+
+func TestPeriodicSyncRestoreFailure(t *testing.T) {
+	// prepare
+	client := &testClient{Client: storage.NewNopClient()}
+	errRestore := errors.New("restore failed")
+	ps := NewPeriodicSync(client, zap.NewNop(), time.Millisecond, func(context.Context, storage.Client) error {
+		return nil
+	})
+
+	// test
+	err := ps.Start(context.Background(), func(context.Context, storage.Client) error {
+		return errRestore
+	})
+
+	// verify
+	assert.ErrorIs(t, err, errRestore)
+	assert.True(t, client.closed)
+}
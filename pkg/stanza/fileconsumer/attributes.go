@@ -25,6 +25,9 @@ type FileAttributes struct {
 	Path         string
 	NameResolved string
 	PathResolved string
+
+	// HeaderAttributes are extracted from the header lines of the file
+	HeaderAttributes map[string]interface{}
 }
 
 // resolveFileAttributes resolves file attributes
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+)
+
+// gzipMagic are the first bytes of a gzip file
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// isGzip returns true if the file starts with the gzip magic number
+func isGzip(file *os.File) (bool, error) {
+	buf := make([]byte, len(gzipMagic))
+	n, err := file.ReadAt(buf, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("reading magic number: %w", err)
+	}
+	return n == len(gzipMagic) && bytes.Equal(buf, gzipMagic), nil
+}
+
+// gzipReader reads the decompressed content of a gzip file from its beginning,
+// regardless of the offset of the file. The file may still be being written,
+// so running out of compressed data is reported as the end of the file, and
+// only reaching the actual end of the stream marks the content as complete
+type gzipReader struct {
+	gz       *gzip.Reader
+	complete bool
+	// read is the number of decompressed bytes read so far
+	read int64
+}
+
+func newGzipReader(file *os.File) (*gzipReader, error) {
+	gz, err := gzip.NewReader(io.NewSectionReader(file, 0, math.MaxInt64))
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		// the header is not fully written yet
+		return &gzipReader{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("gzip: %w", err)
+	}
+	return &gzipReader{gz: gz}, nil
+}
+
+func (g *gzipReader) Read(dst []byte) (int, error) {
+	if g.gz == nil {
+		return 0, io.EOF
+	}
+	n, err := g.gz.Read(dst)
+	g.read += int64(n)
+	switch {
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return n, io.EOF
+	case errors.Is(err, io.EOF):
+		g.complete = true
+	}
+	return n, err
+}
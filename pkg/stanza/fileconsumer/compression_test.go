@@ -0,0 +1,195 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func gzipString(t testing.TB, s string) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte(s))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestNewFingerprintGzip(t *testing.T) {
+	content := string(tokenWithLength(2 * DefaultFingerprintSize))
+	compressed := gzipString(t, content)
+
+	temp := openTempWithPattern(t, t.TempDir(), "*.gz")
+	_, err := temp.Write(compressed)
+	require.NoError(t, err)
+
+	fp, err := NewFingerprint(temp, DefaultFingerprintSize)
+	require.NoError(t, err)
+	require.Equal(t, []byte(content[:DefaultFingerprintSize]), fp.FirstBytes)
+
+	// A file that is still being compressed has the fingerprint of the content
+	// decompressed so far
+	partial := openTempWithPattern(t, t.TempDir(), "*.gz")
+	_, err = partial.Write(compressed[:len(compressed)/2])
+	require.NoError(t, err)
+
+	fp, err = NewFingerprint(partial, DefaultFingerprintSize)
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix([]byte(content), fp.FirstBytes))
+
+	// Not even the header is written yet
+	header := openTempWithPattern(t, t.TempDir(), "*.gz")
+	_, err = header.Write(compressed[:4])
+	require.NoError(t, err)
+
+	fp, err = NewFingerprint(header, DefaultFingerprintSize)
+	require.NoError(t, err)
+	require.Empty(t, fp.FirstBytes)
+}
+
+// TestReadGzipFile tests that a gzip file is decompressed, and only read once
+func TestReadGzipFile(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	temp := openTempWithPattern(t, tempDir, "*.gz")
+	_, err := temp.Write(gzipString(t, "testlog1\ntestlog2\n"))
+	require.NoError(t, err)
+
+	operator.poll(context.Background())
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	waitForToken(t, emitCalls, []byte("testlog1"))
+	waitForToken(t, emitCalls, []byte("testlog2"))
+
+	operator.poll(context.Background())
+	expectNoTokens(t, emitCalls)
+}
+
+// TestReadGzipFileWhileWritten tests that the content of a gzip file is read
+// once, even when the file is being compressed while it is read
+func TestReadGzipFileWhileWritten(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	var content bytes.Buffer
+	expected := make([][]byte, 0, 50)
+	for i := 0; i < 50; i++ {
+		token := tokenWithLength(100)
+		expected = append(expected, token)
+		content.Write(token)
+		content.WriteByte('\n')
+	}
+	compressed := gzipString(t, content.String())
+
+	temp := openTempWithPattern(t, tempDir, "*.gz")
+	_, err := temp.Write(compressed[:len(compressed)/2])
+	require.NoError(t, err)
+
+	operator.poll(context.Background())
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	_, err = temp.Write(compressed[len(compressed)/2:])
+	require.NoError(t, err)
+	operator.poll(context.Background())
+
+	waitForTokens(t, emitCalls, expected)
+}
+
+// TestStartAtEndGzip tests that the existing content of a gzip file is skipped
+func TestStartAtEndGzip(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	temp := openTempWithPattern(t, tempDir, "*.gz")
+	_, err := temp.Write(gzipString(t, "testlog1\ntestlog2\n"))
+	require.NoError(t, err)
+
+	operator.poll(context.Background())
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	expectNoTokens(t, emitCalls)
+}
+
+// TestRotatedFileCompressed tests that a rotated file that gets compressed is
+// not read twice, and that the logs written before the rotation are not lost
+func TestRotatedFileCompressed(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skip("Moving files while open is unsupported on Windows")
+	}
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "testlog1\ntestlog2\n")
+
+	operator.poll(context.Background())
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	waitForToken(t, emitCalls, []byte("testlog1"))
+	waitForToken(t, emitCalls, []byte("testlog2"))
+
+	// Write a log that isn't read before the rotation, then compress the
+	// rotated file and remove it, as logrotate does with `compress`
+	writeString(t, temp, "testlog3\n")
+	require.NoError(t, temp.Close())
+	rotated := openFile(t, fmt.Sprintf("%s.1.gz", temp.Name()))
+	_, err := rotated.Write(gzipString(t, "testlog1\ntestlog2\ntestlog3\n"))
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(temp.Name()))
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog3"))
+
+	operator.poll(context.Background())
+	expectNoTokens(t, emitCalls)
+}
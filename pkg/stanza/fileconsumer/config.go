@@ -58,6 +58,9 @@ type Config struct {
 	MaxLogSize              helper.ByteSize       `mapstructure:"max_log_size,omitempty"                   json:"max_log_size,omitempty"                  yaml:"max_log_size,omitempty"`
 	MaxConcurrentFiles      int                   `mapstructure:"max_concurrent_files,omitempty"           json:"max_concurrent_files,omitempty"          yaml:"max_concurrent_files,omitempty"`
 	Splitter                helper.SplitterConfig `mapstructure:",squash,omitempty"                        json:",inline,omitempty"                       yaml:",inline,omitempty"`
+	OrderingCriteria        OrderingCriteria      `mapstructure:"ordering_criteria,omitempty"              json:"ordering_criteria,omitempty"             yaml:"ordering_criteria,omitempty"`
+	DeleteAfterRead         bool                  `mapstructure:"delete_after_read,omitempty"              json:"delete_after_read,omitempty"             yaml:"delete_after_read,omitempty"`
+	Header                  *HeaderConfig         `mapstructure:"header,omitempty"                         json:"header,omitempty"                        yaml:"header,omitempty"`
 }
 
 // Build will build a file input operator from the supplied configuration
@@ -116,6 +119,25 @@ func (c Config) Build(logger *zap.SugaredLogger, emit EmitFunc) (*Manager, error
 		return nil, fmt.Errorf("invalid start_at location '%s'", c.StartAt)
 	}
 
+	if c.DeleteAfterRead && !startAtBeginning {
+		return nil, fmt.Errorf("`delete_after_read` requires `start_at` to be 'beginning'")
+	}
+
+	orderer, err := c.OrderingCriteria.build(logger.With("component", "fileconsumer"))
+	if err != nil {
+		return nil, err
+	}
+
+	var h *header
+	if c.Header != nil {
+		if !startAtBeginning {
+			return nil, fmt.Errorf("`header` requires `start_at` to be 'beginning'")
+		}
+		if h, err = c.Header.build(logger.With("component", "fileconsumer")); err != nil {
+			return nil, err
+		}
+	}
+
 	return &Manager{
 		SugaredLogger: logger.With("component", "fileconsumer"),
 		cancel:        func() {},
@@ -125,15 +147,18 @@ func (c Config) Build(logger *zap.SugaredLogger, emit EmitFunc) (*Manager, error
 				fingerprintSize: int(c.FingerprintSize),
 				maxLogSize:      int(c.MaxLogSize),
 				emit:            emit,
+				header:          h,
 			},
 			fromBeginning:  startAtBeginning,
 			splitterConfig: c.Splitter,
 		},
-		finder:        c.Finder,
-		roller:        newRoller(),
-		pollInterval:  c.PollInterval,
-		maxBatchFiles: c.MaxConcurrentFiles / 2,
-		knownFiles:    make([]*Reader, 0, 10),
-		seenPaths:     make(map[string]struct{}, 100),
+		finder:          c.Finder,
+		orderer:         orderer,
+		roller:          newRoller(),
+		pollInterval:    c.PollInterval,
+		maxBatchFiles:   c.MaxConcurrentFiles / 2,
+		deleteAfterRead: c.DeleteAfterRead,
+		knownFiles:      make([]*Reader, 0, 10),
+		seenPaths:       make(map[string]struct{}, 100),
 	}, nil
 }
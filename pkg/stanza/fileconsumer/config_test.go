@@ -383,6 +383,49 @@ func TestUnmarshal(t *testing.T) {
 				return cfg
 			}(),
 		},
+		{
+			Name:      "ordering_criteria",
+			ExpectErr: false,
+			Expect: func() *Config {
+				cfg := NewConfig()
+				cfg.Include = append(cfg.Include, "/var/log/app-*.log")
+				cfg.OrderingCriteria = OrderingCriteria{
+					Regex: `app-(?P<date>\d{8})\.log$`,
+					TopN:  2,
+					SortBy: []SortRuleConfig{
+						{
+							RegexKey: "date",
+							SortType: sortTypeTimestamp,
+							Layout:   "%Y%m%d",
+							Location: "UTC",
+						},
+					},
+				}
+				return cfg
+			}(),
+		},
+		{
+			Name:      "delete_after_read",
+			ExpectErr: false,
+			Expect: func() *Config {
+				cfg := NewConfig()
+				cfg.Include = append(cfg.Include, "/var/spool/batch/*.log")
+				cfg.StartAt = "beginning"
+				cfg.DeleteAfterRead = true
+				return cfg
+			}(),
+		},
+		{
+			Name:      "header",
+			ExpectErr: false,
+			Expect: func() *Config {
+				cfg := NewConfig()
+				cfg.Include = append(cfg.Include, "/var/log/iis/*.log")
+				cfg.StartAt = "beginning"
+				cfg.Header = newHeaderConfig("^#", "^#Fields: (?P<fields>.*)$")
+				return cfg
+			}(),
+		},
 	}
 
 	for _, tc := range cases {
@@ -418,6 +461,80 @@ func TestBuild(t *testing.T) {
 				require.Equal(t, f.pollInterval, 10*time.Millisecond)
 			},
 		},
+		{
+			"DeleteAfterReadStartAtEnd",
+			func(f *Config) {
+				f.StartAt = "end"
+				f.DeleteAfterRead = true
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"DeleteAfterReadStartAtBeginning",
+			func(f *Config) {
+				f.StartAt = "beginning"
+				f.DeleteAfterRead = true
+			},
+			require.NoError,
+			func(t *testing.T, f *Manager) {
+				require.True(t, f.deleteAfterRead)
+			},
+		},
+		{
+			"OrderingCriteria",
+			func(f *Config) {
+				f.OrderingCriteria = OrderingCriteria{
+					Regex:  `testpath\.(?P<num>\d+)$`,
+					SortBy: []SortRuleConfig{{RegexKey: "num", SortType: sortTypeNumeric}},
+				}
+			},
+			require.NoError,
+			func(t *testing.T, f *Manager) {
+				require.NotNil(t, f.orderer)
+				require.Equal(t, 1, f.orderer.topN)
+			},
+		},
+		{
+			"InvalidOrderingCriteria",
+			func(f *Config) {
+				f.OrderingCriteria = OrderingCriteria{
+					Regex:  `testpath\.(?P<num>\d+)$`,
+					SortBy: []SortRuleConfig{{RegexKey: "missing", SortType: sortTypeNumeric}},
+				}
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"HeaderStartAtEnd",
+			func(f *Config) {
+				f.StartAt = "end"
+				f.Header = newHeaderConfig("^#", "^#Fields: (?P<fields>.*)$")
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"HeaderStartAtBeginning",
+			func(f *Config) {
+				f.StartAt = "beginning"
+				f.Header = newHeaderConfig("^#", "^#Fields: (?P<fields>.*)$")
+			},
+			require.NoError,
+			func(t *testing.T, f *Manager) {
+				require.NotNil(t, f.readerFactory.readerConfig.header)
+			},
+		},
+		{
+			"InvalidHeader",
+			func(f *Config) {
+				f.StartAt = "beginning"
+				f.Header = newHeaderConfig("^#")
+			},
+			require.Error,
+			nil,
+		},
 		{
 			"BadIncludeGlob",
 			func(f *Config) {
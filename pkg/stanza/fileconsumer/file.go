@@ -37,11 +37,13 @@ type Manager struct {
 
 	readerFactory readerFactory
 	finder        Finder
+	orderer       *orderer
 	roller        roller
 	persister     operator.Persister
 
-	pollInterval  time.Duration
-	maxBatchFiles int
+	pollInterval    time.Duration
+	maxBatchFiles   int
+	deleteAfterRead bool
 
 	knownFiles []*Reader
 	seenPaths  map[string]struct{}
@@ -57,6 +59,12 @@ func (m *Manager) Start(persister operator.Persister) error {
 		return fmt.Errorf("read known files from database: %w", err)
 	}
 
+	if h := m.readerFactory.readerConfig.header; h != nil {
+		if err := h.pipeline.Start(operator.NewScopedPersister("header", persister)); err != nil {
+			return fmt.Errorf("start header pipeline: %w", err)
+		}
+	}
+
 	if len(m.finder.FindFiles()) == 0 {
 		m.Warnw("no files match the configured include patterns",
 			"include", m.finder.Include,
@@ -79,6 +87,9 @@ func (m *Manager) Stop() error {
 	}
 	m.knownFiles = nil
 	m.cancel = nil
+	if h := m.readerFactory.readerConfig.header; h != nil {
+		return h.pipeline.Stop()
+	}
 	return nil
 }
 
@@ -113,6 +124,9 @@ func (m *Manager) poll(ctx context.Context) {
 
 	// Get the list of paths on disk
 	matches := m.finder.FindFiles()
+	if m.orderer != nil {
+		matches = m.orderer.apply(matches)
+	}
 	for len(matches) > m.maxBatchFiles {
 		m.consume(ctx, matches[:m.maxBatchFiles])
 		matches = matches[m.maxBatchFiles:]
@@ -145,6 +159,38 @@ func (m *Manager) consume(ctx context.Context, paths []string) {
 	m.roller.roll(ctx, readers)
 	m.saveCurrent(readers)
 	m.syncLastPollFiles(ctx)
+
+	// The files are only deleted once their offsets are committed, so that
+	// they're not read again if the collector stops before deleting them
+	if m.deleteAfterRead {
+		m.deleteReadFiles(readers)
+	}
+}
+
+// deleteReadFiles deletes the files that were read to their end and didn't
+// change across two polls, so that the files still being written are kept.
+// It forgets about them, including the readers of the previous polls, so that
+// a new file with the same fingerprint is read entirely.
+func (m *Manager) deleteReadFiles(readers []*Reader) {
+	for _, reader := range readers {
+		if !reader.unchanged() {
+			continue
+		}
+		path := reader.file.Name()
+		if err := os.Remove(path); err != nil {
+			m.Errorw("Failed to delete file", "path", path, zap.Error(err))
+			continue
+		}
+		m.Debugw("Deleted file after reading it", "path", path)
+		delete(m.seenPaths, path)
+		knownFiles := m.knownFiles[:0]
+		for _, known := range m.knownFiles {
+			if !reader.Fingerprint.StartsWith(known.Fingerprint) {
+				knownFiles = append(knownFiles, known)
+			}
+		}
+		m.knownFiles = knownFiles
+	}
 }
 
 // makeReaders takes a list of paths, then creates readers from each of those paths,
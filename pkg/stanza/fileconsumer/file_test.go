@@ -1180,3 +1180,139 @@ func TestEncodings(t *testing.T) {
 		})
 	}
 }
+
+// TestDeleteAfterRead tests that files are deleted once they're read and
+// unchanged since the previous poll, and that a new file with the same
+// content is read again
+func TestDeleteAfterRead(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.DeleteAfterRead = true
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	temp1 := openTemp(t, tempDir)
+	writeString(t, temp1, "testlog1\ntestlog2\n")
+	temp2 := openTemp(t, tempDir)
+	writeString(t, temp2, "testlog3\n")
+	require.NoError(t, temp1.Close())
+	require.NoError(t, temp2.Close())
+
+	operator.poll(context.Background())
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	waitForTokens(t, emitCalls, [][]byte{[]byte("testlog1"), []byte("testlog2"), []byte("testlog3")})
+	require.FileExists(t, temp1.Name())
+	require.FileExists(t, temp2.Name())
+
+	operator.poll(context.Background())
+	require.NoFileExists(t, temp1.Name())
+	require.NoFileExists(t, temp2.Name())
+
+	temp3 := openTemp(t, tempDir)
+	writeString(t, temp3, "testlog1\ntestlog2\n")
+	require.NoError(t, temp3.Close())
+
+	operator.poll(context.Background())
+	waitForTokens(t, emitCalls, [][]byte{[]byte("testlog1"), []byte("testlog2")})
+	operator.poll(context.Background())
+	require.NoFileExists(t, temp3.Name())
+	expectNoTokens(t, emitCalls)
+}
+
+// TestDeleteAfterReadGrowing tests that a file isn't deleted while it keeps
+// growing between polls, such as a file being copied
+func TestDeleteAfterReadGrowing(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.DeleteAfterRead = true
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "testlog1\n")
+
+	operator.poll(context.Background())
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+	waitForToken(t, emitCalls, []byte("testlog1"))
+
+	writeString(t, temp, "testlog2\n")
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog2"))
+	require.FileExists(t, temp.Name())
+
+	require.NoError(t, temp.Close())
+	operator.poll(context.Background())
+	require.NoFileExists(t, temp.Name())
+}
+
+// TestDeleteAfterReadUnterminated tests that a file isn't deleted while its
+// last line is not terminated
+func TestDeleteAfterReadUnterminated(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.DeleteAfterRead = true
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "testlog1\ntestlog2")
+
+	operator.poll(context.Background())
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	waitForToken(t, emitCalls, []byte("testlog1"))
+	require.FileExists(t, temp.Name())
+
+	writeString(t, temp, "\n")
+	require.NoError(t, temp.Close())
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog2"))
+	operator.poll(context.Background())
+	require.NoFileExists(t, temp.Name())
+}
+
+// TestOrderingCriteriaTopN tests that only the newest files are read
+func TestOrderingCriteriaTopN(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.OrderingCriteria = OrderingCriteria{
+		Regex: `app-(?P<date>\d{8})\.log$`,
+		TopN:  2,
+		SortBy: []SortRuleConfig{
+			{RegexKey: "date", SortType: sortTypeTimestamp, Layout: "%Y%m%d"},
+		},
+	}
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	for _, date := range []string{"20261016", "20261017", "20261018"} {
+		file := openFile(t, filepath.Join(tempDir, fmt.Sprintf("app-%s.log", date)))
+		writeString(t, file, fmt.Sprintf("log from %s\n", date))
+	}
+
+	operator.poll(context.Background())
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	waitForTokens(t, emitCalls, [][]byte{[]byte("log from 20261017"), []byte("log from 20261018")})
+}
@@ -32,10 +32,29 @@ type Fingerprint struct {
 }
 
 // NewFingerprint creates a new fingerprint from an open file
+// The fingerprint of a gzip file is made of its decompressed content, so that
+// a file keeps its fingerprint when it gets compressed after being rotated
 func NewFingerprint(file *os.File, size int) (*Fingerprint, error) {
 	buf := make([]byte, size)
 
-	n, err := file.ReadAt(buf, 0)
+	compressed, err := isGzip(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var n int
+	if compressed {
+		var gz *gzipReader
+		if gz, err = newGzipReader(file); err != nil {
+			return nil, err
+		}
+		n, err = io.ReadFull(gz, buf)
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			err = io.EOF
+		}
+	} else {
+		n, err = file.ReadAt(buf, 0)
+	}
 	if err != nil && !errors.Is(err, io.EOF) {
 		return nil, fmt.Errorf("reading fingerprint bytes: %w", err)
 	}
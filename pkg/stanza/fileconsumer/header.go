@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/pipeline"
+)
+
+const headerOutputType = "header_attributes"
+
+// HeaderConfig describes the header lines at the beginning of the files, and
+// the operators extracting metadata from them
+type HeaderConfig struct {
+	Pattern           string            `mapstructure:"pattern"            json:"pattern"            yaml:"pattern"`
+	MetadataOperators []operator.Config `mapstructure:"metadata_operators" json:"metadata_operators" yaml:"metadata_operators"`
+}
+
+// header runs the header lines through the metadata operators. The
+// attributes of the resulting entries are collected by the output operator.
+type header struct {
+	regex    *regexp.Regexp
+	pipeline *pipeline.DirectedPipeline
+	first    operator.Operator
+	output   *headerOutput
+
+	// the operators are shared by all the readers
+	mu sync.Mutex
+}
+
+func (c HeaderConfig) build(logger *zap.SugaredLogger) (*header, error) {
+	if c.Pattern == "" {
+		return nil, fmt.Errorf("`header.pattern` is required")
+	}
+	regex, err := regexp.Compile(c.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("compile `header.pattern`: %w", err)
+	}
+	if len(c.MetadataOperators) == 0 {
+		return nil, fmt.Errorf("`header.metadata_operators` must not be empty")
+	}
+
+	outputOperator, err := helper.NewOutputConfig(headerOutputType, headerOutputType).Build(logger)
+	if err != nil {
+		return nil, err
+	}
+	output := &headerOutput{OutputOperator: outputOperator}
+
+	p, err := pipeline.Config{
+		Operators:     c.MetadataOperators,
+		DefaultOutput: output,
+	}.Build(logger)
+	if err != nil {
+		return nil, fmt.Errorf("build `header.metadata_operators`: %w", err)
+	}
+
+	// the ids of the operators are only deduplicated when building the pipeline
+	firstID := c.MetadataOperators[0].ID()
+	h := &header{
+		regex:    regex,
+		pipeline: p,
+		output:   output,
+	}
+	for _, op := range p.Operators() {
+		if op.ID() == firstID {
+			h.first = op
+			break
+		}
+	}
+	if h.first == nil {
+		return nil, fmt.Errorf("find the first of `header.metadata_operators`")
+	}
+	return h, nil
+}
+
+func (h *header) matches(line []byte) bool {
+	return h.regex.Match(line)
+}
+
+// process runs a header line through the metadata operators, adding the
+// attributes of the resulting entries to attrs
+func (h *header) process(ctx context.Context, line []byte, attrs map[string]interface{}) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.output.attributes = attrs
+	defer func() { h.output.attributes = nil }()
+
+	e := entry.New()
+	e.Body = string(line)
+	return h.first.Process(ctx, e)
+}
+
+// headerOutput collects the attributes of the entries it receives
+type headerOutput struct {
+	helper.OutputOperator
+	attributes map[string]interface{}
+}
+
+// Process adds the attributes of the entry to the header attributes
+func (o *headerOutput) Process(_ context.Context, e *entry.Entry) error {
+	for k, v := range e.Attributes {
+		o.attributes[k] = v
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/regex"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func newHeaderConfig(pattern string, regexes ...string) *HeaderConfig {
+	cfg := &HeaderConfig{Pattern: pattern}
+	for _, r := range regexes {
+		regexCfg := regex.NewConfig()
+		regexCfg.Regex = r
+		cfg.MetadataOperators = append(cfg.MetadataOperators, operator.Config{Builder: regexCfg})
+	}
+	return cfg
+}
+
+func TestHeaderConfigBuild(t *testing.T) {
+	cases := []struct {
+		name   string
+		config *HeaderConfig
+		err    string
+	}{
+		{
+			name:   "valid",
+			config: newHeaderConfig("^#", "^#(?P<key>.*)$"),
+		},
+		{
+			name:   "missing-pattern",
+			config: newHeaderConfig("", "^#(?P<key>.*)$"),
+			err:    "`header.pattern` is required",
+		},
+		{
+			name:   "invalid-pattern",
+			config: newHeaderConfig("^#(", "^#(?P<key>.*)$"),
+			err:    "compile `header.pattern`: error parsing regexp: missing closing ): `^#(`",
+		},
+		{
+			name:   "missing-operators",
+			config: newHeaderConfig("^#"),
+			err:    "`header.metadata_operators` must not be empty",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, err := tc.config.build(testutil.Logger(t))
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "regex_parser", h.first.ID())
+		})
+	}
+}
+
+func TestHeaderProcess(t *testing.T) {
+	h, err := newHeaderConfig("^#", `^#Fields: (?P<fields>.*)$`, `^#(?P<key>\w+)`).build(testutil.Logger(t))
+	require.NoError(t, err)
+	require.NoError(t, h.pipeline.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, h.pipeline.Stop())
+	}()
+
+	require.True(t, h.matches([]byte("#Fields: a b c")))
+	require.False(t, h.matches([]byte("1 2 3")))
+
+	attrs := make(map[string]interface{})
+	require.NoError(t, h.process(context.Background(), []byte("#Fields: a b c"), attrs))
+	require.Equal(t, map[string]interface{}{"fields": "a b c", "key": "Fields"}, attrs)
+
+	// the entry is still sent on to the following operators when it can't be parsed
+	require.Error(t, h.process(context.Background(), []byte("#Version: 1.0"), attrs))
+	require.Equal(t, map[string]interface{}{"fields": "a b c", "key": "Version"}, attrs)
+}
+
+func TestReadHeader(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Header = newHeaderConfig("^#", `^#Fields: (?P<fields>.*)$`)
+	operator, emitCalls := buildTestManager(t, cfg)
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "#Version: 1.0\n#Fields: a b c\n")
+
+	// the header may not be complete yet
+	expectNoTokens(t, emitCalls)
+
+	writeString(t, temp, "1 2 3\n#4 5 6\n")
+	for _, expected := range []string{"1 2 3", "#4 5 6"} {
+		call := waitForEmit(t, emitCalls)
+		require.Equal(t, []byte(expected), call.token)
+		require.Equal(t, map[string]interface{}{"fields": "a b c"}, call.attrs.HeaderAttributes)
+	}
+}
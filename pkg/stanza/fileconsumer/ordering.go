@@ -0,0 +1,225 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	strptime "github.com/observiq/ctimefmt"
+	"go.uber.org/zap"
+)
+
+const (
+	sortTypeNumeric      = "numeric"
+	sortTypeTimestamp    = "timestamp"
+	sortTypeAlphabetical = "alphabetical"
+)
+
+// OrderingCriteria restricts the matched files to the top N, once sorted by
+// the values captured from their path
+type OrderingCriteria struct {
+	Regex  string           `mapstructure:"regex,omitempty"   json:"regex,omitempty"   yaml:"regex,omitempty"`
+	TopN   int              `mapstructure:"top_n,omitempty"   json:"top_n,omitempty"   yaml:"top_n,omitempty"`
+	SortBy []SortRuleConfig `mapstructure:"sort_by,omitempty" json:"sort_by,omitempty" yaml:"sort_by,omitempty"`
+}
+
+// SortRuleConfig sorts the files by the value of a named capture group of the
+// ordering regex
+type SortRuleConfig struct {
+	RegexKey  string `mapstructure:"regex_key,omitempty" json:"regex_key,omitempty" yaml:"regex_key,omitempty"`
+	SortType  string `mapstructure:"sort_type,omitempty" json:"sort_type,omitempty" yaml:"sort_type,omitempty"`
+	Ascending bool   `mapstructure:"ascending,omitempty" json:"ascending,omitempty" yaml:"ascending,omitempty"`
+	Layout    string `mapstructure:"layout,omitempty"    json:"layout,omitempty"    yaml:"layout,omitempty"`
+	Location  string `mapstructure:"location,omitempty"  json:"location,omitempty"  yaml:"location,omitempty"`
+}
+
+// orderer keeps the top N files, sorted by the configured rules
+type orderer struct {
+	*zap.SugaredLogger
+	regex *regexp.Regexp
+	topN  int
+	rules []sortRule
+}
+
+type sortRule struct {
+	group     int
+	sortType  string
+	ascending bool
+	layout    string
+	location  *time.Location
+}
+
+// sortKey is the value captured for a sort rule, parsed according to its type
+type sortKey struct {
+	number    int64
+	timestamp time.Time
+	text      string
+}
+
+// build validates the ordering criteria. It returns a nil orderer when no
+// sort rule is configured, as all the files are kept then.
+func (c OrderingCriteria) build(logger *zap.SugaredLogger) (*orderer, error) {
+	if len(c.SortBy) == 0 {
+		if c.Regex != "" || c.TopN != 0 {
+			return nil, fmt.Errorf("`ordering_criteria.sort_by` is required when the ordering criteria are set")
+		}
+		return nil, nil
+	}
+	if c.Regex == "" {
+		return nil, fmt.Errorf("`ordering_criteria.regex` is required when sorting the files")
+	}
+	regex, err := regexp.Compile(c.Regex)
+	if err != nil {
+		return nil, fmt.Errorf("compile `ordering_criteria.regex`: %w", err)
+	}
+	if c.TopN < 0 {
+		return nil, fmt.Errorf("`ordering_criteria.top_n` must not be negative")
+	}
+
+	o := &orderer{
+		SugaredLogger: logger,
+		regex:         regex,
+		topN:          c.TopN,
+	}
+	if o.topN == 0 {
+		o.topN = 1
+	}
+	for _, rc := range c.SortBy {
+		rule := sortRule{
+			group:     regex.SubexpIndex(rc.RegexKey),
+			sortType:  rc.SortType,
+			ascending: rc.Ascending,
+		}
+		if rule.group < 0 {
+			return nil, fmt.Errorf("`ordering_criteria.regex` has no capture group named '%s'", rc.RegexKey)
+		}
+		switch rc.SortType {
+		case sortTypeNumeric, sortTypeAlphabetical:
+		case sortTypeTimestamp:
+			if rc.Layout == "" {
+				return nil, fmt.Errorf("`layout` is required to sort by timestamp")
+			}
+			if rule.layout, err = strptime.ToNative(rc.Layout); err != nil {
+				return nil, fmt.Errorf("parse strptime layout: %w", err)
+			}
+			rule.location = time.UTC
+			if rc.Location != "" {
+				if rule.location, err = time.LoadLocation(rc.Location); err != nil {
+					return nil, fmt.Errorf("load location '%s': %w", rc.Location, err)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("invalid sort_type '%s', must be one of '%s', '%s' or '%s'",
+				rc.SortType, sortTypeNumeric, sortTypeTimestamp, sortTypeAlphabetical)
+		}
+		o.rules = append(o.rules, rule)
+	}
+	return o, nil
+}
+
+// apply sorts the paths by the rules and returns the top N of them. Paths that
+// don't match the regex, or whose captured values can't be parsed, are left out.
+func (o *orderer) apply(paths []string) []string {
+	type sortedPath struct {
+		path string
+		keys []sortKey
+	}
+
+	sorted := make([]sortedPath, 0, len(paths))
+PATHS:
+	for _, path := range paths {
+		match := o.regex.FindStringSubmatch(path)
+		if match == nil {
+			o.Debugw("Skipping file not matching the ordering regex", "path", path)
+			continue
+		}
+		keys := make([]sortKey, 0, len(o.rules))
+		for _, rule := range o.rules {
+			key, err := rule.parse(match[rule.group])
+			if err != nil {
+				o.Debugw("Skipping file with unsortable name", "path", path, zap.Error(err))
+				continue PATHS
+			}
+			keys = append(keys, key)
+		}
+		sorted = append(sorted, sortedPath{path: path, keys: keys})
+	}
+
+	// The first rule sorts the files, the following ones break the ties
+	sort.SliceStable(sorted, func(i, j int) bool {
+		for r, rule := range o.rules {
+			if c := rule.compare(sorted[i].keys[r], sorted[j].keys[r]); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+
+	if len(sorted) > o.topN {
+		sorted = sorted[:o.topN]
+	}
+	result := make([]string, 0, len(sorted))
+	for _, s := range sorted {
+		result = append(result, s.path)
+	}
+	return result
+}
+
+func (r sortRule) parse(value string) (sortKey, error) {
+	switch r.sortType {
+	case sortTypeNumeric:
+		n, err := strconv.ParseInt(value, 10, 64)
+		return sortKey{number: n}, err
+	case sortTypeTimestamp:
+		ts, err := time.ParseInLocation(r.layout, value, r.location)
+		return sortKey{timestamp: ts}, err
+	default:
+		return sortKey{text: value}, nil
+	}
+}
+
+// compare returns a negative number when a sorts before b. The files are
+// sorted in descending order unless the rule is ascending, so that the newest
+// or the largest ones come first.
+func (r sortRule) compare(a, b sortKey) int {
+	var c int
+	switch r.sortType {
+	case sortTypeNumeric:
+		switch {
+		case a.number < b.number:
+			c = -1
+		case a.number > b.number:
+			c = 1
+		}
+	case sortTypeTimestamp:
+		switch {
+		case a.timestamp.Before(b.timestamp):
+			c = -1
+		case a.timestamp.After(b.timestamp):
+			c = 1
+		}
+	default:
+		c = strings.Compare(a.text, b.text)
+	}
+	if !r.ascending {
+		c = -c
+	}
+	return c
+}
@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func TestOrderingCriteriaBuild(t *testing.T) {
+	cases := []struct {
+		name     string
+		criteria OrderingCriteria
+		err      string
+	}{
+		{
+			name: "Empty",
+		},
+		{
+			name:     "RegexWithoutRules",
+			criteria: OrderingCriteria{Regex: `(?P<num>\d+)`},
+			err:      "`ordering_criteria.sort_by` is required when the ordering criteria are set",
+		},
+		{
+			name: "MissingRegex",
+			criteria: OrderingCriteria{
+				SortBy: []SortRuleConfig{{RegexKey: "num", SortType: sortTypeNumeric}},
+			},
+			err: "`ordering_criteria.regex` is required when sorting the files",
+		},
+		{
+			name: "InvalidRegex",
+			criteria: OrderingCriteria{
+				Regex:  `(?P<num>\d+`,
+				SortBy: []SortRuleConfig{{RegexKey: "num", SortType: sortTypeNumeric}},
+			},
+			err: "compile `ordering_criteria.regex`: error parsing regexp: missing closing ): `(?P<num>\\d+`",
+		},
+		{
+			name: "NegativeTopN",
+			criteria: OrderingCriteria{
+				Regex:  `(?P<num>\d+)`,
+				TopN:   -1,
+				SortBy: []SortRuleConfig{{RegexKey: "num", SortType: sortTypeNumeric}},
+			},
+			err: "`ordering_criteria.top_n` must not be negative",
+		},
+		{
+			name: "MissingCaptureGroup",
+			criteria: OrderingCriteria{
+				Regex:  `(?P<num>\d+)`,
+				SortBy: []SortRuleConfig{{RegexKey: "date", SortType: sortTypeNumeric}},
+			},
+			err: "`ordering_criteria.regex` has no capture group named 'date'",
+		},
+		{
+			name: "InvalidSortType",
+			criteria: OrderingCriteria{
+				Regex:  `(?P<num>\d+)`,
+				SortBy: []SortRuleConfig{{RegexKey: "num", SortType: "random"}},
+			},
+			err: "invalid sort_type 'random', must be one of 'numeric', 'timestamp' or 'alphabetical'",
+		},
+		{
+			name: "TimestampWithoutLayout",
+			criteria: OrderingCriteria{
+				Regex:  `(?P<date>\d+)`,
+				SortBy: []SortRuleConfig{{RegexKey: "date", SortType: sortTypeTimestamp}},
+			},
+			err: "`layout` is required to sort by timestamp",
+		},
+		{
+			name: "InvalidLocation",
+			criteria: OrderingCriteria{
+				Regex:  `(?P<date>\d+)`,
+				SortBy: []SortRuleConfig{{RegexKey: "date", SortType: sortTypeTimestamp, Layout: "%Y%m%d", Location: "Mars/Olympus_Mons"}},
+			},
+			err: "load location 'Mars/Olympus_Mons': unknown time zone Mars/Olympus_Mons",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			o, err := tc.criteria.build(testutil.Logger(t))
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Nil(t, o)
+		})
+	}
+}
+
+func TestOrderingCriteriaApply(t *testing.T) {
+	cases := []struct {
+		name     string
+		criteria OrderingCriteria
+		paths    []string
+		expected []string
+	}{
+		{
+			name: "NumericDefaultsToTopOne",
+			criteria: OrderingCriteria{
+				Regex:  `app\.(?P<num>\d+)\.log$`,
+				SortBy: []SortRuleConfig{{RegexKey: "num", SortType: sortTypeNumeric}},
+			},
+			paths:    []string{"/log/app.2.log", "/log/app.10.log", "/log/app.1.log"},
+			expected: []string{"/log/app.10.log"},
+		},
+		{
+			name: "NumericAscending",
+			criteria: OrderingCriteria{
+				Regex:  `app\.(?P<num>\d+)\.log$`,
+				TopN:   2,
+				SortBy: []SortRuleConfig{{RegexKey: "num", SortType: sortTypeNumeric, Ascending: true}},
+			},
+			paths:    []string{"/log/app.2.log", "/log/app.10.log", "/log/app.1.log"},
+			expected: []string{"/log/app.1.log", "/log/app.2.log"},
+		},
+		{
+			name: "Timestamp",
+			criteria: OrderingCriteria{
+				Regex:  `app-(?P<date>\d{8})\.log$`,
+				TopN:   2,
+				SortBy: []SortRuleConfig{{RegexKey: "date", SortType: sortTypeTimestamp, Layout: "%Y%m%d"}},
+			},
+			paths:    []string{"/log/app-20261016.log", "/log/app-20261018.log", "/log/app-20250101.log", "/log/app-20261017.log"},
+			expected: []string{"/log/app-20261018.log", "/log/app-20261017.log"},
+		},
+		{
+			name: "Alphabetical",
+			criteria: OrderingCriteria{
+				Regex:  `(?P<name>[a-z]+)\.log$`,
+				TopN:   3,
+				SortBy: []SortRuleConfig{{RegexKey: "name", SortType: sortTypeAlphabetical, Ascending: true}},
+			},
+			paths:    []string{"/log/charlie.log", "/log/alpha.log", "/log/delta.log", "/log/bravo.log"},
+			expected: []string{"/log/alpha.log", "/log/bravo.log", "/log/charlie.log"},
+		},
+		{
+			name: "TiesBrokenByNextRule",
+			criteria: OrderingCriteria{
+				Regex: `(?P<host>[a-z]+)-(?P<date>\d{8})-(?P<part>\d+)\.log$`,
+				TopN:  3,
+				SortBy: []SortRuleConfig{
+					{RegexKey: "date", SortType: sortTypeTimestamp, Layout: "%Y%m%d"},
+					{RegexKey: "part", SortType: sortTypeNumeric},
+				},
+			},
+			paths: []string{
+				"/log/web-20261017-3.log",
+				"/log/web-20261018-1.log",
+				"/log/web-20261018-2.log",
+				"/log/web-20261016-9.log",
+			},
+			expected: []string{"/log/web-20261018-2.log", "/log/web-20261018-1.log", "/log/web-20261017-3.log"},
+		},
+		{
+			name: "UnmatchedAndUnparsableSkipped",
+			criteria: OrderingCriteria{
+				Regex:  `app-(?P<date>\d{8})\.log$`,
+				TopN:   5,
+				SortBy: []SortRuleConfig{{RegexKey: "date", SortType: sortTypeTimestamp, Layout: "%Y%m%d"}},
+			},
+			paths:    []string{"/log/app-20261018.log", "/log/app-20261399.log", "/log/other.log"},
+			expected: []string{"/log/app-20261018.log"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			o, err := tc.criteria.build(testutil.Logger(t))
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, o.apply(tc.paths))
+		})
+	}
+}
@@ -16,7 +16,9 @@ package fileconsumer // import "github.com/open-telemetry/opentelemetry-collecto
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 
 	"go.uber.org/zap"
@@ -28,6 +30,7 @@ type readerConfig struct {
 	fingerprintSize int
 	maxLogSize      int
 	emit            EmitFunc
+	header          *header
 }
 
 // Reader manages a single file
@@ -41,6 +44,27 @@ type Reader struct {
 	generation     int
 	file           *os.File
 	fileAttributes *FileAttributes
+
+	// The offset and fingerprint of gzip files are based on their
+	// decompressed content. As the decompressed content can't be seeked,
+	// the size of the file when it was last read to its end is kept,
+	// so that it's only decompressed again when it changes.
+	compressed     bool
+	compressedSize int64
+	source         io.Reader
+
+	// eof is true when the whole content of the file was read
+	eof bool
+	// previousEOF and previousOffset tell whether the previous poll read the
+	// whole content of the file, and up to which offset
+	previousEOF    bool
+	previousOffset int64
+
+	// ReadingHeader is true until the first line not matching the header
+	// pattern. The attributes extracted from the header lines are added to
+	// all the following entries.
+	ReadingHeader    bool                   `json:",omitempty"`
+	HeaderAttributes map[string]interface{} `json:",omitempty"`
 }
 
 // offsetToEnd sets the starting offset
@@ -49,15 +73,58 @@ func (r *Reader) offsetToEnd() error {
 	if err != nil {
 		return fmt.Errorf("stat: %w", err)
 	}
-	r.Offset = info.Size()
+	if !r.compressed {
+		r.Offset = info.Size()
+		return nil
+	}
+
+	gz, err := newGzipReader(r.file)
+	if err != nil {
+		return err
+	}
+	if r.Offset, err = io.Copy(io.Discard, gz); err != nil {
+		return fmt.Errorf("decompress: %w", err)
+	}
+	if gz.complete {
+		r.compressedSize = info.Size()
+	}
 	return nil
 }
 
 // ReadToEnd will read until the end of the file
 func (r *Reader) ReadToEnd(ctx context.Context) {
-	if _, err := r.file.Seek(r.Offset, 0); err != nil {
-		r.Errorw("Failed to seek", zap.Error(err))
-		return
+	var gz *gzipReader
+	var size int64
+	if r.compressed {
+		info, err := r.file.Stat()
+		if err != nil {
+			r.Errorw("Failed to stat", zap.Error(err))
+			return
+		}
+		size = info.Size()
+		if size == r.compressedSize {
+			// already read to the end
+			return
+		}
+		r.eof = false
+		if gz, err = newGzipReader(r.file); err != nil {
+			r.Errorw("Failed to decompress", zap.Error(err))
+			return
+		}
+		if _, err = io.CopyN(io.Discard, gz, r.Offset); errors.Is(err, io.EOF) {
+			// the file is still being compressed, and doesn't reach the offset yet
+			return
+		} else if err != nil {
+			r.Errorw("Failed to seek", zap.Error(err))
+			return
+		}
+		r.source = gz
+	} else {
+		if _, err := r.file.Seek(r.Offset, 0); err != nil {
+			r.Errorw("Failed to seek", zap.Error(err))
+			return
+		}
+		r.source = r.file
 	}
 
 	scanner := NewPositionalScanner(r, r.maxLogSize, r.Offset, r.splitter.SplitFunc)
@@ -74,6 +141,14 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 		if !ok {
 			if err := scanner.getError(); err != nil {
 				r.Errorw("Failed during scan", zap.Error(err))
+			} else if gz != nil {
+				if gz.complete {
+					r.compressedSize = size
+					r.eof = r.Offset == gz.read
+				}
+			} else if info, err := r.file.Stat(); err == nil {
+				// the last line may not be terminated yet
+				r.eof = r.Offset >= info.Size()
 			}
 			break
 		}
@@ -81,7 +156,18 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 		token, err := r.splitter.Encoding.Decode(scanner.Bytes())
 		if err != nil {
 			r.Errorw("decode: %w", zap.Error(err))
+		} else if r.ReadingHeader && r.header.matches(token) {
+			if r.HeaderAttributes == nil {
+				// empty attributes are not kept in the checkpoints
+				r.HeaderAttributes = make(map[string]interface{})
+			}
+			if err = r.header.process(ctx, token, r.HeaderAttributes); err != nil {
+				r.Debugw("Failed to process header line", zap.Error(err))
+			}
 		} else {
+			if r.ReadingHeader {
+				r.finishHeader()
+			}
 			r.emit(ctx, r.fileAttributes, token)
 		}
 
@@ -89,6 +175,21 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 	}
 }
 
+// finishHeader adds the attributes extracted from the header lines to the
+// attributes of the file, once the header is complete
+func (r *Reader) finishHeader() {
+	r.ReadingHeader = false
+	if r.fileAttributes != nil {
+		r.fileAttributes.HeaderAttributes = r.HeaderAttributes
+	}
+}
+
+// unchanged tells whether the whole content of the file was already read by
+// the previous poll, and the file didn't grow since
+func (r *Reader) unchanged() bool {
+	return r.eof && r.previousEOF && r.Offset == r.previousOffset
+}
+
 // Close will close the file
 func (r *Reader) Close() {
 	if r.file != nil {
@@ -103,9 +204,9 @@ func (r *Reader) Read(dst []byte) (int, error) {
 	// Skip if fingerprint is already built
 	// or if fingerprint is behind Offset
 	if len(r.Fingerprint.FirstBytes) == r.fingerprintSize || int(r.Offset) > len(r.Fingerprint.FirstBytes) {
-		return r.file.Read(dst)
+		return r.source.Read(dst)
 	}
-	n, err := r.file.Read(dst)
+	n, err := r.source.Read(dst)
 	appendCount := min0(n, r.fingerprintSize-int(r.Offset))
 	// return for n == 0 or r.Offset >= r.fileInput.fingerprintSize
 	if appendCount == 0 {
@@ -38,12 +38,30 @@ func (f *readerFactory) newReader(file *os.File, fp *Fingerprint) (*Reader, erro
 
 // copy creates a deep copy of a Reader
 func (f *readerFactory) copy(old *Reader, newFile *os.File) (*Reader, error) {
-	return f.newReaderBuilder().
+	r, err := f.newReaderBuilder().
 		withFile(newFile).
 		withFingerprint(old.Fingerprint.Copy()).
 		withOffset(old.Offset).
 		withSplitter(old.splitter).
 		build()
+	if err != nil {
+		return nil, err
+	}
+	r.previousEOF = old.eof
+	r.previousOffset = old.Offset
+	// The offset of a rotated file that got compressed since is still valid,
+	// as it's based on the decompressed content
+	if r.compressed && old.compressed {
+		r.compressedSize = old.compressedSize
+		r.eof = old.eof
+	}
+	// the header is only read further when it's still configured
+	r.ReadingHeader = old.ReadingHeader && r.header != nil
+	r.HeaderAttributes = old.HeaderAttributes
+	if !r.ReadingHeader && r.fileAttributes != nil {
+		r.fileAttributes.HeaderAttributes = r.HeaderAttributes
+	}
+	return r, nil
 }
 
 func (f *readerFactory) unsafeReader() (*Reader, error) {
@@ -108,12 +126,18 @@ func (b *readerBuilder) build() (r *Reader, err error) {
 		if err != nil {
 			b.Errorf("resolve attributes: %w", err)
 		}
+		if r.compressed, err = isGzip(b.file); err != nil {
+			return nil, err
+		}
 
 		// unsafeReader has the file set to nil, so don't try emending its offset.
 		if !b.fromBeginning {
 			if err := r.offsetToEnd(); err != nil {
 				return nil, err
 			}
+		} else if b.readerConfig.header != nil {
+			r.ReadingHeader = true
+			r.HeaderAttributes = make(map[string]interface{})
 		}
 	} else {
 		r.SugaredLogger = b.SugaredLogger.With("path", "uninitialized")
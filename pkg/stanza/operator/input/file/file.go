@@ -56,6 +56,12 @@ func (f *Input) emit(ctx context.Context, attrs *fileconsumer.FileAttributes, to
 		return
 	}
 
+	for k, v := range attrs.HeaderAttributes {
+		if err := ent.Set(entry.NewAttributeField(k), v); err != nil {
+			f.Errorf("set header attribute: %w", err)
+		}
+	}
+
 	for _, option := range f.preEmitOptions {
 		if err := option(attrs, ent); err != nil {
 			f.Errorf("preemit: %w", err)
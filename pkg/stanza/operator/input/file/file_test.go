@@ -26,7 +26,10 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/regex"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
 )
 
@@ -68,6 +71,34 @@ func TestAddFileFields(t *testing.T) {
 	require.Equal(t, temp.Name(), e.Attributes["log.file.path"])
 }
 
+// TestAddHeaderAttributes tests that the attributes extracted from the header
+// lines are added to the following entries
+func TestAddHeaderAttributes(t *testing.T) {
+	t.Parallel()
+	regexCfg := regex.NewConfig()
+	regexCfg.Regex = "^#Fields: (?P<fields>.*)$"
+	operator, logReceived, tempDir := newTestFileOperator(t, func(cfg *Config) {
+		cfg.Header = &fileconsumer.HeaderConfig{
+			Pattern:           "^#",
+			MetadataOperators: []operator.Config{{Builder: regexCfg}},
+		}
+	}, nil)
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "#Fields: a b c\n1 2 3\n")
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	e := waitForOne(t, logReceived)
+	require.Equal(t, "1 2 3", e.Body)
+	require.Equal(t, "a b c", e.Attributes["fields"])
+	require.Equal(t, filepath.Base(temp.Name()), e.Attributes["log.file.name"])
+	expectNoMessages(t, logReceived)
+}
+
 // AddFileResolvedFields tests that the `log.file.name_resolved` and `log.file.path_resolved` fields are included
 // when IncludeFileNameResolved and IncludeFilePathResolved are set to true
 func TestAddFileResolvedFields(t *testing.T) {
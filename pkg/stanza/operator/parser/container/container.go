@@ -0,0 +1,284 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/container"
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/recombine"
+)
+
+const operatorType = "container"
+
+const (
+	dockerFormat     = "docker"
+	crioFormat       = "crio"
+	containerdFormat = "containerd"
+)
+
+const (
+	streamAttribute   = "log.iostream"
+	logTagAttribute   = "logtag"
+	filePathAttribute = "log.file.path"
+
+	namespaceResource    = "k8s.namespace.name"
+	podNameResource      = "k8s.pod.name"
+	podUIDResource       = "k8s.pod.uid"
+	containerResource    = "k8s.container.name"
+	restartCountResource = "k8s.container.restart_count"
+)
+
+// criRegex matches the lines written by CRI-O and containerd, such as
+// `2022-10-18T10:27:25.813799277Z stdout F message`
+var criRegex = regexp.MustCompile(`^(?P<time>\S+) (?P<stream>stdout|stderr) (?P<logtag>[^ ]*) ?(?P<log>.*)$`)
+
+// podPathRegex matches the paths of the container logs of the kubelet, such as
+// `/var/log/pods/<namespace>_<pod_name>_<pod_uid>/<container_name>/<restart_count>.log`
+var podPathRegex = regexp.MustCompile(`^.*/(?P<namespace>[^_/]+)_(?P<pod_name>[^_/]+)_(?P<uid>[a-f0-9-]+)/(?P<container_name>[^_/]+)/(?P<restart_count>\d+)\.log$`)
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new container parser config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new container parser config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		TransformerConfig:       helper.NewTransformerConfig(operatorID, operatorType),
+		AddMetadataFromFilePath: true,
+		ForceFlushPeriod:        5 * time.Second,
+	}
+}
+
+// Config is the configuration of a container parser operator.
+type Config struct {
+	helper.TransformerConfig `mapstructure:",squash" yaml:",inline"`
+
+	Format                  string        `mapstructure:"format"                     json:"format"                     yaml:"format"`
+	AddMetadataFromFilePath bool          `mapstructure:"add_metadata_from_filepath" json:"add_metadata_from_filepath" yaml:"add_metadata_from_filepath"`
+	ForceFlushPeriod        time.Duration `mapstructure:"force_flush_period"         json:"force_flush_period"         yaml:"force_flush_period"`
+}
+
+// Build will build a container parser operator.
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	transformerOperator, err := c.TransformerConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	switch c.Format {
+	case "", dockerFormat, crioFormat, containerdFormat:
+	default:
+		return nil, fmt.Errorf("invalid `format` '%s', must be one of '%s', '%s' or '%s'", c.Format, dockerFormat, crioFormat, containerdFormat)
+	}
+
+	if c.ForceFlushPeriod <= 0 {
+		return nil, fmt.Errorf("`force_flush_period` must be positive")
+	}
+
+	// The partial lines are joined by a recombine operator, which shares the
+	// outputs of the parser
+	recombineConfig := recombine.NewConfigWithID(c.ID() + "_recombine")
+	recombineConfig.IsLastEntry = fmt.Sprintf(`attributes.%s == "F"`, logTagAttribute)
+	recombineConfig.CombineField = entry.NewBodyField()
+	recombineConfig.CombineWith = ""
+	recombineConfig.SourceIdentifier = entry.NewAttributeField(filePathAttribute)
+	recombineConfig.OverwriteWith = "newest"
+	recombineConfig.ForceFlushTimeout = c.ForceFlushPeriod
+	recombiner, err := recombineConfig.Build(logger)
+	if err != nil {
+		return nil, fmt.Errorf("build the recombine operator: %w", err)
+	}
+
+	return &Parser{
+		TransformerOperator:     transformerOperator,
+		format:                  c.Format,
+		addMetadataFromFilePath: c.AddMetadataFromFilePath,
+		json:                    jsoniter.ConfigFastest,
+		recombiner:              recombiner,
+	}, nil
+}
+
+// Parser is an operator that parses the logs written by container runtimes.
+type Parser struct {
+	helper.TransformerOperator
+
+	format                  string
+	addMetadataFromFilePath bool
+	json                    jsoniter.API
+	recombiner              operator.Operator
+}
+
+// dockerLog is a line of the json-file logging driver of docker
+type dockerLog struct {
+	Log    string `json:"log"`
+	Stream string `json:"stream"`
+	Time   string `json:"time"`
+}
+
+// Start will start the recombine operator joining the partial lines.
+func (p *Parser) Start(persister operator.Persister) error {
+	return p.recombiner.Start(persister)
+}
+
+// Stop will flush the partial lines that were not joined yet.
+func (p *Parser) Stop() error {
+	return p.recombiner.Stop()
+}
+
+// SetOutputs will set the outputs of the parser and of the recombine operator.
+func (p *Parser) SetOutputs(operators []operator.Operator) error {
+	if err := p.TransformerOperator.SetOutputs(operators); err != nil {
+		return err
+	}
+	p.recombiner.SetOutputIDs(p.GetOutputIDs())
+	return p.recombiner.SetOutputs(operators)
+}
+
+// Process will parse an entry written by a container runtime.
+func (p *Parser) Process(ctx context.Context, e *entry.Entry) error {
+	skip, err := p.Skip(ctx, e)
+	if err != nil {
+		return p.HandleEntryError(ctx, e, err)
+	}
+	if skip {
+		p.Write(ctx, e)
+		return nil
+	}
+
+	var line string
+	switch body := e.Body.(type) {
+	case string:
+		line = body
+	case []byte:
+		line = string(body)
+	default:
+		return p.HandleEntryError(ctx, e, fmt.Errorf("type %T cannot be parsed as a container log", e.Body))
+	}
+
+	format := p.format
+	if format == "" {
+		format = detectFormat(line)
+	}
+
+	if format == dockerFormat {
+		err = p.parseDocker(e, line)
+	} else {
+		err = p.parseCRI(e, line)
+	}
+	if err != nil {
+		return p.HandleEntryError(ctx, e, err)
+	}
+
+	if p.addMetadataFromFilePath {
+		p.addPodMetadata(e)
+	}
+	return p.recombiner.Process(ctx, e)
+}
+
+// detectFormat tells docker lines, which are JSON objects, from CRI lines.
+// CRI-O and containerd write their lines in the same format.
+func detectFormat(line string) string {
+	if strings.HasPrefix(line, "{") {
+		return dockerFormat
+	}
+	return containerdFormat
+}
+
+func (p *Parser) parseDocker(e *entry.Entry, line string) error {
+	var parsed dockerLog
+	if err := p.json.UnmarshalFromString(line, &parsed); err != nil {
+		return fmt.Errorf("parse docker log: %w", err)
+	}
+	timestamp, err := time.Parse(time.RFC3339Nano, parsed.Time)
+	if err != nil {
+		return fmt.Errorf("parse docker log time: %w", err)
+	}
+
+	// docker splits the messages longer than 16KiB into several lines, only
+	// the last one ends with a newline
+	logTag := "F"
+	if !strings.HasSuffix(parsed.Log, "\n") {
+		logTag = "P"
+	}
+
+	e.Timestamp = timestamp
+	e.Body = strings.TrimSuffix(parsed.Log, "\n")
+	e.AddAttribute(streamAttribute, parsed.Stream)
+	e.AddAttribute(logTagAttribute, logTag)
+	return nil
+}
+
+func (p *Parser) parseCRI(e *entry.Entry, line string) error {
+	matches := criRegex.FindStringSubmatch(line)
+	if matches == nil {
+		return fmt.Errorf("line does not match the CRI log format")
+	}
+	timestamp, err := time.Parse(time.RFC3339Nano, matches[criRegex.SubexpIndex("time")])
+	if err != nil {
+		return fmt.Errorf("parse CRI log time: %w", err)
+	}
+
+	// the log tag may hold several tags separated by colons, the first one
+	// tells whether the line is partial or full
+	logTag := strings.SplitN(matches[criRegex.SubexpIndex("logtag")], ":", 2)[0]
+
+	e.Timestamp = timestamp
+	e.Body = matches[criRegex.SubexpIndex("log")]
+	e.AddAttribute(streamAttribute, matches[criRegex.SubexpIndex("stream")])
+	e.AddAttribute(logTagAttribute, logTag)
+	return nil
+}
+
+// addPodMetadata adds the kubernetes metadata found in the path of the log
+// file to the resource of the entry. Entries without a path, or whose path is
+// not the log file of a kubernetes container, are left as is: they still go
+// through the recombine operator, so that their partial lines are joined.
+func (p *Parser) addPodMetadata(e *entry.Entry) {
+	field := entry.NewAttributeField(filePathAttribute)
+	if _, ok := e.Get(field); !ok {
+		return
+	}
+	var path string
+	if err := e.Read(field, &path); err != nil {
+		p.Debugw("Failed to read the file path", zap.Error(err))
+		return
+	}
+
+	matches := podPathRegex.FindStringSubmatch(path)
+	if matches == nil {
+		p.Debugw("The file path is not the log file of a kubernetes container", "path", path)
+		return
+	}
+	e.AddResourceKey(namespaceResource, matches[podPathRegex.SubexpIndex("namespace")])
+	e.AddResourceKey(podNameResource, matches[podPathRegex.SubexpIndex("pod_name")])
+	e.AddResourceKey(podUIDResource, matches[podPathRegex.SubexpIndex("uid")])
+	e.AddResourceKey(containerResource, matches[podPathRegex.SubexpIndex("container_name")])
+	e.AddResourceKey(restartCountResource, matches[podPathRegex.SubexpIndex("restart_count")])
+}
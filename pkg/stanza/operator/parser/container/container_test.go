@@ -0,0 +1,339 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+const podLogPath = "/var/log/pods/default_my-pod_8a5f4c2e-7bd4-4e2b-9c54-0ef0c7d5bf1c/app/2.log"
+
+func newTestParser(t *testing.T, configure func(*Config)) (*Parser, *testutil.FakeOutput) {
+	cfg := NewConfigWithID("test")
+	cfg.OutputIDs = []string{"fake"}
+	if configure != nil {
+		configure(cfg)
+	}
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+	require.NoError(t, op.Start(testutil.NewMockPersister("test")))
+	t.Cleanup(func() {
+		require.NoError(t, op.Stop())
+	})
+	return op.(*Parser), fake
+}
+
+func newTestEntry(body interface{}) *entry.Entry {
+	e := entry.New()
+	e.Body = body
+	e.AddAttribute(filePathAttribute, podLogPath)
+	return e
+}
+
+func parseTime(t *testing.T, value string) time.Time {
+	ts, err := time.Parse(time.RFC3339Nano, value)
+	require.NoError(t, err)
+	return ts
+}
+
+func TestConfigBuildFailure(t *testing.T) {
+	cases := []struct {
+		name      string
+		configure func(*Config)
+		err       string
+	}{
+		{
+			name:      "invalid-format",
+			configure: func(cfg *Config) { cfg.Format = "podman" },
+			err:       "invalid `format` 'podman', must be one of 'docker', 'crio' or 'containerd'",
+		},
+		{
+			name:      "force-flush-period",
+			configure: func(cfg *Config) { cfg.ForceFlushPeriod = 0 },
+			err:       "`force_flush_period` must be positive",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			tc.configure(cfg)
+			_, err := cfg.Build(testutil.Logger(t))
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestParser(t *testing.T) {
+	resource := map[string]interface{}{
+		namespaceResource:    "default",
+		podNameResource:      "my-pod",
+		podUIDResource:       "8a5f4c2e-7bd4-4e2b-9c54-0ef0c7d5bf1c",
+		containerResource:    "app",
+		restartCountResource: "2",
+	}
+
+	cases := []struct {
+		name      string
+		configure func(*Config)
+		body      interface{}
+		expect    func(*entry.Entry)
+	}{
+		{
+			name: "docker",
+			body: `{"log":"INFO: started\n","stream":"stderr","time":"2022-10-18T10:27:25.813799277Z"}`,
+			expect: func(e *entry.Entry) {
+				e.Timestamp = parseTime(t, "2022-10-18T10:27:25.813799277Z")
+				e.Body = "INFO: started"
+				e.Attributes[streamAttribute] = "stderr"
+				e.Attributes[logTagAttribute] = "F"
+				e.Resource = resource
+			},
+		},
+		{
+			name: "containerd",
+			body: "2022-10-18T10:27:25.813799277Z stdout F INFO: started",
+			expect: func(e *entry.Entry) {
+				e.Timestamp = parseTime(t, "2022-10-18T10:27:25.813799277Z")
+				e.Body = "INFO: started"
+				e.Attributes[streamAttribute] = "stdout"
+				e.Attributes[logTagAttribute] = "F"
+				e.Resource = resource
+			},
+		},
+		{
+			name: "crio",
+			body: []byte("2022-10-18T10:27:25.813799277+02:00 stderr F INFO: started"),
+			expect: func(e *entry.Entry) {
+				e.Timestamp = parseTime(t, "2022-10-18T10:27:25.813799277+02:00")
+				e.Body = "INFO: started"
+				e.Attributes[streamAttribute] = "stderr"
+				e.Attributes[logTagAttribute] = "F"
+				e.Resource = resource
+			},
+		},
+		{
+			name: "empty-line",
+			body: "2022-10-18T10:27:25.813799277Z stdout F",
+			expect: func(e *entry.Entry) {
+				e.Timestamp = parseTime(t, "2022-10-18T10:27:25.813799277Z")
+				e.Body = ""
+				e.Attributes[streamAttribute] = "stdout"
+				e.Attributes[logTagAttribute] = "F"
+				e.Resource = resource
+			},
+		},
+		{
+			name: "forced-format",
+			configure: func(cfg *Config) {
+				cfg.Format = dockerFormat
+			},
+			body: `{"log":"INFO: started\n","stream":"stdout","time":"2022-10-18T10:27:25.813799277Z"}`,
+			expect: func(e *entry.Entry) {
+				e.Timestamp = parseTime(t, "2022-10-18T10:27:25.813799277Z")
+				e.Body = "INFO: started"
+				e.Attributes[streamAttribute] = "stdout"
+				e.Attributes[logTagAttribute] = "F"
+				e.Resource = resource
+			},
+		},
+		{
+			name: "no-metadata",
+			configure: func(cfg *Config) {
+				cfg.AddMetadataFromFilePath = false
+			},
+			body: "2022-10-18T10:27:25.813799277Z stdout F INFO: started",
+			expect: func(e *entry.Entry) {
+				e.Timestamp = parseTime(t, "2022-10-18T10:27:25.813799277Z")
+				e.Body = "INFO: started"
+				e.Attributes[streamAttribute] = "stdout"
+				e.Attributes[logTagAttribute] = "F"
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parser, fake := newTestParser(t, tc.configure)
+
+			input := newTestEntry(tc.body)
+			expected := input.Copy()
+			// the entries are sent by the recombine operator, which doesn't
+			// copy the empty resource and trace fields
+			expected.Resource = nil
+			expected.TraceID, expected.SpanID, expected.TraceFlags = nil, nil, nil
+			tc.expect(expected)
+
+			require.NoError(t, parser.Process(context.Background(), input))
+			fake.ExpectEntry(t, expected)
+		})
+	}
+}
+
+func TestParserWithoutFilePath(t *testing.T) {
+	parser, fake := newTestParser(t, nil)
+
+	input := entry.New()
+	input.Body = "2022-10-18T10:27:25.813799277Z stdout F INFO: started"
+	require.NoError(t, parser.Process(context.Background(), input))
+
+	select {
+	case e := <-fake.Received:
+		require.Equal(t, "INFO: started", e.Body)
+		require.Empty(t, e.Resource)
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for entry")
+	}
+}
+
+func TestParserErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		body interface{}
+		path string
+		err  string
+	}{
+		{
+			name: "invalid-type",
+			body: 42,
+			path: podLogPath,
+			err:  "type int cannot be parsed as a container log",
+		},
+		{
+			name: "invalid-docker",
+			body: `{"log":`,
+			path: podLogPath,
+			err:  "parse docker log",
+		},
+		{
+			name: "invalid-cri",
+			body: "2022-10-18T10:27:25.813799277Z INFO: started",
+			path: podLogPath,
+			err:  "line does not match the CRI log format",
+		},
+		{
+			name: "invalid-time",
+			body: "yesterday stdout F INFO: started",
+			path: podLogPath,
+			err:  "parse CRI log time",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parser, fake := newTestParser(t, nil)
+
+			input := newTestEntry(tc.body)
+			input.Attributes[filePathAttribute] = tc.path
+			err := parser.Process(context.Background(), input)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+
+			// the entry is sent as is with the default on_error
+			fake.ExpectBody(t, tc.body)
+		})
+	}
+}
+
+// TestParserNonKubeletFilePath tests that the lines of a file that is not the
+// log file of a kubernetes container are parsed and joined, without metadata
+func TestParserNonKubeletFilePath(t *testing.T) {
+	parser, fake := newTestParser(t, nil)
+
+	lines := []string{
+		`{"log":"INFO: a long ","stream":"stdout","time":"2022-10-18T10:27:25.813799277Z"}`,
+		`{"log":"message\n","stream":"stdout","time":"2022-10-18T10:27:25.813799278Z"}`,
+	}
+	for _, line := range lines {
+		input := newTestEntry(line)
+		input.Attributes[filePathAttribute] = "/var/lib/docker/containers/0123456789ab/0123456789ab-json.log"
+		require.NoError(t, parser.Process(context.Background(), input))
+	}
+
+	select {
+	case e := <-fake.Received:
+		require.Equal(t, "INFO: a long message", e.Body)
+		require.Equal(t, "stdout", e.Attributes[streamAttribute])
+		require.Empty(t, e.Resource)
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for entry")
+	}
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+}
+
+func TestParserJoinsPartialLines(t *testing.T) {
+	parser, fake := newTestParser(t, nil)
+
+	lines := []string{
+		"2022-10-18T10:27:25.813799277Z stdout P INFO: a long ",
+		"2022-10-18T10:27:25.813799278Z stdout P message split ",
+		"2022-10-18T10:27:25.813799279Z stdout F in three lines",
+	}
+	for _, line := range lines {
+		require.NoError(t, parser.Process(context.Background(), newTestEntry(line)))
+	}
+
+	select {
+	case e := <-fake.Received:
+		require.Equal(t, "INFO: a long message split in three lines", e.Body)
+		require.Equal(t, parseTime(t, "2022-10-18T10:27:25.813799279Z"), e.Timestamp)
+		require.Equal(t, "F", e.Attributes[logTagAttribute])
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for entry")
+	}
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+}
+
+func TestParserJoinsDockerPartialLines(t *testing.T) {
+	parser, fake := newTestParser(t, nil)
+
+	lines := []string{
+		`{"log":"INFO: a long ","stream":"stdout","time":"2022-10-18T10:27:25.813799277Z"}`,
+		`{"log":"message split ","stream":"stdout","time":"2022-10-18T10:27:25.813799278Z"}`,
+		`{"log":"in three lines\n","stream":"stdout","time":"2022-10-18T10:27:25.813799279Z"}`,
+	}
+	for _, line := range lines {
+		require.NoError(t, parser.Process(context.Background(), newTestEntry(line)))
+	}
+
+	select {
+	case e := <-fake.Received:
+		require.Equal(t, "INFO: a long message split in three lines", e.Body)
+		require.Equal(t, parseTime(t, "2022-10-18T10:27:25.813799279Z"), e.Timestamp)
+		require.Equal(t, "F", e.Attributes[logTagAttribute])
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for entry")
+	}
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+}
+
+func TestParserFlushesPartialLines(t *testing.T) {
+	parser, fake := newTestParser(t, func(cfg *Config) {
+		cfg.ForceFlushPeriod = 100 * time.Millisecond
+	})
+
+	require.NoError(t, parser.Process(context.Background(), newTestEntry("2022-10-18T10:27:25.813799277Z stdout P INFO: partial")))
+	fake.ExpectBody(t, "INFO: partial")
+}
@@ -959,7 +959,9 @@ func setAttr(attrs pcommon.Map, mapKey string, val interface{}) {
 			arr.SliceVal().AppendEmpty().SetBytesVal(pcommon.NewImmutableByteSlice(b))
 		}
 		attrs.Upsert(mapKey, arr)
-	default:
-		// TODO(anuraaga): Support set of map type.
+	case pcommon.Map:
+		m := pcommon.NewValueMap()
+		v.CopyTo(m.MapVal())
+		attrs.Upsert(mapKey, m)
 	}
 }
@@ -31,6 +31,9 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 
 	newExemplars, newAttrs, newArrStr, newArrBool, newArrInt, newArrFloat, newArrBytes := createNewTelemetry()
 
+	newMap := pcommon.NewMap()
+	newMap.UpsertString("hello", "world")
+
 	tests := []struct {
 		name      string
 		path      []tql.Field
@@ -287,6 +290,22 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 				datapoint.Attributes().Upsert("arr_bytes", newArrBytes)
 			},
 		},
+		{
+			name: "attributes map",
+			path: []tql.Field{
+				{
+					Name:   "attributes",
+					MapKey: tqltest.Strp("map"),
+				},
+			},
+			orig:   nil,
+			newVal: newMap,
+			modified: func(datapoint pmetric.NumberDataPoint) {
+				m := pcommon.NewValueMap()
+				m.MapVal().UpsertString("hello", "world")
+				datapoint.Attributes().Upsert("map", m)
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,171 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqltraces // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqltraces"
+
+import (
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+// SpanEventTransformContext is the TransformContext used to process the events of a span one by one.
+type SpanEventTransformContext struct {
+	SpanEvent            ptrace.SpanEvent
+	Span                 ptrace.Span
+	InstrumentationScope pcommon.InstrumentationScope
+	Resource             pcommon.Resource
+}
+
+func (ctx SpanEventTransformContext) GetItem() interface{} {
+	return ctx.SpanEvent
+}
+
+func (ctx SpanEventTransformContext) GetInstrumentationScope() pcommon.InstrumentationScope {
+	return ctx.InstrumentationScope
+}
+
+func (ctx SpanEventTransformContext) GetResource() pcommon.Resource {
+	return ctx.Resource
+}
+
+func (ctx SpanEventTransformContext) GetSpan() ptrace.Span {
+	return ctx.Span
+}
+
+// ParseSpanEventPath parses the paths of the SpanEventTransformContext.
+func ParseSpanEventPath(val *tql.Path) (tql.GetSetter, error) {
+	if val != nil && len(val.Fields) > 0 {
+		return newSpanEventPathGetSetter(val.Fields)
+	}
+	return nil, fmt.Errorf("bad path %v", val)
+}
+
+func newSpanEventPathGetSetter(path []tql.Field) (tql.GetSetter, error) {
+	switch path[0].Name {
+	case "resource", "instrumentation_library":
+		return newPathGetSetter(path)
+	case "span":
+		return accessParentSpan(path[1:])
+	case "name":
+		return accessSpanEventName(), nil
+	case "time_unix_nano":
+		return accessSpanEventTimeUnixNano(), nil
+	case "attributes":
+		mapKey := path[0].MapKey
+		if mapKey == nil {
+			return accessSpanEventAttributes(), nil
+		}
+		return accessSpanEventAttributesKey(mapKey), nil
+	case "dropped_attributes_count":
+		return accessSpanEventDroppedAttributesCount(), nil
+	}
+	return nil, fmt.Errorf("invalid path expression, unrecognized field %v", path[0].Name)
+}
+
+// spanContext is implemented by the TransformContexts of the items of a span.
+type spanContext interface {
+	tql.TransformContext
+	GetSpan() ptrace.Span
+}
+
+// accessParentSpan gives read access to the span that contains the item being processed, using the paths of
+// the span context. Setting a field of the parent span has no effect.
+func accessParentSpan(path []tql.Field) (tql.GetSetter, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("invalid path expression, span must be followed by a span field")
+	}
+	spanGetSetter, err := newPathGetSetter(path)
+	if err != nil {
+		return nil, err
+	}
+	return tql.StandardGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			parent := ctx.(spanContext)
+			return spanGetSetter.Get(SpanTransformContext{
+				Span:                 parent.GetSpan(),
+				InstrumentationScope: parent.GetInstrumentationScope(),
+				Resource:             parent.GetResource(),
+			})
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {},
+	}, nil
+}
+
+func accessSpanEventName() tql.StandardGetSetter {
+	return tql.StandardGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return ctx.GetItem().(ptrace.SpanEvent).Name()
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			if str, ok := val.(string); ok {
+				ctx.GetItem().(ptrace.SpanEvent).SetName(str)
+			}
+		},
+	}
+}
+
+func accessSpanEventTimeUnixNano() tql.StandardGetSetter {
+	return tql.StandardGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return ctx.GetItem().(ptrace.SpanEvent).Timestamp().AsTime().UnixNano()
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			if i, ok := val.(int64); ok {
+				ctx.GetItem().(ptrace.SpanEvent).SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, i)))
+			}
+		},
+	}
+}
+
+func accessSpanEventAttributes() tql.StandardGetSetter {
+	return tql.StandardGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return ctx.GetItem().(ptrace.SpanEvent).Attributes()
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			if attrs, ok := val.(pcommon.Map); ok {
+				attrs.CopyTo(ctx.GetItem().(ptrace.SpanEvent).Attributes())
+			}
+		},
+	}
+}
+
+func accessSpanEventAttributesKey(mapKey *string) tql.StandardGetSetter {
+	return tql.StandardGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return getAttr(ctx.GetItem().(ptrace.SpanEvent).Attributes(), *mapKey)
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			setAttr(ctx.GetItem().(ptrace.SpanEvent).Attributes(), *mapKey, val)
+		},
+	}
+}
+
+func accessSpanEventDroppedAttributesCount() tql.StandardGetSetter {
+	return tql.StandardGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return int64(ctx.GetItem().(ptrace.SpanEvent).DroppedAttributesCount())
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			if i, ok := val.(int64); ok {
+				ctx.GetItem().(ptrace.SpanEvent).SetDroppedAttributesCount(uint32(i))
+			}
+		},
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqltraces
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_newSpanEventPathGetSetter(t *testing.T) {
+	refEvent, _, _, _ := createSpanEventTelemetry()
+
+	newAttrs := pcommon.NewMap()
+	newAttrs.UpsertString("hello", "world")
+
+	tests := []struct {
+		name     string
+		path     []tql.Field
+		orig     interface{}
+		newVal   interface{}
+		modified func(event ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource)
+	}{
+		{
+			name: "name",
+			path: []tql.Field{
+				{
+					Name: "name",
+				},
+			},
+			orig:   "exception",
+			newVal: "cat",
+			modified: func(event ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				event.SetName("cat")
+			},
+		},
+		{
+			name: "time_unix_nano",
+			path: []tql.Field{
+				{
+					Name: "time_unix_nano",
+				},
+			},
+			orig:   int64(200_000_000),
+			newVal: int64(300_000_000),
+			modified: func(event ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				event.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(300)))
+			},
+		},
+		{
+			name: "attributes",
+			path: []tql.Field{
+				{
+					Name: "attributes",
+				},
+			},
+			orig:   refEvent.Attributes(),
+			newVal: newAttrs,
+			modified: func(event ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				newAttrs.CopyTo(event.Attributes())
+			},
+		},
+		{
+			name: "attributes string",
+			path: []tql.Field{
+				{
+					Name:   "attributes",
+					MapKey: tqltest.Strp("exception.stacktrace"),
+				},
+			},
+			orig:   "panic: at line 1",
+			newVal: "redacted",
+			modified: func(event ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				event.Attributes().UpsertString("exception.stacktrace", "redacted")
+			},
+		},
+		{
+			name: "dropped_attributes_count",
+			path: []tql.Field{
+				{
+					Name: "dropped_attributes_count",
+				},
+			},
+			orig:   int64(5),
+			newVal: int64(6),
+			modified: func(event ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				event.SetDroppedAttributesCount(6)
+			},
+		},
+		{
+			name: "resource attributes string",
+			path: []tql.Field{
+				{
+					Name: "resource",
+				},
+				{
+					Name:   "attributes",
+					MapKey: tqltest.Strp("str"),
+				},
+			},
+			orig:   "val",
+			newVal: "newVal",
+			modified: func(event ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				resource.Attributes().UpsertString("str", "newVal")
+			},
+		},
+		{
+			name: "instrumentation_library name",
+			path: []tql.Field{
+				{
+					Name: "instrumentation_library",
+				},
+				{
+					Name: "name",
+				},
+			},
+			orig:   "library",
+			newVal: "park",
+			modified: func(event ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				il.SetName("park")
+			},
+		},
+		{
+			name: "span name is read only",
+			path: []tql.Field{
+				{
+					Name: "span",
+				},
+				{
+					Name: "name",
+				},
+			},
+			orig:   "bear",
+			newVal: "cat",
+			modified: func(event ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+			},
+		},
+		{
+			name: "span attributes string",
+			path: []tql.Field{
+				{
+					Name: "span",
+				},
+				{
+					Name:   "attributes",
+					MapKey: tqltest.Strp("str"),
+				},
+			},
+			orig:   "val",
+			newVal: "newVal",
+			modified: func(event ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessor, err := newSpanEventPathGetSetter(tt.path)
+			assert.NoError(t, err)
+
+			event, span, il, resource := createSpanEventTelemetry()
+
+			ctx := SpanEventTransformContext{
+				SpanEvent:            event,
+				Span:                 span,
+				InstrumentationScope: il,
+				Resource:             resource,
+			}
+
+			got := accessor.Get(ctx)
+			assert.Equal(t, tt.orig, got)
+
+			accessor.Set(ctx, tt.newVal)
+
+			exEvent, exSpan, exIl, exRes := createSpanEventTelemetry()
+			tt.modified(exEvent, exSpan, exIl, exRes)
+
+			assert.Equal(t, exEvent, event)
+			assert.Equal(t, exSpan, span)
+			assert.Equal(t, exIl, il)
+			assert.Equal(t, exRes, resource)
+		})
+	}
+}
+
+func Test_newSpanEventPathGetSetter_invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		path []tql.Field
+	}{
+		{
+			name: "unknown field",
+			path: []tql.Field{
+				{
+					Name: "kind",
+				},
+			},
+		},
+		{
+			name: "span without field",
+			path: []tql.Field{
+				{
+					Name: "span",
+				},
+			},
+		},
+		{
+			name: "unknown span field",
+			path: []tql.Field{
+				{
+					Name: "span",
+				},
+				{
+					Name: "unknown",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newSpanEventPathGetSetter(tt.path)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func createSpanEventTelemetry() (ptrace.SpanEvent, ptrace.Span, pcommon.InstrumentationScope, pcommon.Resource) {
+	span, il, resource := createTelemetry()
+
+	event := ptrace.NewSpanEvent()
+	event.SetName("exception")
+	event.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
+	event.Attributes().UpsertString("exception.type", "panic")
+	event.Attributes().UpsertString("exception.stacktrace", "panic: at line 1")
+	event.SetDroppedAttributesCount(5)
+
+	return event, span, il, resource
+}
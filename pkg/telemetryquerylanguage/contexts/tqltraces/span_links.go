@@ -0,0 +1,224 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqltraces // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqltraces"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+// SpanLinkTransformContext is the TransformContext used to process the links of a span one by one.
+type SpanLinkTransformContext struct {
+	SpanLink             ptrace.SpanLink
+	Span                 ptrace.Span
+	InstrumentationScope pcommon.InstrumentationScope
+	Resource             pcommon.Resource
+}
+
+func (ctx SpanLinkTransformContext) GetItem() interface{} {
+	return ctx.SpanLink
+}
+
+func (ctx SpanLinkTransformContext) GetInstrumentationScope() pcommon.InstrumentationScope {
+	return ctx.InstrumentationScope
+}
+
+func (ctx SpanLinkTransformContext) GetResource() pcommon.Resource {
+	return ctx.Resource
+}
+
+func (ctx SpanLinkTransformContext) GetSpan() ptrace.Span {
+	return ctx.Span
+}
+
+// ParseSpanLinkPath parses the paths of the SpanLinkTransformContext.
+func ParseSpanLinkPath(val *tql.Path) (tql.GetSetter, error) {
+	if val != nil && len(val.Fields) > 0 {
+		return newSpanLinkPathGetSetter(val.Fields)
+	}
+	return nil, fmt.Errorf("bad path %v", val)
+}
+
+func newSpanLinkPathGetSetter(path []tql.Field) (tql.GetSetter, error) {
+	switch path[0].Name {
+	case "resource", "instrumentation_library":
+		return newPathGetSetter(path)
+	case "span":
+		return accessParentSpan(path[1:])
+	case "trace_id":
+		if len(path) == 1 {
+			return accessSpanLinkTraceID(), nil
+		}
+		if path[1].Name == "string" {
+			return accessSpanLinkStringTraceID(), nil
+		}
+	case "span_id":
+		if len(path) == 1 {
+			return accessSpanLinkSpanID(), nil
+		}
+		if path[1].Name == "string" {
+			return accessSpanLinkStringSpanID(), nil
+		}
+	case "trace_state":
+		mapKey := path[0].MapKey
+		if mapKey == nil {
+			return accessSpanLinkTraceState(), nil
+		}
+		return accessSpanLinkTraceStateKey(mapKey), nil
+	case "attributes":
+		mapKey := path[0].MapKey
+		if mapKey == nil {
+			return accessSpanLinkAttributes(), nil
+		}
+		return accessSpanLinkAttributesKey(mapKey), nil
+	case "dropped_attributes_count":
+		return accessSpanLinkDroppedAttributesCount(), nil
+	default:
+		return nil, fmt.Errorf("invalid path expression, unrecognized field %v", path[0].Name)
+	}
+
+	return nil, fmt.Errorf("invalid path expression %v", path)
+}
+
+func accessSpanLinkTraceID() tql.StandardGetSetter {
+	return tql.StandardGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return ctx.GetItem().(ptrace.SpanLink).TraceID()
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			if newTraceID, ok := val.(pcommon.TraceID); ok {
+				ctx.GetItem().(ptrace.SpanLink).SetTraceID(newTraceID)
+			}
+		},
+	}
+}
+
+func accessSpanLinkStringTraceID() tql.StandardGetSetter {
+	return tql.StandardGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return ctx.GetItem().(ptrace.SpanLink).TraceID().HexString()
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			if str, ok := val.(string); ok {
+				if traceID, err := parseTraceID(str); err == nil {
+					ctx.GetItem().(ptrace.SpanLink).SetTraceID(traceID)
+				}
+			}
+		},
+	}
+}
+
+func accessSpanLinkSpanID() tql.StandardGetSetter {
+	return tql.StandardGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return ctx.GetItem().(ptrace.SpanLink).SpanID()
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			if newSpanID, ok := val.(pcommon.SpanID); ok {
+				ctx.GetItem().(ptrace.SpanLink).SetSpanID(newSpanID)
+			}
+		},
+	}
+}
+
+func accessSpanLinkStringSpanID() tql.StandardGetSetter {
+	return tql.StandardGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return ctx.GetItem().(ptrace.SpanLink).SpanID().HexString()
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			if str, ok := val.(string); ok {
+				if spanID, err := parseSpanID(str); err == nil {
+					ctx.GetItem().(ptrace.SpanLink).SetSpanID(spanID)
+				}
+			}
+		},
+	}
+}
+
+func accessSpanLinkTraceState() tql.StandardGetSetter {
+	return tql.StandardGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return (string)(ctx.GetItem().(ptrace.SpanLink).TraceState())
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			if str, ok := val.(string); ok {
+				ctx.GetItem().(ptrace.SpanLink).SetTraceState(ptrace.TraceState(str))
+			}
+		},
+	}
+}
+
+func accessSpanLinkTraceStateKey(mapKey *string) tql.StandardGetSetter {
+	return tql.StandardGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			if ts, err := trace.ParseTraceState(string(ctx.GetItem().(ptrace.SpanLink).TraceState())); err == nil {
+				return ts.Get(*mapKey)
+			}
+			return nil
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			if str, ok := val.(string); ok {
+				if ts, err := trace.ParseTraceState(string(ctx.GetItem().(ptrace.SpanLink).TraceState())); err == nil {
+					if updated, err := ts.Insert(*mapKey, str); err == nil {
+						ctx.GetItem().(ptrace.SpanLink).SetTraceState(ptrace.TraceState(updated.String()))
+					}
+				}
+			}
+		},
+	}
+}
+
+func accessSpanLinkAttributes() tql.StandardGetSetter {
+	return tql.StandardGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return ctx.GetItem().(ptrace.SpanLink).Attributes()
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			if attrs, ok := val.(pcommon.Map); ok {
+				attrs.CopyTo(ctx.GetItem().(ptrace.SpanLink).Attributes())
+			}
+		},
+	}
+}
+
+func accessSpanLinkAttributesKey(mapKey *string) tql.StandardGetSetter {
+	return tql.StandardGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return getAttr(ctx.GetItem().(ptrace.SpanLink).Attributes(), *mapKey)
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			setAttr(ctx.GetItem().(ptrace.SpanLink).Attributes(), *mapKey, val)
+		},
+	}
+}
+
+func accessSpanLinkDroppedAttributesCount() tql.StandardGetSetter {
+	return tql.StandardGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return int64(ctx.GetItem().(ptrace.SpanLink).DroppedAttributesCount())
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			if i, ok := val.(int64); ok {
+				ctx.GetItem().(ptrace.SpanLink).SetDroppedAttributesCount(uint32(i))
+			}
+		},
+	}
+}
@@ -0,0 +1,257 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqltraces
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_newSpanLinkPathGetSetter(t *testing.T) {
+	refLink, _, _, _ := createSpanLinkTelemetry()
+
+	newAttrs := pcommon.NewMap()
+	newAttrs.UpsertString("hello", "world")
+
+	tests := []struct {
+		name     string
+		path     []tql.Field
+		orig     interface{}
+		newVal   interface{}
+		modified func(link ptrace.SpanLink, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource)
+	}{
+		{
+			name: "trace_id",
+			path: []tql.Field{
+				{
+					Name: "trace_id",
+				},
+			},
+			orig:   pcommon.NewTraceID(traceID2),
+			newVal: pcommon.NewTraceID(traceID),
+			modified: func(link ptrace.SpanLink, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				link.SetTraceID(pcommon.NewTraceID(traceID))
+			},
+		},
+		{
+			name: "trace_id string",
+			path: []tql.Field{
+				{
+					Name: "trace_id",
+				},
+				{
+					Name: "string",
+				},
+			},
+			orig:   hex.EncodeToString(traceID2[:]),
+			newVal: hex.EncodeToString(traceID[:]),
+			modified: func(link ptrace.SpanLink, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				link.SetTraceID(pcommon.NewTraceID(traceID))
+			},
+		},
+		{
+			name: "span_id",
+			path: []tql.Field{
+				{
+					Name: "span_id",
+				},
+			},
+			orig:   pcommon.NewSpanID(spanID2),
+			newVal: pcommon.NewSpanID(spanID),
+			modified: func(link ptrace.SpanLink, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				link.SetSpanID(pcommon.NewSpanID(spanID))
+			},
+		},
+		{
+			name: "span_id string",
+			path: []tql.Field{
+				{
+					Name: "span_id",
+				},
+				{
+					Name: "string",
+				},
+			},
+			orig:   hex.EncodeToString(spanID2[:]),
+			newVal: hex.EncodeToString(spanID[:]),
+			modified: func(link ptrace.SpanLink, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				link.SetSpanID(pcommon.NewSpanID(spanID))
+			},
+		},
+		{
+			name: "trace_state",
+			path: []tql.Field{
+				{
+					Name: "trace_state",
+				},
+			},
+			orig:   "key1=val1",
+			newVal: "key1=val2",
+			modified: func(link ptrace.SpanLink, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				link.SetTraceState("key1=val2")
+			},
+		},
+		{
+			name: "trace_state key",
+			path: []tql.Field{
+				{
+					Name:   "trace_state",
+					MapKey: tqltest.Strp("key1"),
+				},
+			},
+			orig:   "val1",
+			newVal: "val2",
+			modified: func(link ptrace.SpanLink, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				link.SetTraceState("key1=val2")
+			},
+		},
+		{
+			name: "attributes",
+			path: []tql.Field{
+				{
+					Name: "attributes",
+				},
+			},
+			orig:   refLink.Attributes(),
+			newVal: newAttrs,
+			modified: func(link ptrace.SpanLink, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				newAttrs.CopyTo(link.Attributes())
+			},
+		},
+		{
+			name: "attributes string",
+			path: []tql.Field{
+				{
+					Name:   "attributes",
+					MapKey: tqltest.Strp("str"),
+				},
+			},
+			orig:   "link",
+			newVal: "newVal",
+			modified: func(link ptrace.SpanLink, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				link.Attributes().UpsertString("str", "newVal")
+			},
+		},
+		{
+			name: "dropped_attributes_count",
+			path: []tql.Field{
+				{
+					Name: "dropped_attributes_count",
+				},
+			},
+			orig:   int64(3),
+			newVal: int64(4),
+			modified: func(link ptrace.SpanLink, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				link.SetDroppedAttributesCount(4)
+			},
+		},
+		{
+			name: "span trace_id is read only",
+			path: []tql.Field{
+				{
+					Name: "span",
+				},
+				{
+					Name: "trace_id",
+				},
+			},
+			orig:   pcommon.NewTraceID(traceID),
+			newVal: pcommon.NewTraceID(traceID2),
+			modified: func(link ptrace.SpanLink, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessor, err := newSpanLinkPathGetSetter(tt.path)
+			assert.NoError(t, err)
+
+			link, span, il, resource := createSpanLinkTelemetry()
+
+			ctx := SpanLinkTransformContext{
+				SpanLink:             link,
+				Span:                 span,
+				InstrumentationScope: il,
+				Resource:             resource,
+			}
+
+			got := accessor.Get(ctx)
+			assert.Equal(t, tt.orig, got)
+
+			accessor.Set(ctx, tt.newVal)
+
+			exLink, exSpan, exIl, exRes := createSpanLinkTelemetry()
+			tt.modified(exLink, exSpan, exIl, exRes)
+
+			assert.Equal(t, exLink, link)
+			assert.Equal(t, exSpan, span)
+			assert.Equal(t, exIl, il)
+			assert.Equal(t, exRes, resource)
+		})
+	}
+}
+
+func Test_newSpanLinkPathGetSetter_invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		path []tql.Field
+	}{
+		{
+			name: "unknown field",
+			path: []tql.Field{
+				{
+					Name: "name",
+				},
+			},
+		},
+		{
+			name: "unknown trace_id field",
+			path: []tql.Field{
+				{
+					Name: "trace_id",
+				},
+				{
+					Name: "bytes",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newSpanLinkPathGetSetter(tt.path)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func createSpanLinkTelemetry() (ptrace.SpanLink, ptrace.Span, pcommon.InstrumentationScope, pcommon.Resource) {
+	span, il, resource := createTelemetry()
+
+	link := ptrace.NewSpanLink()
+	link.SetTraceID(pcommon.NewTraceID(traceID2))
+	link.SetSpanID(pcommon.NewSpanID(spanID2))
+	link.SetTraceState("key1=val1")
+	link.Attributes().UpsertString("str", "link")
+	link.SetDroppedAttributesCount(3)
+
+	return link, span, il, resource
+}
@@ -64,6 +64,9 @@ func Test_newPathGetSetter(t *testing.T) {
 	newArrBytes := pcommon.NewValueSlice()
 	newArrBytes.SliceVal().AppendEmpty().SetBytesVal(pcommon.NewImmutableByteSlice([]byte{9, 6, 4}))
 
+	newMap := pcommon.NewMap()
+	newMap.UpsertString("hello", "world")
+
 	tests := []struct {
 		name     string
 		path     []tql.Field
@@ -390,6 +393,22 @@ func Test_newPathGetSetter(t *testing.T) {
 				span.Attributes().Upsert("arr_bytes", newArrBytes)
 			},
 		},
+		{
+			name: "attributes map",
+			path: []tql.Field{
+				{
+					Name:   "attributes",
+					MapKey: tqltest.Strp("map"),
+				},
+			},
+			orig:   nil,
+			newVal: newMap,
+			modified: func(span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				m := pcommon.NewValueMap()
+				m.MapVal().UpsertString("hello", "world")
+				span.Attributes().Upsert("map", m)
+			},
+		},
 		{
 			name: "dropped_attributes_count",
 			path: []tql.Field{
@@ -0,0 +1,75 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_concat(t *testing.T) {
+	tests := []struct {
+		name     string
+		vals     []interface{}
+		expected string
+	}{
+		{
+			name:     "strings",
+			vals:     []interface{}{"hello", " ", "world"},
+			expected: "hello world",
+		},
+		{
+			name:     "mixed types",
+			vals:     []interface{}{"status=", int64(200), ",ratio=", 0.5, ",ok=", true},
+			expected: "status=200,ratio=0.5,ok=true",
+		},
+		{
+			name:     "bytes",
+			vals:     []interface{}{"id-", []byte{1, 2, 3, 4}},
+			expected: "id-01020304",
+		},
+		{
+			name:     "unsupported value is skipped",
+			vals:     []interface{}{"a", map[string]interface{}{"k": "v"}, "b"},
+			expected: "ab",
+		},
+		{
+			name:     "no values",
+			vals:     nil,
+			expected: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getters := make([]tql.Getter, len(tt.vals))
+			for i, val := range tt.vals {
+				val := val
+				getters[i] = &tql.StandardGetSetter{
+					Getter: func(ctx tql.TransformContext) interface{} {
+						return val
+					},
+				}
+			}
+			exprFunc, err := Concat(getters)
+			assert.NoError(t, err)
+			result := exprFunc(tqltest.TestTransformContext{})
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
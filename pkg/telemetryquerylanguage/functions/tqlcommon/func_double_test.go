@@ -0,0 +1,85 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_double(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected interface{}
+	}{
+		{
+			name:     "float",
+			value:    1.5,
+			expected: 1.5,
+		},
+		{
+			name:     "int",
+			value:    int64(2),
+			expected: 2.0,
+		},
+		{
+			name:     "true",
+			value:    true,
+			expected: 1.0,
+		},
+		{
+			name:     "false",
+			value:    false,
+			expected: 0.0,
+		},
+		{
+			name:     "string",
+			value:    "3.14",
+			expected: 3.14,
+		},
+		{
+			name:     "int string",
+			value:    "3",
+			expected: 3.0,
+		},
+		{
+			name:     "invalid string",
+			value:    "pi",
+			expected: nil,
+		},
+		{
+			name:     "nil",
+			value:    nil,
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := Double(&tql.StandardGetSetter{
+				Getter: func(ctx tql.TransformContext) interface{} {
+					return tt.value
+				},
+			})
+			assert.NoError(t, err)
+			result := exprFunc(tqltest.TestTransformContext{})
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
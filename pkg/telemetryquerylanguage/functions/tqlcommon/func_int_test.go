@@ -0,0 +1,95 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_int(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected interface{}
+	}{
+		{
+			name:     "int",
+			value:    int64(5),
+			expected: int64(5),
+		},
+		{
+			name:     "float",
+			value:    1.9,
+			expected: int64(1),
+		},
+		{
+			name:     "negative float",
+			value:    -1.9,
+			expected: int64(-1),
+		},
+		{
+			name:     "true",
+			value:    true,
+			expected: int64(1),
+		},
+		{
+			name:     "false",
+			value:    false,
+			expected: int64(0),
+		},
+		{
+			name:     "string",
+			value:    "-42",
+			expected: int64(-42),
+		},
+		{
+			name:     "invalid string",
+			value:    "forty-two",
+			expected: nil,
+		},
+		{
+			name:     "float string",
+			value:    "1.5",
+			expected: nil,
+		},
+		{
+			name:     "nil",
+			value:    nil,
+			expected: nil,
+		},
+		{
+			name:     "bytes",
+			value:    []byte{1},
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := Int(&tql.StandardGetSetter{
+				Getter: func(ctx tql.TransformContext) interface{} {
+					return tt.value
+				},
+			})
+			assert.NoError(t, err)
+			result := exprFunc(tqltest.TestTransformContext{})
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
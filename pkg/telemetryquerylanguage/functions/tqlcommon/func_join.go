@@ -25,20 +25,7 @@ func Join(delimiter string, vals []tql.Getter) (tql.ExprFunc, error) {
 	return func(ctx tql.TransformContext) interface{} {
 		builder := strings.Builder{}
 		for i, rv := range vals {
-			switch val := rv.Get(ctx).(type) {
-			case string:
-				builder.WriteString(val)
-			case []byte:
-				builder.WriteString(fmt.Sprintf("%x", val))
-			case int64:
-				builder.WriteString(fmt.Sprint(val))
-			case float64:
-				builder.WriteString(fmt.Sprint(val))
-			case bool:
-				builder.WriteString(fmt.Sprint(val))
-			case nil:
-				builder.WriteString(fmt.Sprint(val))
-			}
+			writeValue(&builder, rv.Get(ctx))
 
 			if i != len(vals)-1 {
 				builder.WriteString(delimiter)
@@ -47,3 +34,21 @@ func Join(delimiter string, vals []tql.Getter) (tql.ExprFunc, error) {
 		return builder.String()
 	}, nil
 }
+
+// writeValue writes the string representation of val to builder. Unsupported values are skipped.
+func writeValue(builder *strings.Builder, val interface{}) {
+	switch v := val.(type) {
+	case string:
+		builder.WriteString(v)
+	case []byte:
+		builder.WriteString(fmt.Sprintf("%x", v))
+	case int64:
+		builder.WriteString(fmt.Sprint(v))
+	case float64:
+		builder.WriteString(fmt.Sprint(v))
+	case bool:
+		builder.WriteString(fmt.Sprint(v))
+	case nil:
+		builder.WriteString(fmt.Sprint(v))
+	}
+}
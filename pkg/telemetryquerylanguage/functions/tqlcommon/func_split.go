@@ -0,0 +1,34 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlcommon // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlcommon"
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+func Split(target tql.Getter, delimiter string) (tql.ExprFunc, error) {
+	if delimiter == "" {
+		return nil, fmt.Errorf("the delimiter supplied to Split must not be empty")
+	}
+	return func(ctx tql.TransformContext) interface{} {
+		if value, ok := target.Get(ctx).(string); ok {
+			return strings.Split(value, delimiter)
+		}
+		return nil
+	}, nil
+}
@@ -0,0 +1,81 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_split(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     interface{}
+		delimiter string
+		expected  interface{}
+	}{
+		{
+			name:      "split string",
+			value:     "a,b,c",
+			delimiter: ",",
+			expected:  []string{"a", "b", "c"},
+		},
+		{
+			name:      "multi-character delimiter",
+			value:     "a, b, c",
+			delimiter: ", ",
+			expected:  []string{"a", "b", "c"},
+		},
+		{
+			name:      "delimiter not found",
+			value:     "abc",
+			delimiter: ",",
+			expected:  []string{"abc"},
+		},
+		{
+			name:      "not a string",
+			value:     int64(1),
+			delimiter: ",",
+			expected:  nil,
+		},
+		{
+			name:      "nil",
+			value:     nil,
+			delimiter: ",",
+			expected:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := Split(&tql.StandardGetSetter{
+				Getter: func(ctx tql.TransformContext) interface{} {
+					return tt.value
+				},
+			}, tt.delimiter)
+			assert.NoError(t, err)
+			result := exprFunc(tqltest.TestTransformContext{})
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_split_validation(t *testing.T) {
+	_, err := Split(&tql.StandardGetSetter{}, "")
+	assert.Error(t, err)
+}
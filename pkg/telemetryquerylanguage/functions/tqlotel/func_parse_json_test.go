@@ -0,0 +1,101 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlotel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_parseJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  func() interface{}
+	}{
+		{
+			name:  "flat object",
+			value: `{"name": "test", "count": 2, "ok": true}`,
+			want: func() interface{} {
+				expected := pcommon.NewMap()
+				expected.InsertString("name", "test")
+				expected.InsertDouble("count", 2)
+				expected.InsertBool("ok", true)
+				return expected
+			},
+		},
+		{
+			name:  "nested object and array",
+			value: `{"http": {"method": "GET"}, "tags": ["a", "b"]}`,
+			want: func() interface{} {
+				expected := pcommon.NewMap()
+				http := pcommon.NewValueMap()
+				http.MapVal().InsertString("method", "GET")
+				expected.Insert("http", http)
+				tags := pcommon.NewValueSlice()
+				tags.SliceVal().AppendEmpty().SetStringVal("a")
+				tags.SliceVal().AppendEmpty().SetStringVal("b")
+				expected.Insert("tags", tags)
+				return expected
+			},
+		},
+		{
+			name:  "not an object",
+			value: `["a", "b"]`,
+			want: func() interface{} {
+				return nil
+			},
+		},
+		{
+			name:  "invalid json",
+			value: `{"name": `,
+			want: func() interface{} {
+				return nil
+			},
+		},
+		{
+			name:  "not a string",
+			value: int64(1),
+			want: func() interface{} {
+				return nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := ParseJSON(&tql.StandardGetSetter{
+				Getter: func(ctx tql.TransformContext) interface{} {
+					return tt.value
+				},
+			})
+			assert.NoError(t, err)
+
+			actual := exprFunc(tqltest.TestTransformContext{})
+			expected := tt.want()
+			if expected == nil {
+				assert.Nil(t, actual)
+				return
+			}
+			actualMap, ok := actual.(pcommon.Map)
+			assert.True(t, ok)
+			assert.Equal(t, expected.(pcommon.Map).AsRaw(), actualMap.AsRaw())
+		})
+	}
+}
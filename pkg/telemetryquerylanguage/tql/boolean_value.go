@@ -16,6 +16,10 @@ package tql // import "github.com/open-telemetry/opentelemetry-collector-contrib
 
 import (
 	"fmt"
+	"reflect"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 // BoolExpressionEvaluator is a function that returns the result.
@@ -55,6 +59,85 @@ func orFuncs(funcs []BoolExpressionEvaluator) BoolExpressionEvaluator {
 	}
 }
 
+func notFunc(f BoolExpressionEvaluator) BoolExpressionEvaluator {
+	return func(ctx TransformContext) bool {
+		return !f(ctx)
+	}
+}
+
+// contains reports whether list, which is either a []interface{} or a pcommon.Slice, contains val.
+func contains(list interface{}, val interface{}) bool {
+	var items []interface{}
+	switch l := list.(type) {
+	case []interface{}:
+		items = l
+	case pcommon.Slice:
+		items = l.AsRaw()
+	default:
+		return false
+	}
+	for _, item := range items {
+		if reflect.DeepEqual(item, val) {
+			return true
+		}
+	}
+	return false
+}
+
+// compareOrdered compares two numbers, or two strings, returning -1, 0 or 1 when a is respectively lower than,
+// equal to, or greater than b. Integers and floats can be compared to each other. The boolean result is false
+// when the values can't be ordered, in which case the comparison doesn't match.
+func compareOrdered(a interface{}, b interface{}) (int, bool) {
+	switch av := a.(type) {
+	case int64:
+		switch bv := b.(type) {
+		case int64:
+			return compareInts(av, bv), true
+		case float64:
+			return compareFloats(float64(av), bv), true
+		}
+	case float64:
+		switch bv := b.(type) {
+		case int64:
+			return compareFloats(av, float64(bv)), true
+		case float64:
+			return compareFloats(av, bv), true
+		}
+	case string:
+		if bv, ok := b.(string); ok {
+			return strings.Compare(av, bv), true
+		}
+	}
+	return 0, false
+}
+
+func compareInts(a int64, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareFloats(a float64, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// isListValue reports whether the value may evaluate to a list. Literals other than lists never do.
+func isListValue(val Value) bool {
+	return val.List != nil || val.Path != nil || val.Invocation != nil
+}
+
 func newComparisonEvaluator(comparison *Comparison, functions map[string]interface{}, pathParser PathExpressionParser, enumParser EnumParser) (BoolExpressionEvaluator, error) {
 	if comparison == nil {
 		return alwaysTrue, nil
@@ -70,6 +153,13 @@ func newComparisonEvaluator(comparison *Comparison, functions map[string]interfa
 	}
 
 	switch comparison.Op {
+	case "in":
+		if !isListValue(comparison.Right) {
+			return nil, fmt.Errorf("the right side of 'in' must be a list, a path or an invocation")
+		}
+		return func(ctx TransformContext) bool {
+			return contains(right.Get(ctx), left.Get(ctx))
+		}, nil
 	case "==":
 		return func(ctx TransformContext) bool {
 			a := left.Get(ctx)
@@ -82,6 +172,24 @@ func newComparisonEvaluator(comparison *Comparison, functions map[string]interfa
 			b := right.Get(ctx)
 			return a != b
 		}, nil
+	case ">", ">=", "<", "<=":
+		op := comparison.Op
+		return func(ctx TransformContext) bool {
+			result, ok := compareOrdered(left.Get(ctx), right.Get(ctx))
+			if !ok {
+				return false
+			}
+			switch op {
+			case ">":
+				return result > 0
+			case ">=":
+				return result >= 0
+			case "<":
+				return result < 0
+			default:
+				return result <= 0
+			}
+		}, nil
 	}
 
 	return nil, fmt.Errorf("unrecognized boolean operation %v", comparison.Op)
@@ -131,6 +239,17 @@ func newBooleanValueEvaluator(value *BooleanValue, functions map[string]interfac
 	if value == nil {
 		return alwaysTrue, nil
 	}
+	f, err := newUnnegatedBooleanValueEvaluator(value, functions, pathParser, enumParser)
+	if err != nil {
+		return nil, err
+	}
+	if value.Negation != nil {
+		return notFunc(f), nil
+	}
+	return f, nil
+}
+
+func newUnnegatedBooleanValueEvaluator(value *BooleanValue, functions map[string]interface{}, pathParser PathExpressionParser, enumParser EnumParser) (BoolExpressionEvaluator, error) {
 	switch {
 	case value.Comparison != nil:
 		comparison, err := newComparisonEvaluator(value.Comparison, functions, pathParser, enumParser)
@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
 )
@@ -119,6 +120,79 @@ func Test_newComparisonEvaluator(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "path expression in list",
+			comparison: &Comparison{
+				Left: Value{
+					Path: &Path{
+						Fields: []Field{
+							{
+								Name: "name",
+							},
+						},
+					},
+				},
+				Op: "in",
+				Right: Value{
+					List: &List{
+						Values: []Value{
+							{
+								String: tqltest.Strp("cat"),
+							},
+							{
+								String: tqltest.Strp("bear"),
+							},
+						},
+					},
+				},
+			},
+			item: "bear",
+		},
+		{
+			name: "int in list",
+			comparison: &Comparison{
+				Left: Value{
+					Int: tqltest.Intp(2),
+				},
+				Op: "in",
+				Right: Value{
+					List: &List{
+						Values: []Value{
+							{
+								Int: tqltest.Intp(1),
+							},
+							{
+								Int: tqltest.Intp(2),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "literal in slice",
+			comparison: &Comparison{
+				Left: Value{
+					String: tqltest.Strp("bear"),
+				},
+				Op: "in",
+				Right: Value{
+					Path: &Path{
+						Fields: []Field{
+							{
+								Name: "name",
+							},
+						},
+					},
+				},
+			},
+			item: func() pcommon.Slice {
+				s := pcommon.NewSlice()
+				s.AppendEmpty().SetStringVal("cat")
+				s.AppendEmpty().SetStringVal("bear")
+				return s
+			}(),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -131,6 +205,37 @@ func Test_newComparisonEvaluator(t *testing.T) {
 	}
 }
 
+func Test_newComparisonEvaluator_ordering(t *testing.T) {
+	tests := []struct {
+		name     string
+		left     Value
+		op       string
+		right    Value
+		item     interface{}
+		expected bool
+	}{
+		{name: "int greater than int", left: Value{Int: tqltest.Intp(500)}, op: ">", right: Value{Int: tqltest.Intp(499)}, expected: true},
+		{name: "int not greater than equal int", left: Value{Int: tqltest.Intp(500)}, op: ">", right: Value{Int: tqltest.Intp(500)}, expected: false},
+		{name: "int greater or equal int", left: Value{Int: tqltest.Intp(500)}, op: ">=", right: Value{Int: tqltest.Intp(500)}, expected: true},
+		{name: "int lower than float", left: Value{Int: tqltest.Intp(1)}, op: "<", right: Value{Float: tqltest.Floatp(1.5)}, expected: true},
+		{name: "float lower or equal int", left: Value{Float: tqltest.Floatp(2.0)}, op: "<=", right: Value{Int: tqltest.Intp(2)}, expected: true},
+		{name: "strings", left: Value{String: tqltest.Strp("a")}, op: "<", right: Value{String: tqltest.Strp("b")}, expected: true},
+		{name: "path greater than int", left: Value{Path: &Path{Fields: []Field{{Name: "name"}}}}, op: ">=", right: Value{Int: tqltest.Intp(500)}, item: int64(503), expected: true},
+		{name: "string and int can't be ordered", left: Value{String: tqltest.Strp("a")}, op: "<", right: Value{Int: tqltest.Intp(1)}, expected: false},
+		{name: "nil can't be ordered", left: Value{Path: &Path{Fields: []Field{{Name: "name"}}}}, op: "<", right: Value{Int: tqltest.Intp(1)}, expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comparison := &Comparison{Left: tt.left, Op: tt.op, Right: tt.right}
+			evaluate, err := newComparisonEvaluator(comparison, DefaultFunctionsForTests(), testParsePath, testParseEnum)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, evaluate(tqltest.TestTransformContext{
+				Item: tt.item,
+			}))
+		})
+	}
+}
+
 func Test_newConditionEvaluator_invalid(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -148,6 +253,18 @@ func Test_newConditionEvaluator_invalid(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "in with a string literal",
+			comparison: &Comparison{
+				Left: Value{
+					String: tqltest.Strp("bear"),
+				},
+				Op: "in",
+				Right: Value{
+					String: tqltest.Strp("bear"),
+				},
+			},
+		},
 		{
 			name: "unknown Path",
 			comparison: &Comparison{
@@ -349,6 +466,40 @@ func Test_newBooleanExpressionEvaluator(t *testing.T) {
 				},
 			},
 		},
+		{"i", false,
+			&BooleanExpression{
+				Left: &Term{
+					Left: &BooleanValue{
+						Negation:  tqltest.Strp("not"),
+						ConstExpr: Booleanp(true),
+					},
+				},
+			},
+		},
+		{"j", true,
+			&BooleanExpression{
+				Left: &Term{
+					Left: &BooleanValue{
+						Negation: tqltest.Strp("not"),
+						SubExpr: &BooleanExpression{
+							Left: &Term{
+								Left: &BooleanValue{
+									ConstExpr: Booleanp(true),
+								},
+								Right: []*OpAndBooleanValue{
+									{
+										Operator: "and",
+										Value: &BooleanValue{
+											ConstExpr: Booleanp(false),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -72,6 +72,19 @@ func (g exprGetter) Get(ctx TransformContext) interface{} {
 	return g.expr(ctx)
 }
 
+// listGetter evaluates each of the values of a list literal and returns them as a []interface{}.
+type listGetter struct {
+	values []Getter
+}
+
+func (l *listGetter) Get(ctx TransformContext) interface{} {
+	evaluated := make([]interface{}, len(l.values))
+	for i, v := range l.values {
+		evaluated[i] = v.Get(ctx)
+	}
+	return evaluated
+}
+
 func NewGetter(val Value, functions map[string]interface{}, pathParser PathExpressionParser, enumParser EnumParser) (Getter, error) {
 	if val.IsNil != nil && *val.IsNil {
 		return &Literal{Value: nil}, nil
@@ -93,6 +106,18 @@ func NewGetter(val Value, functions map[string]interface{}, pathParser PathExpre
 		return &Literal{Value: ([]byte)(*b)}, nil
 	}
 
+	if val.List != nil {
+		values := make([]Getter, len(val.List.Values))
+		for i, v := range val.List.Values {
+			getter, err := NewGetter(v, functions, pathParser, enumParser)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = getter
+		}
+		return &listGetter{values: values}, nil
+	}
+
 	if val.Enum != nil {
 		enum, err := enumParser(val.Enum)
 		if err != nil {
@@ -105,6 +130,10 @@ func NewGetter(val Value, functions map[string]interface{}, pathParser PathExpre
 		return pathParser(val.Path)
 	}
 
+	if val.MathExpression != nil {
+		return newMathGetter(val.MathExpression, functions, pathParser, enumParser)
+	}
+
 	if val.Invocation == nil {
 		// In practice, can't happen since the DSL grammar guarantees one is set
 		return nil, fmt.Errorf("no value field set. This is a bug in the Telemetry Query Language")
@@ -105,6 +105,27 @@ func Test_newGetter(t *testing.T) {
 			},
 			want: int64(1),
 		},
+		{
+			name: "list",
+			val: Value{
+				List: &List{
+					Values: []Value{
+						{
+							String: tqltest.Strp("str"),
+						},
+						{
+							Int: tqltest.Intp(12),
+						},
+						{
+							Invocation: &Invocation{
+								Function: "hello",
+							},
+						},
+					},
+				},
+			},
+			want: []interface{}{"str", int64(12), "world"},
+		},
 	}
 
 	functions := map[string]interface{}{"hello": hello}
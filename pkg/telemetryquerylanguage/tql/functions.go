@@ -69,34 +69,47 @@ func buildArgs(inv Invocation, fType reflect.Type, functions map[string]interfac
 	return args, nil
 }
 
+// sliceArgValues returns the Values a slice parameter starting at startingIndex is built from: either the
+// elements of a list literal passed as the last argument, or all the remaining arguments.
+func sliceArgValues(inv Invocation, startingIndex int) []Value {
+	if startingIndex == len(inv.Arguments)-1 && inv.Arguments[startingIndex].List != nil {
+		return inv.Arguments[startingIndex].List.Values
+	}
+	if startingIndex >= len(inv.Arguments) {
+		return nil
+	}
+	return inv.Arguments[startingIndex:]
+}
+
 func buildSliceArg(inv Invocation, argType reflect.Type, startingIndex int, args *[]reflect.Value,
 	functions map[string]interface{}, pathParser PathExpressionParser, enumParser EnumParser) error {
+	values := sliceArgValues(inv, startingIndex)
 	switch argType.Elem().Name() {
 	case reflect.String.String():
 		arg := make([]string, 0)
-		for j := startingIndex; j < len(inv.Arguments); j++ {
-			if inv.Arguments[j].String == nil {
-				return fmt.Errorf("invalid argument for slice parameter at position %v, must be a string", j)
+		for j, v := range values {
+			if v.String == nil {
+				return fmt.Errorf("invalid argument for slice parameter at position %v, must be a string", startingIndex+j)
 			}
-			arg = append(arg, *inv.Arguments[j].String)
+			arg = append(arg, *v.String)
 		}
 		*args = append(*args, reflect.ValueOf(arg))
 	case reflect.Float64.String():
 		arg := make([]float64, 0)
-		for j := startingIndex; j < len(inv.Arguments); j++ {
-			if inv.Arguments[j].Float == nil {
-				return fmt.Errorf("invalid argument for slice parameter at position %v, must be a float", j)
+		for j, v := range values {
+			if v.Float == nil {
+				return fmt.Errorf("invalid argument for slice parameter at position %v, must be a float", startingIndex+j)
 			}
-			arg = append(arg, *inv.Arguments[j].Float)
+			arg = append(arg, *v.Float)
 		}
 		*args = append(*args, reflect.ValueOf(arg))
 	case reflect.Int64.String():
 		arg := make([]int64, 0)
-		for j := startingIndex; j < len(inv.Arguments); j++ {
-			if inv.Arguments[j].Int == nil {
-				return fmt.Errorf("invalid argument for slice parameter at position %v, must be an int", j)
+		for j, v := range values {
+			if v.Int == nil {
+				return fmt.Errorf("invalid argument for slice parameter at position %v, must be an int", startingIndex+j)
 			}
-			arg = append(arg, *inv.Arguments[j].Int)
+			arg = append(arg, *v.Int)
 		}
 		*args = append(*args, reflect.ValueOf(arg))
 	case reflect.Uint8.String():
@@ -106,8 +119,8 @@ func buildSliceArg(inv Invocation, argType reflect.Type, startingIndex int, args
 		*args = append(*args, reflect.ValueOf(([]byte)(*inv.Arguments[startingIndex].Bytes)))
 	case "Getter":
 		arg := make([]Getter, 0)
-		for j := startingIndex; j < len(inv.Arguments); j++ {
-			val, err := NewGetter(inv.Arguments[j], functions, pathParser, enumParser)
+		for _, v := range values {
+			val, err := NewGetter(v, functions, pathParser, enumParser)
 			if err != nil {
 				return err
 			}
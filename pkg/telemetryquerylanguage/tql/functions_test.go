@@ -116,6 +116,26 @@ func Test_NewFunctionCall_invalid(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "not matching list element type",
+			inv: Invocation{
+				Function: "testing_string_slice",
+				Arguments: []Value{
+					{
+						List: &List{
+							Values: []Value{
+								{
+									String: tqltest.Strp("test"),
+								},
+								{
+									Int: tqltest.Intp(10),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "function call returns error",
 			inv: Invocation{
@@ -166,6 +186,37 @@ func Test_NewFunctionCall(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "string slice arg as list",
+			inv: Invocation{
+				Function: "testing_string_slice",
+				Arguments: []Value{
+					{
+						List: &List{
+							Values: []Value{
+								{
+									String: tqltest.Strp("test"),
+								},
+								{
+									String: tqltest.Strp("test"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "empty list for slice arg",
+			inv: Invocation{
+				Function: "testing_int_slice",
+				Arguments: []Value{
+					{
+						List: &List{},
+					},
+				},
+			},
+		},
 		{
 			name: "float slice arg",
 			inv: Invocation{
@@ -397,6 +448,44 @@ func Test_NewFunctionCall(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "multiple args with list",
+			inv: Invocation{
+				Function: "testing_multiple_args",
+				Arguments: []Value{
+					{
+						Path: &Path{
+							Fields: []Field{
+								{
+									Name: "name",
+								},
+							},
+						},
+					},
+					{
+						String: tqltest.Strp("test"),
+					},
+					{
+						Float: tqltest.Floatp(1.1),
+					},
+					{
+						Int: tqltest.Intp(1),
+					},
+					{
+						List: &List{
+							Values: []Value{
+								{
+									String: tqltest.Strp("test"),
+								},
+								{
+									String: tqltest.Strp("test"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "Enum arg",
 			inv: Invocation{
@@ -57,6 +57,16 @@ func Test_lexer(t *testing.T) {
 			{"OpComparison", "!="},
 			{"Float", "4.9"},
 		}},
+		{"basic_ordering", "3>=4.9", false, []result{
+			{"Int", "3"},
+			{"OpComparison", ">="},
+			{"Float", "4.9"},
+		}},
+		{"strict_ordering", "3<4", false, []result{
+			{"Int", "3"},
+			{"OpComparison", "<"},
+			{"Int", "4"},
+		}},
 		{"unambiguous_names", "foo bar BAZZ", false, []result{
 			{"Lowercase", "foo"},
 			{"Lowercase", "bar"},
@@ -83,6 +93,20 @@ func Test_lexer(t *testing.T) {
 			{"OpOr", "or"},
 			{"Lowercase", "but"},
 		}},
+		{"parse_not_in", "not name in [1, 2]", false, []result{
+			{"OpNot", "not"},
+			{"Lowercase", "name"},
+			{"OpIn", "in"},
+			{"Punct", "["},
+			{"Int", "1"},
+			{"Punct", ","},
+			{"Int", "2"},
+			{"Punct", "]"},
+		}},
+		{"name_containing_not_and_in", "nothing inside", false, []result{
+			{"Lowercase", "nothing"},
+			{"Lowercase", "inside"}, // should not parse "not" or "in" as an operator
+		}},
 		{"nothing_recognizable", "{}", true, []result{
 			{"", ""},
 		}},
@@ -97,6 +121,41 @@ func Test_lexer(t *testing.T) {
 			{"Bytes", "0x0102030405060708"},
 			{"RParen", ")"},
 		}},
+		{"math_operators", `(a + 1) * b - 2.5 / c`, false, []result{
+			{"LParen", "("},
+			{"Lowercase", "a"},
+			{"OpAddSub", "+"},
+			{"Int", "1"},
+			{"RParen", ")"},
+			{"OpMultDiv", "*"},
+			{"Lowercase", "b"},
+			{"OpAddSub", "-"},
+			{"Float", "2.5"},
+			{"OpMultDiv", "/"},
+			{"Lowercase", "c"},
+		}},
+		{"signed_literal_after_operator", `a - -1`, false, []result{
+			{"Lowercase", "a"},
+			{"OpAddSub", "-"},
+			{"OpAddSub", "-"},
+			{"Int", "1"},
+		}},
+		{"unspaced_subtraction_from_path", `a-1`, false, []result{
+			{"Lowercase", "a"},
+			{"OpAddSub", "-"},
+			{"Int", "1"},
+		}},
+		{"unspaced_signed_operand", `2*-1`, false, []result{
+			{"Int", "2"},
+			{"OpMultDiv", "*"},
+			{"OpAddSub", "-"},
+			{"Int", "1"},
+		}},
+		{"unspaced_subtraction_of_literals", `1-2.5`, false, []result{
+			{"Int", "1"},
+			{"OpAddSub", "-"},
+			{"Float", "2.5"},
+		}},
 		{"Mixing case", `aBCd`, false, []result{
 			{"Lowercase", "a"},
 			{"Uppercase", "BC"},
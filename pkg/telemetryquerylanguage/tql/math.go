@@ -0,0 +1,184 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tql // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+
+import (
+	"fmt"
+)
+
+// mathType is the type of a math operand as far as it is known when the statement is parsed.
+// The type of paths and invocations is only known when the statement is executed.
+type mathType int
+
+const (
+	mathTypeUnknown mathType = iota
+	mathTypeInt
+	mathTypeFloat
+)
+
+func (t mathType) String() string {
+	switch t {
+	case mathTypeInt:
+		return "int"
+	case mathTypeFloat:
+		return "float"
+	}
+	return "unknown"
+}
+
+type mathGetter struct {
+	op    MathOp
+	left  Getter
+	right Getter
+}
+
+// Get evaluates the operation. Both operands must be either int64 or float64; if they are not, or if an
+// int64 is divided by zero, nil is returned.
+func (g mathGetter) Get(ctx TransformContext) interface{} {
+	left := g.left.Get(ctx)
+	right := g.right.Get(ctx)
+	switch l := left.(type) {
+	case int64:
+		if r, ok := right.(int64); ok {
+			return performIntOp(g.op, l, r)
+		}
+	case float64:
+		if r, ok := right.(float64); ok {
+			return performFloatOp(g.op, l, r)
+		}
+	}
+	return nil
+}
+
+func performIntOp(op MathOp, left int64, right int64) interface{} {
+	switch op {
+	case Add:
+		return left + right
+	case Sub:
+		return left - right
+	case Mult:
+		return left * right
+	case Div:
+		if right == 0 {
+			return nil
+		}
+		return left / right
+	}
+	return nil
+}
+
+func performFloatOp(op MathOp, left float64, right float64) interface{} {
+	switch op {
+	case Add:
+		return left + right
+	case Sub:
+		return left - right
+	case Mult:
+		return left * right
+	case Div:
+		return left / right
+	}
+	return nil
+}
+
+func newMathGetter(expr *MathExpression, functions map[string]interface{}, pathParser PathExpressionParser, enumParser EnumParser) (Getter, error) {
+	getter, _, err := buildMathExpression(expr, functions, pathParser, enumParser)
+	return getter, err
+}
+
+func buildMathExpression(expr *MathExpression, functions map[string]interface{}, pathParser PathExpressionParser, enumParser EnumParser) (Getter, mathType, error) {
+	left, leftType, err := buildAddSubTerm(expr.Left, functions, pathParser, enumParser)
+	if err != nil {
+		return nil, mathTypeUnknown, err
+	}
+	for _, rhs := range expr.Right {
+		right, rightType, err := buildAddSubTerm(rhs.Term, functions, pathParser, enumParser)
+		if err != nil {
+			return nil, mathTypeUnknown, err
+		}
+		leftType, err = resultType(rhs.Operator, leftType, rightType)
+		if err != nil {
+			return nil, mathTypeUnknown, err
+		}
+		left = &mathGetter{op: rhs.Operator, left: left, right: right}
+	}
+	return left, leftType, nil
+}
+
+func buildAddSubTerm(term *AddSubTerm, functions map[string]interface{}, pathParser PathExpressionParser, enumParser EnumParser) (Getter, mathType, error) {
+	left, leftType, err := buildMathValue(term.Left, functions, pathParser, enumParser)
+	if err != nil {
+		return nil, mathTypeUnknown, err
+	}
+	for _, rhs := range term.Right {
+		if rhs.Operator == Div && isZeroLiteral(rhs.Value) {
+			return nil, mathTypeUnknown, fmt.Errorf("division by zero")
+		}
+		right, rightType, err := buildMathValue(rhs.Value, functions, pathParser, enumParser)
+		if err != nil {
+			return nil, mathTypeUnknown, err
+		}
+		leftType, err = resultType(rhs.Operator, leftType, rightType)
+		if err != nil {
+			return nil, mathTypeUnknown, err
+		}
+		left = &mathGetter{op: rhs.Operator, left: left, right: right}
+	}
+	return left, leftType, nil
+}
+
+func buildMathValue(value *MathValue, functions map[string]interface{}, pathParser PathExpressionParser, enumParser EnumParser) (Getter, mathType, error) {
+	if value.SubExpression != nil {
+		return buildMathExpression(value.SubExpression, functions, pathParser, enumParser)
+	}
+	literal := value.Literal
+	switch {
+	case literal.Int != nil:
+		return &Literal{Value: *literal.Int}, mathTypeInt, nil
+	case literal.Float != nil:
+		return &Literal{Value: *literal.Float}, mathTypeFloat, nil
+	case literal.Path != nil:
+		getter, err := pathParser(literal.Path)
+		return getter, mathTypeUnknown, err
+	case literal.Invocation != nil:
+		call, err := NewFunctionCall(*literal.Invocation, functions, pathParser, enumParser)
+		if err != nil {
+			return nil, mathTypeUnknown, err
+		}
+		return &exprGetter{expr: call}, mathTypeUnknown, nil
+	}
+	// In practice, can't happen since the DSL grammar guarantees one is set
+	return nil, mathTypeUnknown, fmt.Errorf("no math value field set. This is a bug in the Telemetry Query Language")
+}
+
+// resultType checks that the operands of op can be combined and returns the type of the result. Operands
+// whose type is only known at execution time are accepted here and checked when the statement is executed.
+func resultType(op MathOp, left mathType, right mathType) (mathType, error) {
+	switch {
+	case left == mathTypeUnknown:
+		return right, nil
+	case right == mathTypeUnknown, left == right:
+		return left, nil
+	}
+	return mathTypeUnknown, fmt.Errorf("invalid operands for operator %v: %v and %v cannot be combined", op, left, right)
+}
+
+func isZeroLiteral(value *MathValue) bool {
+	if value.Literal == nil {
+		return false
+	}
+	return (value.Literal.Int != nil && *value.Literal.Int == 0) ||
+		(value.Literal.Float != nil && *value.Literal.Float == 0)
+}
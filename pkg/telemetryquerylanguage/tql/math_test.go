@@ -0,0 +1,188 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func three() (ExprFunc, error) {
+	return func(ctx TransformContext) interface{} {
+		return int64(3)
+	}, nil
+}
+
+func Test_evaluateMathExpression(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		item     interface{}
+		expected interface{}
+	}{
+		{
+			name:     "simple addition",
+			input:    "1 + 2",
+			expected: int64(3),
+		},
+		{
+			name:     "simple subtraction",
+			input:    "10 - 4",
+			expected: int64(6),
+		},
+		{
+			name:     "simple multiplication",
+			input:    "3 * 4",
+			expected: int64(12),
+		},
+		{
+			name:     "integer division",
+			input:    "7 / 2",
+			expected: int64(3),
+		},
+		{
+			name:     "float division",
+			input:    "7.0 / 2.0",
+			expected: 3.5,
+		},
+		{
+			name:     "multiplication before addition",
+			input:    "1 + 2 * 3",
+			expected: int64(7),
+		},
+		{
+			name:     "left to right",
+			input:    "10 - 4 - 3",
+			expected: int64(3),
+		},
+		{
+			name:     "parentheses",
+			input:    "(1 + 2) * 3",
+			expected: int64(9),
+		},
+		{
+			name:     "nested parentheses",
+			input:    "((1 + 2) * (3 - 1)) / 2",
+			expected: int64(3),
+		},
+		{
+			name:     "negative literal",
+			input:    "1 - -2",
+			expected: int64(3),
+		},
+		{
+			name:     "path",
+			input:    "(name - 500000000) / 1000000",
+			item:     int64(1500000000),
+			expected: int64(1000),
+		},
+		{
+			name:     "invocation",
+			input:    "three() * 2",
+			expected: int64(6),
+		},
+		{
+			name:     "uppercase invocation",
+			input:    "Three() + 1",
+			expected: int64(4),
+		},
+		{
+			name:     "mismatched types at runtime",
+			input:    "name + 1",
+			item:     1.5,
+			expected: nil,
+		},
+		{
+			name:     "non numeric value at runtime",
+			input:    "name * 2",
+			item:     "bear",
+			expected: nil,
+		},
+		{
+			name:     "integer division by zero at runtime",
+			input:    "10 / name",
+			item:     int64(0),
+			expected: nil,
+		},
+	}
+
+	functions := map[string]interface{}{"three": three, "Three": three}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := parseQuery("set(name, " + tt.input + ")")
+			require.NoError(t, err)
+			require.NotNil(t, parsed.Invocation.Arguments[1].MathExpression)
+
+			getter, err := NewGetter(parsed.Invocation.Arguments[1], functions, testParsePath, testParseEnum)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, getter.Get(tqltest.TestTransformContext{Item: tt.item}))
+		})
+	}
+}
+
+func Test_evaluateMathExpression_error(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "int and float",
+			input: "1 + 2.5",
+			err:   "invalid operands for operator +: int and float cannot be combined",
+		},
+		{
+			name:  "float and int in subexpression",
+			input: "name * (1.5 - 1)",
+			err:   "invalid operands for operator -: float and int cannot be combined",
+		},
+		{
+			name:  "int and float result",
+			input: "(1 + name) / 2.0",
+			err:   "invalid operands for operator /: int and float cannot be combined",
+		},
+		{
+			name:  "division by zero",
+			input: "name / 0",
+			err:   "division by zero",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseQueries([]string{"testing_getter(" + tt.input + ")"}, DefaultFunctionsForTests(), testParsePath, testParseEnum)
+			assert.EqualError(t, err, "invalid argument at position 0 "+tt.err)
+		})
+	}
+}
+
+func Test_mathExpressionInWhereClause(t *testing.T) {
+	queries, err := ParseQueries(
+		[]string{`testing_getter(name) where name * 2 == 10`},
+		DefaultFunctionsForTests(),
+		testParsePath,
+		testParseEnum,
+	)
+	require.NoError(t, err)
+	require.Len(t, queries, 1)
+
+	assert.True(t, queries[0].Condition(tqltest.TestTransformContext{Item: int64(5)}))
+	assert.False(t, queries[0].Condition(tqltest.TestTransformContext{Item: int64(4)}))
+}
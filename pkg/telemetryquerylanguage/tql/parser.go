@@ -16,6 +16,7 @@ package tql // import "github.com/open-telemetry/opentelemetry-collector-contrib
 
 import (
 	"encoding/hex"
+	"fmt"
 
 	"github.com/alecthomas/participle/v2"
 	"github.com/alecthomas/participle/v2/lexer"
@@ -31,9 +32,10 @@ type ParsedQuery struct {
 
 // BooleanValue represents something that evaluates to a boolean --
 // either an equality or inequality, explicit true or false, or
-// a parenthesized subexpression.
+// a parenthesized subexpression, optionally negated with `not`.
 // nolint:govet
 type BooleanValue struct {
+	Negation   *string            `@OpNot?`
 	Comparison *Comparison        `( @@`
 	ConstExpr  *Boolean           `| @Boolean`
 	SubExpr    *BooleanExpression `| "(" @@ ")" )`
@@ -72,7 +74,7 @@ type BooleanExpression struct {
 // nolint:govet
 type Comparison struct {
 	Left  Value  `@@`
-	Op    string `@OpComparison`
+	Op    string `@(OpComparison | OpIn)`
 	Right Value  `@@`
 }
 
@@ -84,20 +86,79 @@ type Invocation struct {
 }
 
 // Value represents a part of a parsed query which is resolved to a value of some sort. This can be a telemetry path
-// expression, function call, or literal.
+// expression, function call, literal, or math expression.
+// Invocations, numbers and paths are only captured directly when they are not followed by a math operator,
+// otherwise the Value is a MathExpression. Enums and paths must not capture the beginning of an invocation
+// that is followed by a math operator.
+// The lexer never includes the sign in numbers, so that `1-2` is a subtraction: a leading sign is parsed as
+// a unary operator of the number.
 // nolint:govet
 type Value struct {
+	Invocation     *Invocation     `( @@ (?! OpAddSub | OpMultDiv)`
+	Bytes          *Bytes          `| @Bytes`
+	String         *string         `| @String`
+	Float          *float64        `| @(OpAddSub? Float) (?! OpAddSub | OpMultDiv)`
+	Int            *int64          `| @(OpAddSub? Int) (?! OpAddSub | OpMultDiv)`
+	Bool           *Boolean        `| @Boolean`
+	IsNil          *IsNil          `| @"nil"`
+	List           *List           `| @@`
+	Enum           *EnumSymbol     `| @Uppercase (?! Uppercase | Lowercase | LParen)`
+	Path           *Path           `| @@ (?! OpAddSub | OpMultDiv | LParen)`
+	MathExpression *MathExpression `| @@ )`
+}
+
+// List represents a list of Values surrounded by square brackets.
+// nolint:govet
+type List struct {
+	Values []Value `"[" ( @@ ( "," @@ )* )? "]"`
+}
+
+// MathExprLiteral represents a value that can be an operand of a math expression.
+// Numbers may have a leading sign, as in `2 * -1`.
+// nolint:govet
+type MathExprLiteral struct {
 	Invocation *Invocation `( @@`
-	Bytes      *Bytes      `| @Bytes`
-	String     *string     `| @String`
-	Float      *float64    `| @Float`
-	Int        *int64      `| @Int`
-	Bool       *Boolean    `| @Boolean`
-	IsNil      *IsNil      `| @"nil"`
-	Enum       *EnumSymbol `| @Uppercase`
+	Float      *float64    `| @(OpAddSub? Float)`
+	Int        *int64      `| @(OpAddSub? Int)`
 	Path       *Path       `| @@ )`
 }
 
+// MathValue represents an operand of a math expression: either a literal or a parenthesized subexpression.
+// nolint:govet
+type MathValue struct {
+	Literal       *MathExprLiteral `( @@`
+	SubExpression *MathExpression  `| "(" @@ ")" )`
+}
+
+// OpMultDivValue represents the right side of a multiplication or division.
+// nolint:govet
+type OpMultDivValue struct {
+	Operator MathOp     `@OpMultDiv`
+	Value    *MathValue `@@`
+}
+
+// AddSubTerm represents an arbitrary number of math values joined by multiplication or division.
+// nolint:govet
+type AddSubTerm struct {
+	Left  *MathValue        `@@`
+	Right []*OpMultDivValue `@@*`
+}
+
+// OpAddSubTerm represents the right side of an addition or subtraction.
+// nolint:govet
+type OpAddSubTerm struct {
+	Operator MathOp      `@OpAddSub`
+	Term     *AddSubTerm `@@`
+}
+
+// MathExpression represents an arbitrary number of terms joined by addition or subtraction.
+// Multiplication and division have higher precedence than addition and subtraction.
+// nolint:govet
+type MathExpression struct {
+	Left  *AddSubTerm     `@@`
+	Right []*OpAddSubTerm `@@*`
+}
+
 // Path represents a telemetry path expression.
 // nolint:govet
 type Path struct {
@@ -149,6 +210,42 @@ func (n *IsNil) Capture(_ []string) error {
 
 type EnumSymbol string
 
+// MathOp is an arithmetic operator.
+type MathOp int
+
+const (
+	Add MathOp = iota
+	Sub
+	Mult
+	Div
+)
+
+var mathOpTable = map[string]MathOp{
+	"+": Add,
+	"-": Sub,
+	"*": Mult,
+	"/": Div,
+}
+
+func (m *MathOp) Capture(values []string) error {
+	op, ok := mathOpTable[values[0]]
+	if !ok {
+		return fmt.Errorf("'%s' is not a valid operator", values[0])
+	}
+	*m = op
+	return nil
+}
+
+// String returns the symbol of the operator.
+func (m MathOp) String() string {
+	for k, v := range mathOpTable {
+		if v == m {
+			return k
+		}
+	}
+	return "<unknown>"
+}
+
 func ParseQueries(statements []string, functions map[string]interface{}, pathParser PathExpressionParser, enumParser EnumParser) ([]Query, error) {
 	queries := make([]Query, 0)
 	var errors error
@@ -181,7 +278,35 @@ func ParseQueries(statements []string, functions map[string]interface{}, pathPar
 	return queries, nil
 }
 
-var parser = newParser()
+// ParseConditions parses boolean conditions, with the same syntax as the where clause of a query,
+// for components that only need to match telemetry.
+func ParseConditions(conditions []string, functions map[string]interface{}, pathParser PathExpressionParser, enumParser EnumParser) ([]BoolExpressionEvaluator, error) {
+	evaluators := make([]BoolExpressionEvaluator, 0, len(conditions))
+	var errors error
+
+	for _, condition := range conditions {
+		parsed, err := parseCondition(condition)
+		if err != nil {
+			errors = multierr.Append(errors, err)
+			continue
+		}
+		evaluator, err := newBooleanExpressionEvaluator(parsed, functions, pathParser, enumParser)
+		if err != nil {
+			errors = multierr.Append(errors, err)
+			continue
+		}
+		evaluators = append(evaluators, evaluator)
+	}
+
+	if errors != nil {
+		return nil, errors
+	}
+	return evaluators, nil
+}
+
+var parser = newParser[ParsedQuery]()
+
+var conditionParser = newParser[BooleanExpression]()
 
 func parseQuery(raw string) (*ParsedQuery, error) {
 	parsed, err := parser.ParseString("", raw)
@@ -191,18 +316,30 @@ func parseQuery(raw string) (*ParsedQuery, error) {
 	return parsed, nil
 }
 
+func parseCondition(raw string) (*BooleanExpression, error) {
+	parsed, err := conditionParser.ParseString("", raw)
+	if err != nil {
+		return nil, err
+	}
+	return parsed, nil
+}
+
 // buildLexer constructs a SimpleLexer definition.
 // Note that the ordering of these rules matters.
 // It's in a separate function so it can be easily tested alone (see lexer_test.go).
 func buildLexer() *lexer.StatefulDefinition {
 	return lexer.MustSimple([]lexer.SimpleRule{
 		{Name: `Bytes`, Pattern: `0x[a-fA-F0-9]+`},
-		{Name: `Float`, Pattern: `[-+]?\d*\.\d+([eE][-+]?\d+)?`},
-		{Name: `Int`, Pattern: `[-+]?\d+`},
+		{Name: `Float`, Pattern: `\d*\.\d+([eE][-+]?\d+)?`},
+		{Name: `Int`, Pattern: `\d+`},
 		{Name: `String`, Pattern: `"(\\"|[^"])*"`},
 		{Name: `OpOr`, Pattern: `\b(or)\b`},
 		{Name: `OpAnd`, Pattern: `\b(and)\b`},
-		{Name: `OpComparison`, Pattern: `==|!=`},
+		{Name: `OpNot`, Pattern: `\b(not)\b`},
+		{Name: `OpIn`, Pattern: `\b(in)\b`},
+		{Name: `OpComparison`, Pattern: `==|!=|>=|<=|>|<`},
+		{Name: `OpAddSub`, Pattern: `\+|\-`},
+		{Name: `OpMultDiv`, Pattern: `\/|\*`},
 		{Name: `Boolean`, Pattern: `\b(true|false)\b`},
 		{Name: `LParen`, Pattern: `\(`},
 		{Name: `RParen`, Pattern: `\)`},
@@ -213,14 +350,16 @@ func buildLexer() *lexer.StatefulDefinition {
 	})
 }
 
-// newParser returns a parser that can be used to read a string into a ParsedQuery. An error will be returned if the string
-// is not formatted for the DSL.
-func newParser() *participle.Parser[ParsedQuery] {
+// newParser returns a parser that can be used to read a string into a ParsedQuery, or a BooleanExpression for conditions.
+// An error will be returned if the string is not formatted for the DSL.
+func newParser[G any]() *participle.Parser[G] {
 	lex := buildLexer()
-	parser, err := participle.Build[ParsedQuery](
+	parser, err := participle.Build[G](
 		participle.Lexer(lex),
 		participle.Unquote("String"),
 		participle.Elide("whitespace"),
+		// Allows the negative lookahead in Value to fall back to MathExpression after a path or invocation.
+		participle.UseLookahead(participle.MaxLookahead),
 	)
 	if err != nil {
 		panic("Unable to initialize parser; this is a programming error in the transformprocessor:" + err.Error())
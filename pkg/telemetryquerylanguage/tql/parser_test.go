@@ -424,6 +424,298 @@ func Test_parse(t *testing.T) {
 				WhereClause: nil,
 			},
 		},
+		{
+			name:  "invocation with math expression",
+			query: `set(name, (end - start) / 1000)`,
+			expected: &ParsedQuery{
+				Invocation: Invocation{
+					Function: "set",
+					Arguments: []Value{
+						{
+							Path: &Path{
+								Fields: []Field{
+									{
+										Name: "name",
+									},
+								},
+							},
+						},
+						{
+							MathExpression: &MathExpression{
+								Left: &AddSubTerm{
+									Left: &MathValue{
+										SubExpression: &MathExpression{
+											Left: &AddSubTerm{
+												Left: &MathValue{
+													Literal: &MathExprLiteral{
+														Path: &Path{
+															Fields: []Field{
+																{
+																	Name: "end",
+																},
+															},
+														},
+													},
+												},
+											},
+											Right: []*OpAddSubTerm{
+												{
+													Operator: Sub,
+													Term: &AddSubTerm{
+														Left: &MathValue{
+															Literal: &MathExprLiteral{
+																Path: &Path{
+																	Fields: []Field{
+																		{
+																			Name: "start",
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+									Right: []*OpMultDivValue{
+										{
+											Operator: Div,
+											Value: &MathValue{
+												Literal: &MathExprLiteral{
+													Int: tqltest.Intp(1000),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
+		{
+			name:  "invocation with unspaced subtraction from path",
+			query: `set(name, a-1)`,
+			expected: &ParsedQuery{
+				Invocation: Invocation{
+					Function: "set",
+					Arguments: []Value{
+						{
+							Path: &Path{
+								Fields: []Field{
+									{
+										Name: "name",
+									},
+								},
+							},
+						},
+						{
+							MathExpression: &MathExpression{
+								Left: &AddSubTerm{
+									Left: &MathValue{
+										Literal: &MathExprLiteral{
+											Path: &Path{
+												Fields: []Field{
+													{
+														Name: "a",
+													},
+												},
+											},
+										},
+									},
+								},
+								Right: []*OpAddSubTerm{
+									{
+										Operator: Sub,
+										Term: &AddSubTerm{
+											Left: &MathValue{
+												Literal: &MathExprLiteral{
+													Int: tqltest.Intp(1),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
+		{
+			name:  "invocation with unspaced signed operand",
+			query: `set(name, 2*-1)`,
+			expected: &ParsedQuery{
+				Invocation: Invocation{
+					Function: "set",
+					Arguments: []Value{
+						{
+							Path: &Path{
+								Fields: []Field{
+									{
+										Name: "name",
+									},
+								},
+							},
+						},
+						{
+							MathExpression: &MathExpression{
+								Left: &AddSubTerm{
+									Left: &MathValue{
+										Literal: &MathExprLiteral{
+											Int: tqltest.Intp(2),
+										},
+									},
+									Right: []*OpMultDivValue{
+										{
+											Operator: Mult,
+											Value: &MathValue{
+												Literal: &MathExprLiteral{
+													Int: tqltest.Intp(-1),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
+		{
+			name:  "invocation with unspaced subtraction of literals",
+			query: `set(name, 1-2)`,
+			expected: &ParsedQuery{
+				Invocation: Invocation{
+					Function: "set",
+					Arguments: []Value{
+						{
+							Path: &Path{
+								Fields: []Field{
+									{
+										Name: "name",
+									},
+								},
+							},
+						},
+						{
+							MathExpression: &MathExpression{
+								Left: &AddSubTerm{
+									Left: &MathValue{
+										Literal: &MathExprLiteral{
+											Int: tqltest.Intp(1),
+										},
+									},
+								},
+								Right: []*OpAddSubTerm{
+									{
+										Operator: Sub,
+										Term: &AddSubTerm{
+											Left: &MathValue{
+												Literal: &MathExprLiteral{
+													Int: tqltest.Intp(2),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
+		{
+			name:  "invocation with signed literals",
+			query: `set(name, -1, +2.5)`,
+			expected: &ParsedQuery{
+				Invocation: Invocation{
+					Function: "set",
+					Arguments: []Value{
+						{
+							Path: &Path{
+								Fields: []Field{
+									{
+										Name: "name",
+									},
+								},
+							},
+						},
+						{
+							Int: tqltest.Intp(-1),
+						},
+						{
+							Float: tqltest.Floatp(2.5),
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
+		{
+			name:  "invocation with list",
+			query: `keep_keys(attributes, ["foo", "bar"])`,
+			expected: &ParsedQuery{
+				Invocation: Invocation{
+					Function: "keep_keys",
+					Arguments: []Value{
+						{
+							Path: &Path{
+								Fields: []Field{
+									{
+										Name: "attributes",
+									},
+								},
+							},
+						},
+						{
+							List: &List{
+								Values: []Value{
+									{
+										String: tqltest.Strp("foo"),
+									},
+									{
+										String: tqltest.Strp("bar"),
+									},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
+		{
+			name:  "invocation with empty list",
+			query: `keep_keys(attributes, [])`,
+			expected: &ParsedQuery{
+				Invocation: Invocation{
+					Function: "keep_keys",
+					Arguments: []Value{
+						{
+							Path: &Path{
+								Fields: []Field{
+									{
+										Name: "attributes",
+									},
+								},
+							},
+						},
+						{
+							List: &List{},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -457,6 +749,16 @@ func Test_parse_failure(t *testing.T) {
 		`set("foo") where )`,
 		`set("foo") where (name == "fido"))`,
 		`set("foo") where ((name == "fido")`,
+		`set(name, 1 +)`,
+		`set(name, * 2)`,
+		`set(name, "foo" + 1)`,
+		`set(name, 1 + true)`,
+		`set(name, (1 + 2)`,
+		`set(name, [)`,
+		`set(name, ["foo",])`,
+		`set(name, "foo") where name in`,
+		`set(name, "foo") where not`,
+		`set(name, "foo") where name not in ["foo"]`,
 	}
 	for _, tt := range tests {
 		t.Run(tt, func(t *testing.T) {
@@ -772,6 +1074,90 @@ func Test_parseWhere(t *testing.T) {
 				},
 			}),
 		},
+		{
+			query: `not true`,
+			expected: setNameTest(&BooleanExpression{
+				Left: &Term{
+					Left: &BooleanValue{
+						Negation:  tqltest.Strp("not"),
+						ConstExpr: Booleanp(true),
+					},
+				},
+			}),
+		},
+		{
+			query: `not (name == "foo") and true`,
+			expected: setNameTest(&BooleanExpression{
+				Left: &Term{
+					Left: &BooleanValue{
+						Negation: tqltest.Strp("not"),
+						SubExpr: &BooleanExpression{
+							Left: &Term{
+								Left: &BooleanValue{
+									Comparison: &Comparison{
+										Left: Value{
+											Path: &Path{
+												Fields: []Field{
+													{
+														Name: "name",
+													},
+												},
+											},
+										},
+										Op: "==",
+										Right: Value{
+											String: tqltest.Strp("foo"),
+										},
+									},
+								},
+							},
+						},
+					},
+					Right: []*OpAndBooleanValue{
+						{
+							Operator: "and",
+							Value: &BooleanValue{
+								ConstExpr: Booleanp(true),
+							},
+						},
+					},
+				},
+			}),
+		},
+		{
+			query: `not name in ["foo", "bar"]`,
+			expected: setNameTest(&BooleanExpression{
+				Left: &Term{
+					Left: &BooleanValue{
+						Negation: tqltest.Strp("not"),
+						Comparison: &Comparison{
+							Left: Value{
+								Path: &Path{
+									Fields: []Field{
+										{
+											Name: "name",
+										},
+									},
+								},
+							},
+							Op: "in",
+							Right: Value{
+								List: &List{
+									Values: []Value{
+										{
+											String: tqltest.Strp("foo"),
+										},
+										{
+											String: tqltest.Strp("bar"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}),
+		},
 	}
 
 	// create a test name that doesn't confuse vscode so we can rerun tests with one click
@@ -802,3 +1188,37 @@ func testParseEnum(val *EnumSymbol) (*Enum, error) {
 	}
 	return nil, fmt.Errorf("enum symbol not provided")
 }
+
+func Test_ParseConditions(t *testing.T) {
+	conditions, err := ParseConditions(
+		[]string{
+			`name == "bear"`,
+			`name == "bear" and true`,
+			`name != "bear" or false`,
+		},
+		DefaultFunctionsForTests(),
+		testParsePath,
+		testParseEnum,
+	)
+	assert.NoError(t, err)
+	assert.Len(t, conditions, 3)
+
+	ctx := tqltest.TestTransformContext{Item: "bear"}
+	assert.True(t, conditions[0](ctx))
+	assert.True(t, conditions[1](ctx))
+	assert.False(t, conditions[2](ctx))
+}
+
+func Test_ParseConditions_failure(t *testing.T) {
+	tests := []string{
+		`set(name, "bear")`,
+		`name ==`,
+		`unknown == "bear"`,
+	}
+	for _, condition := range tests {
+		t.Run(condition, func(t *testing.T) {
+			_, err := ParseConditions([]string{condition}, DefaultFunctionsForTests(), testParsePath, testParseEnum)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -24,4 +24,7 @@ type Config struct {
 // SignalConfig configures TQL queries to execute.
 type SignalConfig struct {
 	Queries []string `mapstructure:"queries"`
+	// Context selects the TQL context the queries are executed in. The available contexts depend on the signal and
+	// on the component executing the queries. When empty, the default context of the signal is used.
+	Context string `mapstructure:"context"`
 }
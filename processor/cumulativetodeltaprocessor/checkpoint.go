@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cumulativetodeltaprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/storageutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor/internal/tracking"
+)
+
+// statesKey is the storage key under which the tracking state is checkpointed.
+const statesKey = "states"
+
+// startStateCheckpoints restores the tracking state checkpointed in the storage extension, then periodically
+// checkpoints it, so that the previous points of the streams are known again after a restart.
+func startStateCheckpoints(ctx context.Context, host component.Host, processorID config.ComponentID, storageID config.ComponentID,
+	logger *zap.Logger, tracker *tracking.MetricTracker, interval time.Duration) (*storageutils.PeriodicSync, error) {
+	client, err := storageutils.GetClient(ctx, host, &storageID, component.KindProcessor, processorID)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &stateCheckpointer{logger: logger, tracker: tracker}
+	checkpoints := storageutils.NewPeriodicSync(client, logger, interval, c.checkpoint)
+	if err = checkpoints.Start(ctx, c.restore); err != nil {
+		return nil, err
+	}
+	return checkpoints, nil
+}
+
+// stateCheckpointer reads and writes the checkpoints of the tracking state.
+type stateCheckpointer struct {
+	logger  *zap.Logger
+	tracker *tracking.MetricTracker
+}
+
+func (c *stateCheckpointer) restore(ctx context.Context, client storage.Client) error {
+	checkpoint, err := client.Get(ctx, statesKey)
+	if err != nil {
+		return fmt.Errorf("failed to load the tracking state: %w", err)
+	}
+	if err = c.tracker.UnmarshalStates(checkpoint); err != nil {
+		// the streams are tracked from scratch, as they would be without a checkpoint
+		c.logger.Warn("Discarding the checkpointed tracking state", zap.Error(err))
+	}
+	return nil
+}
+
+func (c *stateCheckpointer) checkpoint(ctx context.Context, client storage.Client) error {
+	if err := client.Set(ctx, statesKey, c.tracker.MarshalStates()); err != nil {
+		return fmt.Errorf("failed to checkpoint the tracking state: %w", err)
+	}
+	return nil
+}
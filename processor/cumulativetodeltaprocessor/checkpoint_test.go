@@ -0,0 +1,200 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cumulativetodeltaprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func newCheckpointedProcessor(t *testing.T, next *consumertest.MetricsSink) component.MetricsProcessor {
+	storageID := config.NewComponentIDWithName("test_storage", "test")
+	cfg := createDefaultConfig().(*Config)
+	cfg.Storage = &storageID
+
+	p, err := NewFactory().CreateMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, next)
+	require.NoError(t, err)
+	return p
+}
+
+func TestStateSurvivesRestart(t *testing.T) {
+	dir := t.TempDir()
+	metrics := func(value float64) testSumMetric {
+		return testSumMetric{
+			metricNames:  []string{"metric_1"},
+			metricValues: [][]float64{{value}},
+			isCumulative: []bool{true},
+		}
+	}
+
+	next := new(consumertest.MetricsSink)
+	p := newCheckpointedProcessor(t, next)
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", dir)
+	require.NoError(t, p.Start(context.Background(), host))
+	require.NoError(t, p.ConsumeMetrics(context.Background(), generateTestSumMetrics(metrics(100))))
+	require.NoError(t, p.Shutdown(context.Background()))
+
+	// the previous point is restored, the new point is converted against it
+	next = new(consumertest.MetricsSink)
+	p = newCheckpointedProcessor(t, next)
+	host = storagetest.NewStorageHost().WithFileBackedStorageExtension("test", dir)
+	require.NoError(t, p.Start(context.Background(), host))
+	require.NoError(t, p.ConsumeMetrics(context.Background(), generateTestSumMetrics(metrics(150))))
+	require.NoError(t, p.Shutdown(context.Background()))
+
+	got := next.AllMetrics()
+	require.Len(t, got, 1)
+	dps := got[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+	require.Equal(t, 1, dps.Len())
+	assert.Equal(t, 50.0, dps.At(0).DoubleVal())
+}
+
+func TestStateCheckpointedOnInterval(t *testing.T) {
+	storageID := config.NewComponentIDWithName("test_storage", "test")
+	client := storagetest.NewInMemoryClient(component.KindProcessor, config.NewComponentID(typeStr), "")
+	host := &clientHost{Host: componenttest.NewNopHost(), storageID: storageID, client: client}
+	cfg := createDefaultConfig().(*Config)
+	cfg.Storage = &storageID
+	cfg.CheckpointInterval = time.Millisecond
+
+	p := newCumulativeToDeltaProcessor(cfg, componenttest.NewNopProcessorCreateSettings().Logger)
+	require.NoError(t, p.start(context.Background(), host))
+	_, err := p.processMetrics(context.Background(), generateTestSumMetrics(testSumMetric{
+		metricNames:  []string{"metric_1"},
+		metricValues: [][]float64{{100}},
+		isCumulative: []bool{true},
+	}))
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		checkpoint, err := client.Get(context.Background(), statesKey)
+		return err == nil && len(checkpoint) > 1
+	}, 10*time.Second, time.Millisecond)
+	require.NoError(t, p.shutdown(context.Background()))
+}
+
+func TestInvalidCheckpointIsDiscarded(t *testing.T) {
+	storageID := config.NewComponentIDWithName("test_storage", "test")
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	cfg := createDefaultConfig().(*Config)
+	cfg.Storage = &storageID
+
+	ext := host.GetExtensions()[storageID].(*storagetest.TestStorage)
+	client, err := ext.GetClient(context.Background(), component.KindProcessor, cfg.ID(), "")
+	require.NoError(t, err)
+	require.NoError(t, client.Set(context.Background(), statesKey, []byte{0xff}))
+	require.NoError(t, client.Close(context.Background()))
+
+	p := newCumulativeToDeltaProcessor(cfg, componenttest.NewNopProcessorCreateSettings().Logger)
+	require.NoError(t, p.start(context.Background(), host))
+	require.NoError(t, p.shutdown(context.Background()))
+}
+
+func TestCheckpointStorageErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		host      *storagetest.StorageHost
+		storageID config.ComponentID
+		err       string
+	}{
+		{
+			name:      "missing",
+			host:      storagetest.NewStorageHost(),
+			storageID: config.NewComponentIDWithName("test_storage", "test"),
+			err:       `storage extension "test_storage/test" not found`,
+		},
+		{
+			name:      "not-a-storage",
+			host:      storagetest.NewStorageHost().WithNonStorageExtension("test"),
+			storageID: config.NewComponentIDWithName("non_storage", "test"),
+			err:       `extension "non_storage/test" is not a storage extension`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Storage = &tt.storageID
+
+			p := newCumulativeToDeltaProcessor(cfg, componenttest.NewNopProcessorCreateSettings().Logger)
+			assert.EqualError(t, p.start(context.Background(), tt.host), tt.err)
+			assert.NoError(t, p.shutdown(context.Background()))
+		})
+	}
+}
+
+func TestCheckpointClientClosedOnFailedStart(t *testing.T) {
+	storageID := config.NewComponentIDWithName("test_storage", "test")
+	client := &failingClient{Client: storage.NewNopClient()}
+	host := &clientHost{Host: componenttest.NewNopHost(), storageID: storageID, client: client}
+	cfg := createDefaultConfig().(*Config)
+	cfg.Storage = &storageID
+
+	p := newCumulativeToDeltaProcessor(cfg, componenttest.NewNopProcessorCreateSettings().Logger)
+	assert.ErrorIs(t, p.start(context.Background(), host), errLoadFailed)
+	assert.True(t, client.closed)
+	assert.NoError(t, p.shutdown(context.Background()))
+}
+
+var errLoadFailed = errors.New("load failed")
+
+// failingClient fails to load any key, and records whether it has been closed.
+type failingClient struct {
+	storage.Client
+	closed bool
+}
+
+func (c *failingClient) Get(context.Context, string) ([]byte, error) {
+	return nil, errLoadFailed
+}
+
+func (c *failingClient) Close(context.Context) error {
+	c.closed = true
+	return nil
+}
+
+// clientHost has a single storage extension, handing out the given client.
+type clientHost struct {
+	component.Host
+	storageID config.ComponentID
+	client    storage.Client
+}
+
+func (h *clientHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return map[config.ComponentID]component.Extension{h.storageID: h}
+}
+
+func (h *clientHost) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (h *clientHost) Shutdown(context.Context) error {
+	return nil
+}
+
+func (h *clientHost) GetClient(context.Context, component.Kind, config.ComponentID, string) (storage.Client, error) {
+	return h.client, nil
+}
@@ -30,6 +30,17 @@ type Config struct {
 	// MaxStaleness is the total time a state entry will live past the time it was last seen. Set to 0 to retain state indefinitely.
 	MaxStaleness time.Duration `mapstructure:"max_staleness"`
 
+	// MaxStreams is the maximum number of streams kept in the tracking state. When it is exceeded, the streams
+	// observed least recently are evicted. Set to 0 to not bound the state.
+	MaxStreams int `mapstructure:"max_streams"`
+
+	// Storage is the ID of a storage extension used to checkpoint the tracking state, so that it survives restarts.
+	Storage *config.ComponentID `mapstructure:"storage"`
+
+	// CheckpointInterval sets how often the tracking state is checkpointed when a storage is configured.
+	// The state is checkpointed on shutdown as well.
+	CheckpointInterval time.Duration `mapstructure:"checkpoint_interval"`
+
 	// Include specifies a filter on the metrics that should be converted.
 	// Exclude specifies a filter on the metrics that should not be converted.
 	// If neither `include` nor `exclude` are set, all metrics will be converted.
@@ -57,5 +68,11 @@ func (config *Config) Validate() error {
 		(len(config.Exclude.MatchType) > 0 && len(config.Exclude.Metrics) == 0) {
 		return fmt.Errorf("metrics must be supplied if match_type is set")
 	}
+	if config.MaxStreams < 0 {
+		return fmt.Errorf("max_streams must not be negative")
+	}
+	if config.Storage != nil && config.CheckpointInterval <= 0 {
+		return fmt.Errorf("checkpoint_interval must be positive when a storage is configured")
+	}
 	return nil
 }
@@ -30,6 +30,8 @@ import (
 func TestLoadConfig(t *testing.T) {
 	t.Parallel()
 
+	storageID := config.NewComponentID("file_storage")
+
 	tests := []struct {
 		id           config.ComponentID
 		expected     config.Processor
@@ -59,7 +61,8 @@ func TestLoadConfig(t *testing.T) {
 						RegexpConfig: nil,
 					},
 				},
-				MaxStaleness: 10 * time.Second,
+				MaxStaleness:       10 * time.Second,
+				CheckpointInterval: 30 * time.Second,
 			},
 		},
 		{
@@ -88,7 +91,17 @@ func TestLoadConfig(t *testing.T) {
 						RegexpConfig: nil,
 					},
 				},
-				MaxStaleness: 10 * time.Second,
+				MaxStaleness:       10 * time.Second,
+				CheckpointInterval: 30 * time.Second,
+			},
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "checkpoint"),
+			expected: &Config{
+				ProcessorSettings:  config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				MaxStreams:         1000,
+				Storage:            &storageID,
+				CheckpointInterval: 10 * time.Second,
 			},
 		},
 		{
@@ -99,6 +112,14 @@ func TestLoadConfig(t *testing.T) {
 			id:           config.NewComponentIDWithName(typeStr, "missing_name"),
 			errorMessage: "metrics must be supplied if match_type is set",
 		},
+		{
+			id:           config.NewComponentIDWithName(typeStr, "negative_max_streams"),
+			errorMessage: "max_streams must not be negative",
+		},
+		{
+			id:           config.NewComponentIDWithName(typeStr, "missing_checkpoint_interval"),
+			errorMessage: "checkpoint_interval must be positive when a storage is configured",
+		},
 	}
 
 	for _, tt := range tests {
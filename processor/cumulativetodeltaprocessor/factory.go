@@ -17,6 +17,7 @@ package cumulativetodeltaprocessor // import "github.com/open-telemetry/opentele
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
@@ -43,7 +44,8 @@ func NewFactory() component.ProcessorFactory {
 
 func createDefaultConfig() config.Processor {
 	return &Config{
-		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		ProcessorSettings:  config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		CheckpointInterval: 30 * time.Second,
 	}
 }
 
@@ -67,5 +69,6 @@ func createMetricsProcessor(
 		nextConsumer,
 		metricsProcessor.processMetrics,
 		processorhelper.WithCapabilities(processorCapabilities),
+		processorhelper.WithStart(metricsProcessor.start),
 		processorhelper.WithShutdown(metricsProcessor.shutdown))
 }
@@ -0,0 +1,293 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracking // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor/internal/tracking"
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+)
+
+// checkpointVersion is written at the beginning of the checkpoints, so that the format can evolve.
+const checkpointVersion byte = 1
+
+var errUnsupportedCheckpoint = errors.New("unsupported checkpoint version")
+
+// Kinds of the values following the scalar values of a point in the checkpoints.
+const (
+	noValue byte = iota
+	histogramValue
+	expHistogramValue
+)
+
+// MarshalStates encodes the last point of each tracked stream, so that it can be restored by UnmarshalStates.
+// Only the points are encoded, the restored states are looked up by the identity of the streams.
+func (t *MetricTracker) MarshalStates() []byte {
+	w := &checkpointWriter{buf: bytes.NewBuffer([]byte{checkpointVersion})}
+	t.states.Range(func(key, value interface{}) bool {
+		s := value.(*State)
+		s.Lock()
+		point := s.PrevPoint
+		s.Unlock()
+
+		id := key.(string)
+		w.uvarint(uint64(len(id)))
+		w.buf.WriteString(id)
+		w.writePoint(point)
+		return true
+	})
+	return w.buf.Bytes()
+}
+
+// UnmarshalStates restores the states encoded by MarshalStates. Streams that are already tracked keep
+// their current state, as it is more recent than the restored one.
+func (t *MetricTracker) UnmarshalStates(data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if data[0] != checkpointVersion {
+		return fmt.Errorf("%w: %d", errUnsupportedCheckpoint, data[0])
+	}
+
+	restored := make(map[string]ValuePoint)
+	r := &checkpointReader{r: bytes.NewReader(data[1:])}
+	for r.r.Len() > 0 {
+		id := r.bytes()
+		point := r.readPoint()
+		if r.err != nil {
+			return fmt.Errorf("invalid checkpoint: %w", r.err)
+		}
+		restored[string(id)] = point
+	}
+
+	for id, point := range restored {
+		if _, loaded := t.states.LoadOrStore(id, &State{PrevPoint: point}); !loaded {
+			t.numStates.Inc()
+		}
+	}
+	if t.maxStreams > 0 && t.numStates.Load() > int64(t.maxStreams) {
+		t.evictOldest()
+	}
+	return nil
+}
+
+type checkpointWriter struct {
+	buf     *bytes.Buffer
+	scratch [binary.MaxVarintLen64]byte
+}
+
+func (w *checkpointWriter) uvarint(v uint64) {
+	n := binary.PutUvarint(w.scratch[:], v)
+	w.buf.Write(w.scratch[:n])
+}
+
+func (w *checkpointWriter) uint64(v uint64) {
+	binary.BigEndian.PutUint64(w.scratch[:8], v)
+	w.buf.Write(w.scratch[:8])
+}
+
+func (w *checkpointWriter) uint64s(values []uint64) {
+	w.uvarint(uint64(len(values)))
+	for _, v := range values {
+		w.uint64(v)
+	}
+}
+
+func (w *checkpointWriter) writePoint(point ValuePoint) {
+	w.uint64(uint64(point.ObservedTimestamp))
+	w.uint64(math.Float64bits(point.FloatValue))
+	w.uint64(uint64(point.IntValue))
+
+	switch {
+	case point.HistogramValue != nil:
+		h := point.HistogramValue
+		w.buf.WriteByte(histogramValue)
+		w.uint64(h.Count)
+		w.uint64(math.Float64bits(h.Sum))
+		w.uvarint(uint64(len(h.ExplicitBounds)))
+		for _, bound := range h.ExplicitBounds {
+			w.uint64(math.Float64bits(bound))
+		}
+		w.uint64s(h.Buckets)
+	case point.ExpHistogramValue != nil:
+		h := point.ExpHistogramValue
+		w.buf.WriteByte(expHistogramValue)
+		w.uint64(h.Count)
+		w.uint64(math.Float64bits(h.Sum))
+		w.uint64(uint64(int64(h.Scale)))
+		w.uint64(h.ZeroCount)
+		w.uint64(uint64(int64(h.Positive.Offset)))
+		w.uint64s(h.Positive.Counts)
+		w.uint64(uint64(int64(h.Negative.Offset)))
+		w.uint64s(h.Negative.Counts)
+	default:
+		w.buf.WriteByte(noValue)
+	}
+}
+
+// checkpointReader decodes the checkpoints written by checkpointWriter. The first error is kept in err,
+// the following reads return zero values.
+type checkpointReader struct {
+	r   *bytes.Reader
+	err error
+}
+
+func (r *checkpointReader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	v, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		r.err = unexpectedEOF(err)
+	}
+	return v
+}
+
+func (r *checkpointReader) uint64() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	var b [8]byte
+	if _, err := io.ReadFull(r.r, b[:]); err != nil {
+		r.err = unexpectedEOF(err)
+		return 0
+	}
+	return binary.BigEndian.Uint64(b[:])
+}
+
+func (r *checkpointReader) byte() byte {
+	if r.err != nil {
+		return 0
+	}
+	b, err := r.r.ReadByte()
+	if err != nil {
+		r.err = io.ErrUnexpectedEOF
+	}
+	return b
+}
+
+// length reads the number of the following items of size bytes, making sure they are all there.
+func (r *checkpointReader) length(size int) int {
+	n := r.uvarint()
+	if r.err == nil && n > uint64(r.r.Len()/size) {
+		r.err = io.ErrUnexpectedEOF
+		return 0
+	}
+	return int(n)
+}
+
+func (r *checkpointReader) bytes() []byte {
+	b := make([]byte, r.length(1))
+	if r.err == nil {
+		// the length is known to be available
+		_, _ = io.ReadFull(r.r, b)
+	}
+	return b
+}
+
+func (r *checkpointReader) uint64s() []uint64 {
+	values := make([]uint64, r.length(8))
+	for i := range values {
+		values[i] = r.uint64()
+	}
+	return values
+}
+
+func (r *checkpointReader) readPoint() ValuePoint {
+	point := ValuePoint{
+		ObservedTimestamp: pcommon.Timestamp(r.uint64()),
+		FloatValue:        math.Float64frombits(r.uint64()),
+		IntValue:          int64(r.uint64()),
+	}
+
+	switch kind := r.byte(); kind {
+	case noValue:
+	case histogramValue:
+		h := &HistogramPoint{
+			Count: r.uint64(),
+			Sum:   math.Float64frombits(r.uint64()),
+		}
+		h.ExplicitBounds = make([]float64, r.length(8))
+		for i := range h.ExplicitBounds {
+			h.ExplicitBounds[i] = math.Float64frombits(r.uint64())
+		}
+		h.Buckets = r.uint64s()
+		point.HistogramValue = h
+	case expHistogramValue:
+		h := &ExpHistogramPoint{
+			Count:     r.uint64(),
+			Sum:       math.Float64frombits(r.uint64()),
+			Scale:     int32(int64(r.uint64())),
+			ZeroCount: r.uint64(),
+		}
+		h.Positive.Offset = int32(int64(r.uint64()))
+		h.Positive.Counts = r.uint64s()
+		h.Negative.Offset = int32(int64(r.uint64()))
+		h.Negative.Counts = r.uint64s()
+		point.ExpHistogramValue = h
+	default:
+		if r.err == nil {
+			r.err = fmt.Errorf("unknown value kind %d", kind)
+		}
+	}
+	return point
+}
+
+// unexpectedEOF reports the checkpoints ending in the middle of a point as truncated.
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// evictOldest removes the states that were observed least recently, so that a tenth of maxStreams is
+// available again. Freeing more than a single state avoids going through all of them for every new stream.
+func (t *MetricTracker) evictOldest() {
+	type observedState struct {
+		key          interface{}
+		lastObserved pcommon.Timestamp
+	}
+
+	var observed []observedState
+	t.states.Range(func(key, value interface{}) bool {
+		s := value.(*State)
+		s.Lock()
+		observed = append(observed, observedState{key: key, lastObserved: s.PrevPoint.ObservedTimestamp})
+		s.Unlock()
+		return true
+	})
+
+	keep := t.maxStreams - t.maxStreams/10
+	if len(observed) <= keep {
+		return
+	}
+	sort.Slice(observed, func(i, j int) bool {
+		return observed[i].lastObserved < observed[j].lastObserved
+	})
+
+	evicted := observed[:len(observed)-keep]
+	for _, o := range evicted {
+		t.deleteState(o.key)
+	}
+	t.logger.Debug("evicted the least recently observed states", zap.Int("evicted", len(evicted)), zap.Int("max_streams", t.maxStreams))
+}
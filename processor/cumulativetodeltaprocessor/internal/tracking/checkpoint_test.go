@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracking
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+func newSumPoint(name string, ts pcommon.Timestamp, value int64) MetricPoint {
+	return MetricPoint{
+		Identity: MetricIdentity{
+			Resource:               pcommon.NewResource(),
+			InstrumentationLibrary: pcommon.NewInstrumentationScope(),
+			MetricDataType:         pmetric.MetricDataTypeSum,
+			MetricIsMonotonic:      true,
+			MetricName:             name,
+			Attributes:             pcommon.NewMap(),
+			MetricValueType:        pmetric.NumberDataPointValueTypeInt,
+		},
+		Value: ValuePoint{
+			ObservedTimestamp: ts,
+			IntValue:          value,
+		},
+	}
+}
+
+func TestMetricTracker_MarshalStates(t *testing.T) {
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0, 0)
+	m.Convert(newSumPoint("a", 10, 100))
+	m.Convert(newSumPoint("b", 20, 200))
+	m.states.Store("float", &State{PrevPoint: ValuePoint{ObservedTimestamp: 30, FloatValue: math.Inf(1)}})
+	histogram := &HistogramPoint{Count: 10, Sum: 2.5, ExplicitBounds: []float64{1, 10}, Buckets: []uint64{3, 4, 3}}
+	m.states.Store("histogram", &State{PrevPoint: ValuePoint{ObservedTimestamp: 40, HistogramValue: histogram}})
+	expHistogram := &ExpHistogramPoint{
+		Count:     10,
+		Sum:       -2.5,
+		Scale:     -1,
+		ZeroCount: 1,
+		Positive:  ExpBuckets{Offset: 2, Counts: []uint64{3, 4}},
+		Negative:  ExpBuckets{Offset: -3, Counts: []uint64{2}},
+	}
+	m.states.Store("exponential", &State{PrevPoint: ValuePoint{ObservedTimestamp: 50, ExpHistogramValue: expHistogram}})
+
+	restored := NewMetricTracker(context.Background(), zap.NewNop(), 0, 0)
+	require.NoError(t, restored.UnmarshalStates(m.MarshalStates()))
+	assert.EqualValues(t, 5, restored.numStates.Load())
+
+	s, ok := restored.states.Load("float")
+	require.True(t, ok)
+	assert.Equal(t, ValuePoint{ObservedTimestamp: 30, FloatValue: math.Inf(1)}, s.(*State).PrevPoint)
+	s, ok = restored.states.Load("histogram")
+	require.True(t, ok)
+	assert.Equal(t, ValuePoint{ObservedTimestamp: 40, HistogramValue: histogram}, s.(*State).PrevPoint)
+	s, ok = restored.states.Load("exponential")
+	require.True(t, ok)
+	assert.Equal(t, ValuePoint{ObservedTimestamp: 50, ExpHistogramValue: expHistogram}, s.(*State).PrevPoint)
+
+	out, valid := restored.Convert(newSumPoint("a", 50, 150))
+	require.True(t, valid)
+	assert.Equal(t, DeltaValue{StartTimestamp: 10, IntValue: 50}, out)
+	out, valid = restored.Convert(newSumPoint("b", 50, 250))
+	require.True(t, valid)
+	assert.Equal(t, DeltaValue{StartTimestamp: 20, IntValue: 50}, out)
+}
+
+func TestMetricTracker_UnmarshalStatesKeepsTrackedStreams(t *testing.T) {
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0, 0)
+	m.Convert(newSumPoint("a", 10, 100))
+	checkpoint := m.MarshalStates()
+	m.Convert(newSumPoint("a", 20, 120))
+
+	require.NoError(t, m.UnmarshalStates(checkpoint))
+	assert.EqualValues(t, 1, m.numStates.Load())
+
+	out, valid := m.Convert(newSumPoint("a", 30, 130))
+	require.True(t, valid)
+	assert.Equal(t, DeltaValue{StartTimestamp: 20, IntValue: 10}, out)
+}
+
+func TestMetricTracker_UnmarshalStatesInvalid(t *testing.T) {
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0, 0)
+	m.Convert(newSumPoint("a", 10, 100))
+	checkpoint := m.MarshalStates()
+
+	tests := []struct {
+		name string
+		data []byte
+		err  string
+	}{
+		{
+			name: "unsupported-version",
+			data: []byte{0xff},
+			err:  "unsupported checkpoint version: 255",
+		},
+		{
+			name: "truncated-identity",
+			data: checkpoint[:3],
+			err:  "invalid checkpoint: unexpected EOF",
+		},
+		{
+			name: "unknown-value-kind",
+			data: append(append([]byte{}, checkpoint[:len(checkpoint)-1]...), 0xff),
+			err:  "invalid checkpoint: unknown value kind 255",
+		},
+		{
+			name: "truncated-point",
+			data: checkpoint[:len(checkpoint)-1],
+			err:  "invalid checkpoint: unexpected EOF",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restored := NewMetricTracker(context.Background(), zap.NewNop(), 0, 0)
+			assert.EqualError(t, restored.UnmarshalStates(tt.data), tt.err)
+			assert.EqualValues(t, 0, restored.numStates.Load())
+		})
+	}
+
+	restored := NewMetricTracker(context.Background(), zap.NewNop(), 0, 0)
+	assert.NoError(t, restored.UnmarshalStates(nil))
+}
+
+func TestMetricTracker_MaxStreams(t *testing.T) {
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0, 10)
+	for i := 0; i < 10; i++ {
+		m.Convert(newSumPoint(strconv.Itoa(i), pcommon.Timestamp(i), 100))
+	}
+	assert.EqualValues(t, 10, m.numStates.Load())
+
+	// the eleventh stream evicts the two streams observed least recently
+	m.Convert(newSumPoint("10", 10, 100))
+	assert.EqualValues(t, 9, m.numStates.Load())
+	out, valid := m.Convert(newSumPoint("0", 20, 150))
+	require.True(t, valid)
+	assert.Equal(t, DeltaValue{StartTimestamp: 20, IntValue: 150}, out)
+	out, valid = m.Convert(newSumPoint("2", 20, 150))
+	require.True(t, valid)
+	assert.Equal(t, DeltaValue{StartTimestamp: 2, IntValue: 50}, out)
+}
+
+func TestMetricTracker_MaxStreamsOnRestore(t *testing.T) {
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0, 0)
+	for i := 0; i < 20; i++ {
+		m.Convert(newSumPoint(strconv.Itoa(i), pcommon.Timestamp(i), 100))
+	}
+
+	restored := NewMetricTracker(context.Background(), zap.NewNop(), 0, 10)
+	require.NoError(t, restored.UnmarshalStates(m.MarshalStates()))
+	assert.EqualValues(t, 9, restored.numStates.Load())
+
+	out, valid := restored.Convert(newSumPoint("19", 30, 150))
+	require.True(t, valid)
+	assert.Equal(t, DeltaValue{StartTimestamp: 19, IntValue: 50}, out)
+}
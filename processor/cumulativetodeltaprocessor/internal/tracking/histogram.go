@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracking // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor/internal/tracking"
+
+// histogramDelta returns the difference between the value and the previous value of a histogram stream.
+// The value itself is returned when the stream was reset, which is detected by any of the counts going down,
+// or when the bucket boundaries changed.
+func histogramDelta(value, prev *HistogramPoint) *HistogramPoint {
+	if prev == nil || value.Count < prev.Count || !equalBounds(value.ExplicitBounds, prev.ExplicitBounds) ||
+		len(value.Buckets) != len(prev.Buckets) {
+		return value
+	}
+
+	delta := &HistogramPoint{
+		Count:          value.Count - prev.Count,
+		Sum:            value.Sum - prev.Sum,
+		ExplicitBounds: value.ExplicitBounds,
+		Buckets:        make([]uint64, len(value.Buckets)),
+	}
+	for i, count := range value.Buckets {
+		if count < prev.Buckets[i] {
+			return value
+		}
+		delta.Buckets[i] = count - prev.Buckets[i]
+	}
+	return delta
+}
+
+func equalBounds(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// expHistogramDelta returns the difference between the value and the previous value of an exponential histogram
+// stream. The previous value is downscaled first when the scale of the stream went down. The value itself is
+// returned when the stream was reset, which is detected by any of the counts going down or the scale going up.
+func expHistogramDelta(value, prev *ExpHistogramPoint) *ExpHistogramPoint {
+	if prev == nil || value.Scale > prev.Scale || value.Count < prev.Count || value.ZeroCount < prev.ZeroCount {
+		return value
+	}
+
+	shift := prev.Scale - value.Scale
+	positive, ok := expBucketsDelta(value.Positive, downscale(prev.Positive, shift))
+	if !ok {
+		return value
+	}
+	negative, ok := expBucketsDelta(value.Negative, downscale(prev.Negative, shift))
+	if !ok {
+		return value
+	}
+
+	return &ExpHistogramPoint{
+		Count:     value.Count - prev.Count,
+		Sum:       value.Sum - prev.Sum,
+		Scale:     value.Scale,
+		ZeroCount: value.ZeroCount - prev.ZeroCount,
+		Positive:  positive,
+		Negative:  negative,
+	}
+}
+
+// downscale merges the buckets so that they match a scale lower by shift. Each bucket index at the lower
+// scale covers 2^shift indexes at the original scale.
+func downscale(buckets ExpBuckets, shift int32) ExpBuckets {
+	if shift == 0 || len(buckets.Counts) == 0 {
+		return buckets
+	}
+
+	first := buckets.Offset >> shift
+	last := (buckets.Offset + int32(len(buckets.Counts)) - 1) >> shift
+	downscaled := ExpBuckets{
+		Offset: first,
+		Counts: make([]uint64, last-first+1),
+	}
+	for i, count := range buckets.Counts {
+		index := (buckets.Offset + int32(i)) >> shift
+		downscaled.Counts[index-first] += count
+	}
+	return downscaled
+}
+
+// expBucketsDelta subtracts the previous bucket counts from the current ones. It reports false when
+// a previous count is not covered by the current buckets anymore, or is greater than the current count.
+func expBucketsDelta(value, prev ExpBuckets) (ExpBuckets, bool) {
+	delta := ExpBuckets{
+		Offset: value.Offset,
+		Counts: make([]uint64, len(value.Counts)),
+	}
+	copy(delta.Counts, value.Counts)
+
+	for i, count := range prev.Counts {
+		if count == 0 {
+			continue
+		}
+		index := prev.Offset + int32(i) - value.Offset
+		if index < 0 || int(index) >= len(delta.Counts) || delta.Counts[index] < count {
+			return ExpBuckets{}, false
+		}
+		delta.Counts[index] -= count
+	}
+	return delta, true
+}
@@ -0,0 +1,234 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracking
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+func Test_histogramDelta(t *testing.T) {
+	prev := &HistogramPoint{Count: 10, Sum: 100, ExplicitBounds: []float64{1, 10}, Buckets: []uint64{5, 3, 2}}
+	tests := []struct {
+		name  string
+		value *HistogramPoint
+		prev  *HistogramPoint
+		want  *HistogramPoint
+	}{
+		{
+			name:  "delta",
+			value: &HistogramPoint{Count: 15, Sum: 160, ExplicitBounds: []float64{1, 10}, Buckets: []uint64{7, 5, 3}},
+			prev:  prev,
+			want:  &HistogramPoint{Count: 5, Sum: 60, ExplicitBounds: []float64{1, 10}, Buckets: []uint64{2, 2, 1}},
+		},
+		{
+			name:  "no-previous-value",
+			value: &HistogramPoint{Count: 15, Sum: 160, ExplicitBounds: []float64{1, 10}, Buckets: []uint64{7, 5, 3}},
+			want:  &HistogramPoint{Count: 15, Sum: 160, ExplicitBounds: []float64{1, 10}, Buckets: []uint64{7, 5, 3}},
+		},
+		{
+			name:  "count-reset",
+			value: &HistogramPoint{Count: 4, Sum: 40, ExplicitBounds: []float64{1, 10}, Buckets: []uint64{2, 1, 1}},
+			prev:  prev,
+			want:  &HistogramPoint{Count: 4, Sum: 40, ExplicitBounds: []float64{1, 10}, Buckets: []uint64{2, 1, 1}},
+		},
+		{
+			name:  "bucket-reset",
+			value: &HistogramPoint{Count: 15, Sum: 160, ExplicitBounds: []float64{1, 10}, Buckets: []uint64{4, 8, 3}},
+			prev:  prev,
+			want:  &HistogramPoint{Count: 15, Sum: 160, ExplicitBounds: []float64{1, 10}, Buckets: []uint64{4, 8, 3}},
+		},
+		{
+			name:  "bounds-changed",
+			value: &HistogramPoint{Count: 15, Sum: 160, ExplicitBounds: []float64{1, 20}, Buckets: []uint64{7, 5, 3}},
+			prev:  prev,
+			want:  &HistogramPoint{Count: 15, Sum: 160, ExplicitBounds: []float64{1, 20}, Buckets: []uint64{7, 5, 3}},
+		},
+		{
+			name:  "buckets-added",
+			value: &HistogramPoint{Count: 15, Sum: 160, ExplicitBounds: []float64{1, 5, 10}, Buckets: []uint64{7, 2, 3, 3}},
+			prev:  prev,
+			want:  &HistogramPoint{Count: 15, Sum: 160, ExplicitBounds: []float64{1, 5, 10}, Buckets: []uint64{7, 2, 3, 3}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, histogramDelta(tt.value, tt.prev))
+		})
+	}
+}
+
+func Test_expHistogramDelta(t *testing.T) {
+	prev := &ExpHistogramPoint{
+		Count:     10,
+		Sum:       100,
+		Scale:     2,
+		ZeroCount: 1,
+		Positive:  ExpBuckets{Offset: 3, Counts: []uint64{2, 3, 1}},
+		Negative:  ExpBuckets{Offset: -2, Counts: []uint64{2, 1}},
+	}
+	tests := []struct {
+		name  string
+		value *ExpHistogramPoint
+		prev  *ExpHistogramPoint
+		want  *ExpHistogramPoint
+	}{
+		{
+			name: "same-scale",
+			value: &ExpHistogramPoint{
+				Count:     16,
+				Sum:       150,
+				Scale:     2,
+				ZeroCount: 2,
+				Positive:  ExpBuckets{Offset: 2, Counts: []uint64{1, 3, 4, 2}},
+				Negative:  ExpBuckets{Offset: -2, Counts: []uint64{3, 1}},
+			},
+			prev: prev,
+			want: &ExpHistogramPoint{
+				Count:     6,
+				Sum:       50,
+				Scale:     2,
+				ZeroCount: 1,
+				Positive:  ExpBuckets{Offset: 2, Counts: []uint64{1, 1, 1, 1}},
+				Negative:  ExpBuckets{Offset: -2, Counts: []uint64{1, 0}},
+			},
+		},
+		{
+			name: "downscaled",
+			value: &ExpHistogramPoint{
+				Count:     16,
+				Sum:       150,
+				Scale:     1,
+				ZeroCount: 2,
+				Positive:  ExpBuckets{Offset: 1, Counts: []uint64{4, 6}},
+				Negative:  ExpBuckets{Offset: -1, Counts: []uint64{4}},
+			},
+			prev: prev,
+			want: &ExpHistogramPoint{
+				Count:     6,
+				Sum:       50,
+				Scale:     1,
+				ZeroCount: 1,
+				Positive:  ExpBuckets{Offset: 1, Counts: []uint64{2, 2}},
+				Negative:  ExpBuckets{Offset: -1, Counts: []uint64{1}},
+			},
+		},
+		{
+			name: "upscaled",
+			value: &ExpHistogramPoint{
+				Count:    16,
+				Sum:      150,
+				Scale:    3,
+				Positive: ExpBuckets{Offset: 6, Counts: []uint64{16}},
+			},
+			prev: prev,
+			want: &ExpHistogramPoint{
+				Count:    16,
+				Sum:      150,
+				Scale:    3,
+				Positive: ExpBuckets{Offset: 6, Counts: []uint64{16}},
+			},
+		},
+		{
+			name: "zero-count-reset",
+			value: &ExpHistogramPoint{
+				Count:    16,
+				Sum:      150,
+				Scale:    2,
+				Positive: ExpBuckets{Offset: 3, Counts: []uint64{4, 6, 3}},
+				Negative: ExpBuckets{Offset: -2, Counts: []uint64{2, 1}},
+			},
+			prev: prev,
+			want: &ExpHistogramPoint{
+				Count:    16,
+				Sum:      150,
+				Scale:    2,
+				Positive: ExpBuckets{Offset: 3, Counts: []uint64{4, 6, 3}},
+				Negative: ExpBuckets{Offset: -2, Counts: []uint64{2, 1}},
+			},
+		},
+		{
+			name: "bucket-not-covered",
+			value: &ExpHistogramPoint{
+				Count:     16,
+				Sum:       150,
+				Scale:     2,
+				ZeroCount: 1,
+				Positive:  ExpBuckets{Offset: 4, Counts: []uint64{8, 2}},
+				Negative:  ExpBuckets{Offset: -2, Counts: []uint64{2, 1}},
+			},
+			prev: prev,
+			want: &ExpHistogramPoint{
+				Count:     16,
+				Sum:       150,
+				Scale:     2,
+				ZeroCount: 1,
+				Positive:  ExpBuckets{Offset: 4, Counts: []uint64{8, 2}},
+				Negative:  ExpBuckets{Offset: -2, Counts: []uint64{2, 1}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, expHistogramDelta(tt.value, tt.prev))
+		})
+	}
+}
+
+func Test_downscale(t *testing.T) {
+	buckets := ExpBuckets{Offset: -3, Counts: []uint64{1, 2, 3, 4, 5}}
+	assert.Equal(t, buckets, downscale(buckets, 0))
+	assert.Equal(t, ExpBuckets{Offset: -2, Counts: []uint64{1, 5, 9}}, downscale(buckets, 1))
+	assert.Equal(t, ExpBuckets{Offset: -1, Counts: []uint64{6, 9}}, downscale(buckets, 2))
+	assert.Equal(t, ExpBuckets{}, downscale(ExpBuckets{}, 2))
+}
+
+func TestMetricTracker_ConvertHistogramNaNSum(t *testing.T) {
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0, 0)
+	identity := MetricIdentity{
+		Resource:               pcommon.NewResource(),
+		InstrumentationLibrary: pcommon.NewInstrumentationScope(),
+		MetricDataType:         pmetric.MetricDataTypeHistogram,
+		MetricIsMonotonic:      true,
+		Attributes:             pcommon.NewMap(),
+	}
+	convert := func(ts pcommon.Timestamp, count uint64, sum float64) DeltaValue {
+		out, valid := m.Convert(MetricPoint{
+			Identity: identity,
+			Value: ValuePoint{
+				ObservedTimestamp: ts,
+				HistogramValue:    &HistogramPoint{Count: count, Sum: sum, Buckets: []uint64{count}},
+			},
+		})
+		require.True(t, valid)
+		return out
+	}
+
+	assert.Equal(t, &HistogramPoint{Count: 10, Sum: 100, Buckets: []uint64{10}}, convert(10, 10, 100).HistogramValue)
+	out := convert(20, 20, math.NaN())
+	assert.Equal(t, uint64(10), out.HistogramValue.Count)
+	assert.True(t, math.IsNaN(out.HistogramValue.Sum))
+	assert.Equal(t, DeltaValue{
+		StartTimestamp: 20,
+		HistogramValue: &HistogramPoint{Count: 10, Sum: 200, Buckets: []uint64{10}},
+	}, convert(30, 30, 300))
+}
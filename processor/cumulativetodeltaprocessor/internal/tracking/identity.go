@@ -35,12 +35,6 @@ type MetricIdentity struct {
 	MetricField            string
 }
 
-type HistogramIdentities struct {
-	CountIdentity    MetricIdentity
-	SumIdentity      MetricIdentity
-	BucketIdentities []MetricIdentity
-}
-
 const A = int32('A')
 const SEP = byte(0x1E)
 const SEPSTR = string(SEP)
@@ -94,5 +88,7 @@ func (mi *MetricIdentity) IsFloatVal() bool {
 }
 
 func (mi *MetricIdentity) IsSupportedMetricType() bool {
-	return mi.MetricDataType == pmetric.MetricDataTypeSum || mi.MetricDataType == pmetric.MetricDataTypeHistogram
+	return mi.MetricDataType == pmetric.MetricDataTypeSum ||
+		mi.MetricDataType == pmetric.MetricDataTypeHistogram ||
+		mi.MetricDataType == pmetric.MetricDataTypeExponentialHistogram
 }
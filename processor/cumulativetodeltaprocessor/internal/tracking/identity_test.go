@@ -194,7 +194,7 @@ func TestMetricIdentity_IsSupportedMetricType(t *testing.T) {
 			fields: fields{
 				MetricDataType: pmetric.MetricDataTypeExponentialHistogram,
 			},
-			want: false,
+			want: true,
 		},
 		{
 			name: "summary",
@@ -22,6 +22,8 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
 
@@ -41,13 +43,15 @@ type State struct {
 }
 
 type DeltaValue struct {
-	StartTimestamp pcommon.Timestamp
-	FloatValue     float64
-	IntValue       int64
+	StartTimestamp    pcommon.Timestamp
+	FloatValue        float64
+	IntValue          int64
+	HistogramValue    *HistogramPoint
+	ExpHistogramValue *ExpHistogramPoint
 }
 
-func NewMetricTracker(ctx context.Context, logger *zap.Logger, maxStaleness time.Duration) *MetricTracker {
-	t := &MetricTracker{logger: logger, maxStaleness: maxStaleness}
+func NewMetricTracker(ctx context.Context, logger *zap.Logger, maxStaleness time.Duration, maxStreams int) *MetricTracker {
+	t := &MetricTracker{logger: logger, maxStaleness: maxStaleness, maxStreams: maxStreams}
 	if maxStaleness > 0 {
 		go t.sweeper(ctx, t.removeStale)
 	}
@@ -57,7 +61,10 @@ func NewMetricTracker(ctx context.Context, logger *zap.Logger, maxStaleness time
 type MetricTracker struct {
 	logger       *zap.Logger
 	maxStaleness time.Duration
-	states       sync.Map
+	// maxStreams bounds the number of states kept. Zero keeps all of them.
+	maxStreams int
+	states     sync.Map
+	numStates  atomic.Int64
 }
 
 func (t *MetricTracker) Convert(in MetricPoint) (out DeltaValue, valid bool) {
@@ -90,11 +97,16 @@ func (t *MetricTracker) Convert(in MetricPoint) (out DeltaValue, valid bool) {
 	}
 
 	if !ok {
+		if numStates := t.numStates.Inc(); t.maxStreams > 0 && numStates > int64(t.maxStreams) {
+			t.evictOldest()
+		}
 		if metricID.MetricIsMonotonic {
 			out = DeltaValue{
-				StartTimestamp: metricPoint.ObservedTimestamp,
-				FloatValue:     metricPoint.FloatValue,
-				IntValue:       metricPoint.IntValue,
+				StartTimestamp:    metricPoint.ObservedTimestamp,
+				FloatValue:        metricPoint.FloatValue,
+				IntValue:          metricPoint.IntValue,
+				HistogramValue:    metricPoint.HistogramValue,
+				ExpHistogramValue: metricPoint.ExpHistogramValue,
 			}
 			valid = true
 		}
@@ -108,7 +120,29 @@ func (t *MetricTracker) Convert(in MetricPoint) (out DeltaValue, valid bool) {
 
 	out.StartTimestamp = state.PrevPoint.ObservedTimestamp
 
-	if metricID.IsFloatVal() {
+	switch {
+	case metricID.MetricDataType == pmetric.MetricDataTypeHistogram:
+		value := metricPoint.HistogramValue
+		prevValue := state.PrevPoint.HistogramValue
+		out.HistogramValue = histogramDelta(value, prevValue)
+
+		// A NaN sum doesn't update the previous sum, so that the next sum is converted against a known value
+		if math.IsNaN(value.Sum) && prevValue != nil && out.HistogramValue != value {
+			stored := *value
+			stored.Sum = prevValue.Sum
+			metricPoint.HistogramValue = &stored
+		}
+	case metricID.MetricDataType == pmetric.MetricDataTypeExponentialHistogram:
+		value := metricPoint.ExpHistogramValue
+		prevValue := state.PrevPoint.ExpHistogramValue
+		out.ExpHistogramValue = expHistogramDelta(value, prevValue)
+
+		if math.IsNaN(value.Sum) && prevValue != nil && out.ExpHistogramValue != value {
+			stored := *value
+			stored.Sum = prevValue.Sum
+			metricPoint.ExpHistogramValue = &stored
+		}
+	case metricID.IsFloatVal():
 		value := metricPoint.FloatValue
 		prevValue := state.PrevPoint.FloatValue
 		delta := value - prevValue
@@ -119,7 +153,7 @@ func (t *MetricTracker) Convert(in MetricPoint) (out DeltaValue, valid bool) {
 		}
 
 		out.FloatValue = delta
-	} else {
+	default:
 		value := metricPoint.IntValue
 		prevValue := state.PrevPoint.IntValue
 		delta := value - prevValue
@@ -158,7 +192,7 @@ func (t *MetricTracker) removeStale(staleBefore pcommon.Timestamp) {
 		s.Unlock()
 		if lastObserved < staleBefore {
 			t.logger.Debug("removing stale state key", zap.String("key", key.(string)))
-			t.states.Delete(key)
+			t.deleteState(key)
 		}
 		return true
 	})
@@ -177,3 +211,10 @@ func (t *MetricTracker) sweeper(ctx context.Context, remove func(pcommon.Timesta
 		}
 	}
 }
+
+// deleteState removes the state stored under key, keeping the count of states up to date.
+func (t *MetricTracker) deleteState(key interface{}) {
+	if _, loaded := t.states.LoadAndDelete(key); loaded {
+		t.numStates.Dec()
+	}
+}
@@ -40,7 +40,7 @@ func TestMetricTracker_Convert(t *testing.T) {
 	miIntSum.MetricValueType = pmetric.NumberDataPointValueTypeInt
 	miSum.MetricValueType = pmetric.NumberDataPointValueTypeDouble
 
-	m := NewMetricTracker(context.Background(), zap.NewNop(), 0)
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0, 0)
 
 	tests := []struct {
 		name    string
@@ -20,4 +20,30 @@ type ValuePoint struct {
 	ObservedTimestamp pcommon.Timestamp
 	FloatValue        float64
 	IntValue          int64
+	HistogramValue    *HistogramPoint
+	ExpHistogramValue *ExpHistogramPoint
+}
+
+// HistogramPoint holds the values of a histogram data point, which are converted together.
+type HistogramPoint struct {
+	Count          uint64
+	Sum            float64
+	ExplicitBounds []float64
+	Buckets        []uint64
+}
+
+// ExpHistogramPoint holds the values of an exponential histogram data point, which are converted together.
+type ExpHistogramPoint struct {
+	Count     uint64
+	Sum       float64
+	Scale     int32
+	ZeroCount uint64
+	Positive  ExpBuckets
+	Negative  ExpBuckets
+}
+
+// ExpBuckets holds the bucket counts of one range of an exponential histogram.
+type ExpBuckets struct {
+	Offset int32
+	Counts []uint64
 }
@@ -16,15 +16,16 @@ package cumulativetodeltaprocessor // import "github.com/open-telemetry/opentele
 
 import (
 	"context"
-	"fmt"
 	"math"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/service/featuregate"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/storageutils"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor/internal/tracking"
 )
 
@@ -33,7 +34,7 @@ const enableHistogramSupportGateID = "processor.cumulativetodeltaprocessor.Enabl
 var enableHistogramSupportGate = featuregate.Gate{
 	ID:          enableHistogramSupportGateID,
 	Enabled:     false,
-	Description: "wip",
+	Description: "Converts the cumulative histograms and exponential histograms to delta",
 }
 
 func init() {
@@ -41,19 +42,22 @@ func init() {
 }
 
 type cumulativeToDeltaProcessor struct {
+	config                  *Config
 	includeFS               filterset.FilterSet
 	excludeFS               filterset.FilterSet
 	logger                  *zap.Logger
 	deltaCalculator         *tracking.MetricTracker
 	cancelFunc              context.CancelFunc
 	histogramSupportEnabled bool
+	checkpoints             *storageutils.PeriodicSync
 }
 
 func newCumulativeToDeltaProcessor(config *Config, logger *zap.Logger) *cumulativeToDeltaProcessor {
 	ctx, cancel := context.WithCancel(context.Background())
 	p := &cumulativeToDeltaProcessor{
+		config:                  config,
 		logger:                  logger,
-		deltaCalculator:         tracking.NewMetricTracker(ctx, logger, config.MaxStaleness),
+		deltaCalculator:         tracking.NewMetricTracker(ctx, logger, config.MaxStaleness, config.MaxStreams),
 		cancelFunc:              cancel,
 		histogramSupportEnabled: featuregate.GetRegistry().IsEnabled(enableHistogramSupportGateID),
 	}
@@ -114,30 +118,41 @@ func (ctdp *cumulativeToDeltaProcessor) processMetrics(_ context.Context, md pme
 						return false
 					}
 
-					countIdentity := tracking.MetricIdentity{
+					baseIdentity := tracking.MetricIdentity{
 						Resource:               rm.Resource(),
 						InstrumentationLibrary: ilm.Scope(),
 						MetricDataType:         m.DataType(),
 						MetricName:             m.Name(),
 						MetricUnit:             m.Unit(),
 						MetricIsMonotonic:      true,
-						MetricValueType:        pmetric.NumberDataPointValueTypeInt,
-						MetricField:            "count",
 					}
+					ctdp.convertHistogramDataPoints(ms.DataPoints(), baseIdentity)
 
-					sumIdentity := countIdentity
-					sumIdentity.MetricField = "sum"
-					sumIdentity.MetricValueType = pmetric.NumberDataPointValueTypeDouble
+					ms.SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+					return ms.DataPoints().Len() == 0
+				case pmetric.MetricDataTypeExponentialHistogram:
+					if !ctdp.histogramSupportEnabled {
+						return false
+					}
 
-					bucketIdentities := makeBucketIdentities(countIdentity, ms.DataPoints().At(0))
+					ms := m.ExponentialHistogram()
+					if ms.AggregationTemporality() != pmetric.MetricAggregationTemporalityCumulative {
+						return false
+					}
 
-					histogramIdentities := tracking.HistogramIdentities{
-						CountIdentity:    countIdentity,
-						SumIdentity:      sumIdentity,
-						BucketIdentities: bucketIdentities,
+					if ms.DataPoints().Len() == 0 {
+						return false
 					}
 
-					ctdp.convertHistogramDataPoints(ms.DataPoints(), &histogramIdentities)
+					baseIdentity := tracking.MetricIdentity{
+						Resource:               rm.Resource(),
+						InstrumentationLibrary: ilm.Scope(),
+						MetricDataType:         m.DataType(),
+						MetricName:             m.Name(),
+						MetricUnit:             m.Unit(),
+						MetricIsMonotonic:      true,
+					}
+					ctdp.convertExpHistogramDataPoints(ms.DataPoints(), baseIdentity)
 
 					ms.SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
 					return ms.DataPoints().Len() == 0
@@ -152,21 +167,26 @@ func (ctdp *cumulativeToDeltaProcessor) processMetrics(_ context.Context, md pme
 	return md, nil
 }
 
-func makeBucketIdentities(baseIdentity tracking.MetricIdentity, dp pmetric.HistogramDataPoint) []tracking.MetricIdentity {
-	numBuckets := dp.BucketCounts().Len()
-	bucketIdentities := make([]tracking.MetricIdentity, numBuckets)
-
-	for index := 0; index < numBuckets; index++ {
-		bucketIdentity := baseIdentity
-		bucketIdentity.MetricField = fmt.Sprintf("bucket_%d", index)
-		bucketIdentities[index] = bucketIdentity
+// start restores the tracking state checkpointed by a previous run, when a storage is configured.
+func (ctdp *cumulativeToDeltaProcessor) start(ctx context.Context, host component.Host) error {
+	if ctdp.config.Storage == nil {
+		return nil
 	}
 
-	return bucketIdentities
+	checkpoints, err := startStateCheckpoints(ctx, host, ctdp.config.ID(), *ctdp.config.Storage,
+		ctdp.logger, ctdp.deltaCalculator, ctdp.config.CheckpointInterval)
+	if err != nil {
+		return err
+	}
+	ctdp.checkpoints = checkpoints
+	return nil
 }
 
-func (ctdp *cumulativeToDeltaProcessor) shutdown(context.Context) error {
+func (ctdp *cumulativeToDeltaProcessor) shutdown(ctx context.Context) error {
 	ctdp.cancelFunc()
+	if ctdp.checkpoints != nil {
+		return ctdp.checkpoints.Stop(ctx)
+	}
 	return nil
 }
 
@@ -175,32 +195,6 @@ func (ctdp *cumulativeToDeltaProcessor) shouldConvertMetric(metricName string) b
 		(ctdp.excludeFS == nil || !ctdp.excludeFS.Matches(metricName))
 }
 
-func (ctdp *cumulativeToDeltaProcessor) convertHistogramFloatValue(id tracking.MetricIdentity, dp pmetric.HistogramDataPoint, value float64) (tracking.DeltaValue, bool) {
-	id.StartTimestamp = dp.StartTimestamp()
-	id.Attributes = dp.Attributes()
-	trackingPoint := tracking.MetricPoint{
-		Identity: id,
-		Value: tracking.ValuePoint{
-			ObservedTimestamp: dp.Timestamp(),
-			FloatValue:        value,
-		},
-	}
-	return ctdp.deltaCalculator.Convert(trackingPoint)
-}
-
-func (ctdp *cumulativeToDeltaProcessor) convertHistogramIntValue(id tracking.MetricIdentity, dp pmetric.HistogramDataPoint, value int64) (tracking.DeltaValue, bool) {
-	id.StartTimestamp = dp.StartTimestamp()
-	id.Attributes = dp.Attributes()
-	trackingPoint := tracking.MetricPoint{
-		Identity: id,
-		Value: tracking.ValuePoint{
-			ObservedTimestamp: dp.Timestamp(),
-			IntValue:          value,
-		},
-	}
-	return ctdp.deltaCalculator.Convert(trackingPoint)
-}
-
 func (ctdp *cumulativeToDeltaProcessor) convertDataPoints(in interface{}, baseIdentity tracking.MetricIdentity) {
 
 	if dps, ok := in.(pmetric.NumberDataPointSlice); ok {
@@ -244,41 +238,81 @@ func (ctdp *cumulativeToDeltaProcessor) convertDataPoints(in interface{}, baseId
 	}
 }
 
-func (ctdp *cumulativeToDeltaProcessor) convertHistogramDataPoints(in interface{}, baseIdentities *tracking.HistogramIdentities) {
-
-	if dps, ok := in.(pmetric.HistogramDataPointSlice); ok {
-		dps.RemoveIf(func(dp pmetric.HistogramDataPoint) bool {
-			countID := baseIdentities.CountIdentity
-			countDelta, countValid := ctdp.convertHistogramIntValue(countID, dp, int64(dp.Count()))
-
-			hasSum := dp.HasSum() && !math.IsNaN(dp.Sum())
-			sumDelta, sumValid := tracking.DeltaValue{}, true
-
-			if hasSum {
-				sumID := baseIdentities.SumIdentity
-				sumDelta, sumValid = ctdp.convertHistogramFloatValue(sumID, dp, dp.Sum())
-			}
-
-			bucketsValid := true
-			rawBucketCounts := dp.BucketCounts().AsRaw()
-			for index := 0; index < len(rawBucketCounts); index++ {
-				bucketID := baseIdentities.BucketIdentities[index]
-				bucketDelta, bucketValid := ctdp.convertHistogramIntValue(bucketID, dp, int64(rawBucketCounts[index]))
-				rawBucketCounts[index] = uint64(bucketDelta.IntValue)
-				bucketsValid = bucketsValid && bucketValid
-			}
-
-			if countValid && sumValid && bucketsValid {
-				dp.SetStartTimestamp(countDelta.StartTimestamp)
-				dp.SetCount(uint64(countDelta.IntValue))
-				if hasSum {
-					dp.SetSum(sumDelta.FloatValue)
-				}
-				dp.SetBucketCounts(pcommon.NewImmutableUInt64Slice(rawBucketCounts))
-				return false
-			}
+func (ctdp *cumulativeToDeltaProcessor) convertHistogramDataPoints(dps pmetric.HistogramDataPointSlice, baseIdentity tracking.MetricIdentity) {
+	dps.RemoveIf(func(dp pmetric.HistogramDataPoint) bool {
+		id := baseIdentity
+		id.StartTimestamp = dp.StartTimestamp()
+		id.Attributes = dp.Attributes()
+		value := &tracking.HistogramPoint{
+			Count:          dp.Count(),
+			ExplicitBounds: dp.ExplicitBounds().AsRaw(),
+			Buckets:        dp.BucketCounts().AsRaw(),
+		}
+		if dp.HasSum() {
+			value.Sum = dp.Sum()
+		}
+		trackingPoint := tracking.MetricPoint{
+			Identity: id,
+			Value: tracking.ValuePoint{
+				ObservedTimestamp: dp.Timestamp(),
+				HistogramValue:    value,
+			},
+		}
+		delta, valid := ctdp.deltaCalculator.Convert(trackingPoint)
+		if !valid {
+			return true
+		}
+
+		dp.SetStartTimestamp(delta.StartTimestamp)
+		dp.SetCount(delta.HistogramValue.Count)
+		if dp.HasSum() {
+			dp.SetSum(delta.HistogramValue.Sum)
+		}
+		dp.SetBucketCounts(pcommon.NewImmutableUInt64Slice(delta.HistogramValue.Buckets))
+		return false
+	})
+}
 
+func (ctdp *cumulativeToDeltaProcessor) convertExpHistogramDataPoints(dps pmetric.ExponentialHistogramDataPointSlice, baseIdentity tracking.MetricIdentity) {
+	dps.RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool {
+		id := baseIdentity
+		id.StartTimestamp = dp.StartTimestamp()
+		id.Attributes = dp.Attributes()
+		trackingPoint := tracking.MetricPoint{
+			Identity: id,
+			Value: tracking.ValuePoint{
+				ObservedTimestamp: dp.Timestamp(),
+				ExpHistogramValue: &tracking.ExpHistogramPoint{
+					Count:     dp.Count(),
+					Sum:       dp.Sum(),
+					Scale:     dp.Scale(),
+					ZeroCount: dp.ZeroCount(),
+					Positive: tracking.ExpBuckets{
+						Offset: dp.Positive().Offset(),
+						Counts: dp.Positive().BucketCounts().AsRaw(),
+					},
+					Negative: tracking.ExpBuckets{
+						Offset: dp.Negative().Offset(),
+						Counts: dp.Negative().BucketCounts().AsRaw(),
+					},
+				},
+			},
+		}
+		delta, valid := ctdp.deltaCalculator.Convert(trackingPoint)
+		if !valid {
 			return true
-		})
-	}
+		}
+
+		value := delta.ExpHistogramValue
+		dp.SetStartTimestamp(delta.StartTimestamp)
+		dp.SetCount(value.Count)
+		dp.SetSum(value.Sum)
+		dp.SetScale(value.Scale)
+		dp.SetZeroCount(value.ZeroCount)
+		dp.Positive().SetOffset(value.Positive.Offset)
+		dp.Positive().SetBucketCounts(pcommon.NewImmutableUInt64Slice(value.Positive.Counts))
+		dp.Negative().SetOffset(value.Negative.Offset)
+		dp.Negative().SetBucketCounts(pcommon.NewImmutableUInt64Slice(value.Negative.Counts))
+		return false
+	})
 }
@@ -44,10 +44,22 @@ type testHistogramMetric struct {
 	metricNames   []string
 	metricCounts  [][]uint64
 	metricSums    [][]float64
+	metricBounds  [][][]float64
 	metricBuckets [][][]uint64
 	isCumulative  []bool
 }
 
+type testExpHistogramMetric struct {
+	metricNames     []string
+	metricCounts    [][]uint64
+	metricSums      [][]float64
+	metricScales    [][]int32
+	metricOffsets   [][]int32
+	metricBuckets   [][][]uint64
+	metricZeroCount [][]uint64
+	isCumulative    []bool
+}
+
 type cumulativeToDeltaTest struct {
 	name                    string
 	include                 MatchMetrics
@@ -270,6 +282,157 @@ var (
 			}),
 			histogramSupportEnabled: false,
 		},
+		{
+			name: "cumulative_to_delta_histogram_reset",
+			inMetrics: generateTestHistogramMetrics(testHistogramMetric{
+				metricNames:  []string{"metric_1"},
+				metricCounts: [][]uint64{{100, 200, 20, 50}},
+				metricSums:   [][]float64{{100, 200, 20, 50}},
+				metricBuckets: [][][]uint64{
+					{{50, 25, 25}, {100, 50, 50}, {10, 5, 5}, {25, 15, 10}},
+				},
+				isCumulative: []bool{true},
+			}),
+			outMetrics: generateTestHistogramMetrics(testHistogramMetric{
+				metricNames:  []string{"metric_1"},
+				metricCounts: [][]uint64{{100, 100, 20, 30}},
+				metricSums:   [][]float64{{100, 100, 20, 30}},
+				metricBuckets: [][][]uint64{
+					{{50, 25, 25}, {50, 25, 25}, {10, 5, 5}, {15, 10, 5}},
+				},
+				isCumulative: []bool{false},
+			}),
+			histogramSupportEnabled: true,
+		},
+		{
+			name: "cumulative_to_delta_histogram_bucket_reset",
+			inMetrics: generateTestHistogramMetrics(testHistogramMetric{
+				metricNames:  []string{"metric_1"},
+				metricCounts: [][]uint64{{100, 200}},
+				metricSums:   [][]float64{{100, 200}},
+				metricBuckets: [][][]uint64{
+					{{50, 25, 25}, {40, 80, 80}},
+				},
+				isCumulative: []bool{true},
+			}),
+			outMetrics: generateTestHistogramMetrics(testHistogramMetric{
+				metricNames:  []string{"metric_1"},
+				metricCounts: [][]uint64{{100, 200}},
+				metricSums:   [][]float64{{100, 200}},
+				metricBuckets: [][][]uint64{
+					{{50, 25, 25}, {40, 80, 80}},
+				},
+				isCumulative: []bool{false},
+			}),
+			histogramSupportEnabled: true,
+		},
+		{
+			name: "cumulative_to_delta_histogram_bounds_changed",
+			inMetrics: generateTestHistogramMetrics(testHistogramMetric{
+				metricNames:  []string{"metric_1"},
+				metricCounts: [][]uint64{{100, 200, 300}},
+				metricSums:   [][]float64{{100, 200, 300}},
+				metricBounds: [][][]float64{
+					{{1, 10}, {1, 5, 10}, {1, 5, 10}},
+				},
+				metricBuckets: [][][]uint64{
+					{{50, 25, 25}, {100, 50, 25, 25}, {150, 50, 50, 50}},
+				},
+				isCumulative: []bool{true},
+			}),
+			outMetrics: generateTestHistogramMetrics(testHistogramMetric{
+				metricNames:  []string{"metric_1"},
+				metricCounts: [][]uint64{{100, 200, 100}},
+				metricSums:   [][]float64{{100, 200, 100}},
+				metricBounds: [][][]float64{
+					{{1, 10}, {1, 5, 10}, {1, 5, 10}},
+				},
+				metricBuckets: [][][]uint64{
+					{{50, 25, 25}, {100, 50, 25, 25}, {50, 0, 25, 25}},
+				},
+				isCumulative: []bool{false},
+			}),
+			histogramSupportEnabled: true,
+		},
+		{
+			name: "cumulative_to_delta_exponential_histogram",
+			include: MatchMetrics{
+				Metrics: []string{"metric_1"},
+				Config: filterset.Config{
+					MatchType:    "strict",
+					RegexpConfig: nil,
+				},
+			},
+			inMetrics: generateTestExpHistogramMetrics(testExpHistogramMetric{
+				metricNames:     []string{"metric_1", "metric_2"},
+				metricCounts:    [][]uint64{{10, 25, 45}, {4}},
+				metricSums:      [][]float64{{100, 250, 450}, {4}},
+				metricScales:    [][]int32{{1, 1, 0}, {1}},
+				metricOffsets:   [][]int32{{2, 1, 0}, {0}},
+				metricBuckets:   [][][]uint64{{{4, 6}, {5, 8, 10}, {15, 25}}, {{4}}},
+				metricZeroCount: [][]uint64{{0, 2, 5}, {0}},
+				isCumulative:    []bool{true, true},
+			}),
+			outMetrics: generateTestExpHistogramMetrics(testExpHistogramMetric{
+				metricNames:     []string{"metric_1", "metric_2"},
+				metricCounts:    [][]uint64{{10, 15, 20}, {4}},
+				metricSums:      [][]float64{{100, 150, 200}, {4}},
+				metricScales:    [][]int32{{1, 1, 0}, {1}},
+				metricOffsets:   [][]int32{{2, 1, 0}, {0}},
+				metricBuckets:   [][][]uint64{{{4, 6}, {5, 4, 4}, {10, 7}}, {{4}}},
+				metricZeroCount: [][]uint64{{0, 2, 3}, {0}},
+				isCumulative:    []bool{false, true},
+			}),
+			histogramSupportEnabled: true,
+		},
+		{
+			name: "cumulative_to_delta_exponential_histogram_reset",
+			inMetrics: generateTestExpHistogramMetrics(testExpHistogramMetric{
+				metricNames:     []string{"metric_1"},
+				metricCounts:    [][]uint64{{10, 20, 4}},
+				metricSums:      [][]float64{{100, 200, 40}},
+				metricScales:    [][]int32{{0, 0, 1}},
+				metricOffsets:   [][]int32{{0, 0, 0}},
+				metricBuckets:   [][][]uint64{{{4, 6}, {8, 12}, {1, 3}}},
+				metricZeroCount: [][]uint64{{0, 0, 0}},
+				isCumulative:    []bool{true},
+			}),
+			outMetrics: generateTestExpHistogramMetrics(testExpHistogramMetric{
+				metricNames:     []string{"metric_1"},
+				metricCounts:    [][]uint64{{10, 10, 4}},
+				metricSums:      [][]float64{{100, 100, 40}},
+				metricScales:    [][]int32{{0, 0, 1}},
+				metricOffsets:   [][]int32{{0, 0, 0}},
+				metricBuckets:   [][][]uint64{{{4, 6}, {4, 6}, {1, 3}}},
+				metricZeroCount: [][]uint64{{0, 0, 0}},
+				isCumulative:    []bool{false},
+			}),
+			histogramSupportEnabled: true,
+		},
+		{
+			name: "cumulative_to_delta_exponential_histogram_ignored_without_feature",
+			inMetrics: generateTestExpHistogramMetrics(testExpHistogramMetric{
+				metricNames:     []string{"metric_1"},
+				metricCounts:    [][]uint64{{10, 20}},
+				metricSums:      [][]float64{{100, 200}},
+				metricScales:    [][]int32{{0, 0}},
+				metricOffsets:   [][]int32{{0, 0}},
+				metricBuckets:   [][][]uint64{{{4, 6}, {8, 12}}},
+				metricZeroCount: [][]uint64{{0, 0}},
+				isCumulative:    []bool{true},
+			}),
+			outMetrics: generateTestExpHistogramMetrics(testExpHistogramMetric{
+				metricNames:     []string{"metric_1"},
+				metricCounts:    [][]uint64{{10, 20}},
+				metricSums:      [][]float64{{100, 200}},
+				metricScales:    [][]int32{{0, 0}},
+				metricOffsets:   [][]int32{{0, 0}},
+				metricBuckets:   [][][]uint64{{{4, 6}, {8, 12}}},
+				metricZeroCount: [][]uint64{{0, 0}},
+				isCumulative:    []bool{true},
+			}),
+			histogramSupportEnabled: false,
+		},
 	}
 )
 
@@ -362,6 +525,24 @@ func TestCumulativeToDeltaProcessor(t *testing.T) {
 							require.Equal(t, eDataPoints.At(j).Sum(), aDataPoints.At(j).Sum())
 						}
 						require.Equal(t, eDataPoints.At(j).BucketCounts().AsRaw(), aDataPoints.At(j).BucketCounts().AsRaw())
+						require.Equal(t, eDataPoints.At(j).ExplicitBounds().AsRaw(), aDataPoints.At(j).ExplicitBounds().AsRaw())
+					}
+				}
+
+				if eM.DataType() == pmetric.MetricDataTypeExponentialHistogram {
+					eDataPoints := eM.ExponentialHistogram().DataPoints()
+					aDataPoints := aM.ExponentialHistogram().DataPoints()
+
+					require.Equal(t, eDataPoints.Len(), aDataPoints.Len())
+					require.Equal(t, eM.ExponentialHistogram().AggregationTemporality(), aM.ExponentialHistogram().AggregationTemporality())
+
+					for j := 0; j < eDataPoints.Len(); j++ {
+						require.Equal(t, eDataPoints.At(j).Count(), aDataPoints.At(j).Count())
+						require.Equal(t, eDataPoints.At(j).Sum(), aDataPoints.At(j).Sum())
+						require.Equal(t, eDataPoints.At(j).Scale(), aDataPoints.At(j).Scale())
+						require.Equal(t, eDataPoints.At(j).ZeroCount(), aDataPoints.At(j).ZeroCount())
+						require.Equal(t, eDataPoints.At(j).Positive().Offset(), aDataPoints.At(j).Positive().Offset())
+						require.Equal(t, eDataPoints.At(j).Positive().BucketCounts().AsRaw(), aDataPoints.At(j).Positive().BucketCounts().AsRaw())
 					}
 				}
 			}
@@ -430,6 +611,43 @@ func generateTestHistogramMetrics(tm testHistogramMetric) pmetric.Metrics {
 				dp.SetSum(sums[index])
 			}
 			dp.SetBucketCounts(pcommon.NewImmutableUInt64Slice(tm.metricBuckets[i][index]))
+			if len(tm.metricBounds) > 0 {
+				dp.SetExplicitBounds(pcommon.NewImmutableFloat64Slice(tm.metricBounds[i][index]))
+			}
+		}
+	}
+
+	return md
+}
+
+func generateTestExpHistogramMetrics(tm testExpHistogramMetric) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	now := time.Now()
+
+	rm := md.ResourceMetrics().AppendEmpty()
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+	for i, name := range tm.metricNames {
+		m := ms.AppendEmpty()
+		m.SetName(name)
+		m.SetDataType(pmetric.MetricDataTypeExponentialHistogram)
+
+		hist := m.ExponentialHistogram()
+
+		if tm.isCumulative[i] {
+			hist.SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+		} else {
+			hist.SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+		}
+
+		for index, count := range tm.metricCounts[i] {
+			dp := hist.DataPoints().AppendEmpty()
+			dp.SetTimestamp(pcommon.NewTimestampFromTime(now.Add(10 * time.Second)))
+			dp.SetCount(count)
+			dp.SetSum(tm.metricSums[i][index])
+			dp.SetScale(tm.metricScales[i][index])
+			dp.SetZeroCount(tm.metricZeroCount[i][index])
+			dp.Positive().SetOffset(tm.metricOffsets[i][index])
+			dp.Positive().SetBucketCounts(pcommon.NewImmutableUInt64Slice(tm.metricBuckets[i][index]))
 		}
 	}
 
@@ -15,6 +15,8 @@
 package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"go.opentelemetry.io/collector/config"
@@ -36,15 +38,62 @@ type Config struct {
 	// Default: 1s.
 	WaitDuration time.Duration `mapstructure:"wait_duration"`
 
-	// DiscardOrphans instructs the processor to discard traces without the root span.
+	// DiscardOrphans instructs the processor to discard traces without the root span, instead of releasing them.
 	// This typically indicates that the trace is incomplete.
 	// Default: false.
-	// Not yet implemented, and an error will be returned when this option is used.
 	DiscardOrphans bool `mapstructure:"discard_orphans"`
 
-	// StoreOnDisk tells the processor to keep only the trace ID in memory, serializing the trace spans to disk.
-	// Useful when the duration to wait for traces to complete is high.
+	// LateSpans tells the processor what to do with spans arriving after their trace has been released.
+	// Default: group.
+	LateSpans LateSpansAction `mapstructure:"late_spans"`
+
+	// NumReleasedTraces is the max number of released trace IDs to remember, used to detect late spans.
+	// Only used when LateSpans is "drop" or "forward".
+	// Default: 100_000.
+	NumReleasedTraces int `mapstructure:"num_released_traces"`
+
+	// StoreOnDisk tells the processor to keep only the trace ID in memory, serializing the trace spans to disk
+	// by means of a storage extension, like the file_storage. Traces that haven't been released yet are restored
+	// when the collector restarts. Useful when the duration to wait for traces to complete is high.
 	// Default: false.
-	// Not yet implemented, and an error will be returned when this option is used.
 	StoreOnDisk bool `mapstructure:"store_on_disk"`
+
+	// StorageID is the ID of the storage extension to use when StoreOnDisk is enabled. When not set,
+	// the only storage extension configured in the collector is used.
+	StorageID *config.ComponentID `mapstructure:"storage"`
+}
+
+// LateSpansAction is the action to take for spans arriving after their trace has been released.
+type LateSpansAction string
+
+const (
+	// LateSpansGroup waits for the duration again, grouping the late spans into a new trace.
+	LateSpansGroup LateSpansAction = "group"
+	// LateSpansDrop discards the late spans.
+	LateSpansDrop LateSpansAction = "drop"
+	// LateSpansForward sends the late spans to the next consumer right away.
+	LateSpansForward LateSpansAction = "forward"
+)
+
+var errInvalidNumReleasedTraces = errors.New("'num_released_traces' must be positive when late spans are dropped or forwarded")
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	switch cfg.LateSpans {
+	case "", LateSpansGroup:
+		return nil
+	case LateSpansDrop, LateSpansForward:
+		if cfg.NumReleasedTraces <= 0 {
+			return errInvalidNumReleasedTraces
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid 'late_spans' action %q, must be one of %q, %q or %q",
+			cfg.LateSpans, LateSpansGroup, LateSpansDrop, LateSpansForward)
+	}
+}
+
+// detectsLateSpans returns whether the processor has to remember released traces to act on late spans.
+func (cfg *Config) detectsLateSpans() bool {
+	return cfg.LateSpans == LateSpansDrop || cfg.LateSpans == LateSpansForward
 }
@@ -318,6 +318,9 @@ type eventMachineWorker struct {
 	// the ring buffer holds the IDs for all the in-flight traces
 	buffer *ringBuffer
 
+	// the ring buffer holding the IDs of the recently released traces, nil when late spans aren't detected
+	released *ringBuffer
+
 	events chan event
 }
 
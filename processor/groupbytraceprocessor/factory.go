@@ -16,7 +16,6 @@ package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"go.opencensus.io/stats/view"
@@ -31,16 +30,13 @@ const (
 	// The stability level of the processor.
 	stability = component.StabilityLevelBeta
 
-	defaultWaitDuration   = time.Second
-	defaultNumTraces      = 1_000_000
-	defaultNumWorkers     = 1
-	defaultDiscardOrphans = false
-	defaultStoreOnDisk    = false
-)
-
-var (
-	errDiskStorageNotSupported    = fmt.Errorf("option 'disk storage' not supported in this release")
-	errDiscardOrphansNotSupported = fmt.Errorf("option 'discard orphans' not supported in this release")
+	defaultWaitDuration      = time.Second
+	defaultNumTraces         = 1_000_000
+	defaultNumWorkers        = 1
+	defaultDiscardOrphans    = false
+	defaultStoreOnDisk       = false
+	defaultLateSpans         = LateSpansGroup
+	defaultNumReleasedTraces = 100_000
 )
 
 // NewFactory returns a new factory for the Filter processor.
@@ -61,10 +57,10 @@ func createDefaultConfig() config.Processor {
 		NumTraces:         defaultNumTraces,
 		NumWorkers:        defaultNumWorkers,
 		WaitDuration:      defaultWaitDuration,
-
-		// not supported for now
-		DiscardOrphans: defaultDiscardOrphans,
-		StoreOnDisk:    defaultStoreOnDisk,
+		StoreOnDisk:       defaultStoreOnDisk,
+		DiscardOrphans:    defaultDiscardOrphans,
+		LateSpans:         defaultLateSpans,
+		NumReleasedTraces: defaultNumReleasedTraces,
 	}
 }
 
@@ -79,14 +75,10 @@ func createTracesProcessor(
 
 	var st storage
 	if oCfg.StoreOnDisk {
-		return nil, errDiskStorageNotSupported
+		st = newDiskStorage(oCfg.ID(), oCfg.StorageID)
+	} else {
+		st = newMemoryStorage()
 	}
-	if oCfg.DiscardOrphans {
-		return nil, errDiscardOrphansNotSupported
-	}
-
-	// the only supported storage for now
-	st = newMemoryStorage()
 
 	return newGroupByTraceProcessor(params.Logger, st, nextConsumer, *oCfg), nil
 }
@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 )
 
@@ -32,6 +33,9 @@ func TestDefaultConfiguration(t *testing.T) {
 	assert.Equal(t, defaultWaitDuration, c.WaitDuration)
 	assert.Equal(t, defaultDiscardOrphans, c.DiscardOrphans)
 	assert.Equal(t, defaultStoreOnDisk, c.StoreOnDisk)
+	assert.Equal(t, defaultLateSpans, c.LateSpans)
+	assert.Equal(t, defaultNumReleasedTraces, c.NumReleasedTraces)
+	assert.NoError(t, c.Validate())
 }
 
 func TestCreateTestProcessor(t *testing.T) {
@@ -47,33 +51,47 @@ func TestCreateTestProcessor(t *testing.T) {
 	assert.NotNil(t, p)
 }
 
-func TestCreateTestProcessorWithNotImplementedOptions(t *testing.T) {
-	// prepare
-	f := NewFactory()
+func TestCreateTestProcessorWithDiskStorage(t *testing.T) {
+	c := createDefaultConfig().(*Config)
+	c.StoreOnDisk = true
+
 	next := &mockProcessor{}
 
 	// test
+	p, err := createTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), c, next)
+
+	// verify
+	require.NoError(t, err)
+	gp, ok := p.(*groupByTraceProcessor)
+	require.True(t, ok)
+	assert.IsType(t, &diskStorage{}, gp.st)
+}
+
+func TestValidateLateSpans(t *testing.T) {
 	for _, tt := range []struct {
-		config      *Config
-		expectedErr error
+		name              string
+		lateSpans         LateSpansAction
+		numReleasedTraces int
+		expectErr         bool
 	}{
-		{
-			&Config{
-				DiscardOrphans: true,
-			},
-			errDiscardOrphansNotSupported,
-		},
-		{
-			&Config{
-				StoreOnDisk: true,
-			},
-			errDiskStorageNotSupported,
-		},
+		{name: "empty", lateSpans: ""},
+		{name: "group", lateSpans: LateSpansGroup},
+		{name: "drop", lateSpans: LateSpansDrop, numReleasedTraces: 10},
+		{name: "forward", lateSpans: LateSpansForward, numReleasedTraces: 10},
+		{name: "forward without released traces", lateSpans: LateSpansForward, expectErr: true},
+		{name: "unknown", lateSpans: "unknown", numReleasedTraces: 10, expectErr: true},
 	} {
-		p, err := f.CreateTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), tt.config, next)
+		t.Run(tt.name, func(t *testing.T) {
+			c := createDefaultConfig().(*Config)
+			c.LateSpans = tt.lateSpans
+			c.NumReleasedTraces = tt.numReleasedTraces
 
-		// verify
-		assert.Error(t, tt.expectedErr, err)
-		assert.Nil(t, p)
+			err := c.Validate()
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
 	}
 }
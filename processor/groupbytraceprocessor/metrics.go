@@ -30,6 +30,10 @@ var (
 	mReleasedTraces     = stats.Int64("processor_groupbytrace_traces_released", "Traces released to the next consumer", stats.UnitDimensionless)
 	mIncompleteReleases = stats.Int64("processor_groupbytrace_incomplete_releases", "Releases that are suspected to have been incomplete", stats.UnitDimensionless)
 	mEventLatency       = stats.Int64("processor_groupbytrace_event_latency", "How long the queue events are taking to be processed", stats.UnitMilliseconds)
+	mOrphansDiscarded   = stats.Int64("processor_groupbytrace_orphan_traces_discarded", "Traces discarded for not having a root span", stats.UnitDimensionless)
+	mLateSpans          = stats.Int64("processor_groupbytrace_late_spans", "Spans received after their trace had been released", stats.UnitDimensionless)
+
+	tagLateSpansAction = tag.MustNewKey("action")
 )
 
 // MetricViews return the metrics views according to given telemetry level.
@@ -87,5 +91,20 @@ func MetricViews() []*view.View {
 			},
 			Aggregation: view.Distribution(0, 5, 10, 20, 50, 100, 200, 500, 1000),
 		},
+		{
+			Name:        obsreport.BuildProcessorCustomMetricName(string(typeStr), mOrphansDiscarded.Name()),
+			Measure:     mOrphansDiscarded,
+			Description: mOrphansDiscarded.Description(),
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        obsreport.BuildProcessorCustomMetricName(string(typeStr), mLateSpans.Name()),
+			Measure:     mLateSpans,
+			Description: mLateSpans.Description(),
+			TagKeys: []tag.Key{
+				tagLateSpansAction,
+			},
+			Aggregation: view.Sum(),
+		},
 	}
 }
@@ -30,6 +30,8 @@ func TestProcessorMetrics(t *testing.T) {
 		"processor/groupbytrace/processor_groupbytrace_traces_released",
 		"processor/groupbytrace/processor_groupbytrace_incomplete_releases",
 		"processor/groupbytrace/processor_groupbytrace_event_latency",
+		"processor/groupbytrace/processor_groupbytrace_orphan_traces_discarded",
+		"processor/groupbytrace/processor_groupbytrace_late_spans",
 	}
 
 	views := MetricViews()
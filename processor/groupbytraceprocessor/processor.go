@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/pcommon"
@@ -62,6 +63,15 @@ const bufferSize = 10_000
 func newGroupByTraceProcessor(logger *zap.Logger, st storage, nextConsumer consumer.Traces, config Config) *groupByTraceProcessor {
 	// the event machine will buffer up to N concurrent events before blocking
 	eventMachine := newEventMachine(logger, 10000, config.NumWorkers, config.NumTraces)
+	if config.detectsLateSpans() {
+		size := config.NumReleasedTraces / config.NumWorkers
+		if size < 1 {
+			size = 1
+		}
+		for _, worker := range eventMachine.workers {
+			worker.released = newRingBuffer(size)
+		}
+	}
 
 	sp := &groupByTraceProcessor{
 		logger:       logger,
@@ -93,14 +103,28 @@ func (sp *groupByTraceProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Start is invoked during service startup.
-func (sp *groupByTraceProcessor) Start(context.Context, component.Host) error {
+func (sp *groupByTraceProcessor) Start(ctx context.Context, host component.Host) error {
 	// start these metrics, as it might take a while for them to receive their first event
 	stats.Record(context.Background(), mTracesEvicted.M(0))
 	stats.Record(context.Background(), mIncompleteReleases.M(0))
+	stats.Record(context.Background(), mOrphansDiscarded.M(0))
 	stats.Record(context.Background(), mNumTracesConf.M(int64(sp.config.NumTraces)))
 
 	sp.eventMachine.startInBackground()
-	return sp.st.start()
+	if err := sp.st.start(ctx, host); err != nil {
+		return err
+	}
+
+	// traces left in the storage by a previous run wait for the whole duration again before being released
+	restored, err := sp.st.restore()
+	if err != nil {
+		return fmt.Errorf("couldn't restore traces from the storage: %w", err)
+	}
+	var errs error
+	for _, td := range restored {
+		errs = multierr.Append(errs, sp.eventMachine.consume(td))
+	}
+	return errs
 }
 
 // Shutdown is invoked during service shutdown.
@@ -111,6 +135,11 @@ func (sp *groupByTraceProcessor) Shutdown(_ context.Context) error {
 
 func (sp *groupByTraceProcessor) onTraceReceived(trace tracesWithID, worker *eventMachineWorker) error {
 	traceID := trace.id
+	if worker.released != nil && worker.released.contains(traceID) {
+		// the trace has been released already, these spans arrived too late to be grouped with it
+		return sp.onLateSpans(traceID, trace.td)
+	}
+
 	if worker.buffer.contains(traceID) {
 		sp.logger.Debug("trace is already in memory storage")
 
@@ -175,6 +204,11 @@ func (sp *groupByTraceProcessor) onTraceExpired(traceID pcommon.TraceID, worker
 	// delete from the map and erase its memory entry
 	worker.buffer.delete(traceID)
 
+	// remember the released trace, so that spans arriving later can be told apart
+	if worker.released != nil {
+		worker.released.put(traceID)
+	}
+
 	// this might block, but we don't need to wait
 	sp.logger.Debug("marking the trace as released",
 		zap.String("traceID", traceID.HexString()))
@@ -217,6 +251,13 @@ func (sp *groupByTraceProcessor) onTraceReleased(rss []ptrace.ResourceSpans) err
 		trs := trace.ResourceSpans().AppendEmpty()
 		rs.CopyTo(trs)
 	}
+
+	if sp.config.DiscardOrphans && !hasRootSpan(trace) {
+		sp.logger.Debug("discarding trace without a root span")
+		stats.Record(context.Background(), mOrphansDiscarded.M(1))
+		return nil
+	}
+
 	stats.Record(context.Background(),
 		mReleasedSpans.M(int64(trace.SpanCount())),
 		mReleasedTraces.M(1),
@@ -231,6 +272,24 @@ func (sp *groupByTraceProcessor) onTraceReleased(rss []ptrace.ResourceSpans) err
 	return nil
 }
 
+func (sp *groupByTraceProcessor) onLateSpans(traceID pcommon.TraceID, td ptrace.Traces) error {
+	ctx, _ := tag.New(context.Background(), tag.Upsert(tagLateSpansAction, string(sp.config.LateSpans)))
+	stats.Record(ctx, mLateSpans.M(int64(td.SpanCount())))
+
+	if sp.config.LateSpans == LateSpansDrop {
+		sp.logger.Debug("dropping late spans", zap.String("traceID", traceID.HexString()))
+		return nil
+	}
+
+	sp.logger.Debug("forwarding late spans", zap.String("traceID", traceID.HexString()))
+	go func() {
+		if err := sp.nextConsumer.ConsumeTraces(context.Background(), td); err != nil {
+			sp.logger.Error("consume failed", zap.Error(err))
+		}
+	}()
+	return nil
+}
+
 func (sp *groupByTraceProcessor) onTraceRemoved(traceID pcommon.TraceID) error {
 	trace, err := sp.st.delete(traceID)
 	if err != nil {
@@ -248,3 +307,19 @@ func (sp *groupByTraceProcessor) addSpans(traceID pcommon.TraceID, trace ptrace.
 	sp.logger.Debug("creating trace at the storage", zap.String("traceID", traceID.HexString()))
 	return sp.st.createOrAppend(traceID, trace)
 }
+
+func hasRootSpan(td ptrace.Traces) bool {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				if spans.At(k).ParentSpanID().IsEmpty() {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -555,6 +555,133 @@ func TestAsyncOnRelease(t *testing.T) {
 	close(blockCh)
 }
 
+func TestDiscardOrphans(t *testing.T) {
+	for _, tt := range []struct {
+		name            string
+		discardOrphans  bool
+		orphan          bool
+		expectedRelease bool
+	}{
+		{name: "orphan is discarded", discardOrphans: true, orphan: true, expectedRelease: false},
+		{name: "complete trace is released", discardOrphans: true, orphan: false, expectedRelease: true},
+		{name: "orphan is released when not discarding", discardOrphans: false, orphan: true, expectedRelease: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// prepare
+			trace := simpleTraces()
+			if tt.orphan {
+				trace.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetParentSpanID(pcommon.NewSpanID([8]byte{1, 2, 3, 4}))
+			}
+
+			received := make(chan ptrace.Traces, 1)
+			sp := &groupByTraceProcessor{
+				logger: zap.NewNop(),
+				config: Config{DiscardOrphans: tt.discardOrphans},
+				nextConsumer: &mockProcessor{onTraces: func(_ context.Context, td ptrace.Traces) error {
+					received <- td
+					return nil
+				}},
+			}
+
+			// test
+			require.NoError(t, sp.onTraceReleased([]ptrace.ResourceSpans{trace.ResourceSpans().At(0)}))
+
+			// verify
+			select {
+			case <-received:
+				assert.True(t, tt.expectedRelease, "the trace should have been discarded")
+			case <-time.After(100 * time.Millisecond):
+				assert.False(t, tt.expectedRelease, "the trace should have been released")
+			}
+		})
+	}
+}
+
+func TestLateSpans(t *testing.T) {
+	for _, tt := range []struct {
+		action           LateSpansAction
+		expectedReceived int
+	}{
+		{action: LateSpansGroup, expectedReceived: 2},
+		{action: LateSpansDrop, expectedReceived: 1},
+		{action: LateSpansForward, expectedReceived: 2},
+	} {
+		t.Run(string(tt.action), func(t *testing.T) {
+			// prepare
+			config := Config{
+				WaitDuration:      20 * time.Millisecond,
+				NumTraces:         10,
+				NumWorkers:        1,
+				LateSpans:         tt.action,
+				NumReleasedTraces: 10,
+			}
+
+			received := 0
+			mockProcessor := &mockProcessor{}
+			mockProcessor.onTraces = func(context.Context, ptrace.Traces) error {
+				received++
+				return nil
+			}
+			numReceived := func() int {
+				mockProcessor.mutex.Lock()
+				defer mockProcessor.mutex.Unlock()
+				return received
+			}
+
+			p := newGroupByTraceProcessor(zap.NewNop(), newMemoryStorage(), mockProcessor, config)
+			ctx := context.Background()
+			assert.NoError(t, p.Start(ctx, nil))
+			defer func() {
+				assert.NoError(t, p.Shutdown(ctx))
+			}()
+
+			// the trace is released once the duration expires
+			assert.NoError(t, p.ConsumeTraces(ctx, simpleTraces()))
+			require.Eventually(t, func() bool {
+				return numReceived() == 1
+			}, time.Second, 10*time.Millisecond)
+
+			// test
+			assert.NoError(t, p.ConsumeTraces(ctx, simpleTraces()))
+
+			// verify
+			if tt.expectedReceived > 1 {
+				assert.Eventually(t, func() bool {
+					return numReceived() == tt.expectedReceived
+				}, time.Second, 10*time.Millisecond)
+			} else {
+				time.Sleep(5 * config.WaitDuration)
+				assert.Equal(t, tt.expectedReceived, numReceived())
+			}
+		})
+	}
+}
+
+func TestLateSpansAreForwardedRightAway(t *testing.T) {
+	// prepare
+	received := make(chan ptrace.Traces, 1)
+	sp := &groupByTraceProcessor{
+		logger: zap.NewNop(),
+		config: Config{LateSpans: LateSpansForward},
+		nextConsumer: &mockProcessor{onTraces: func(_ context.Context, td ptrace.Traces) error {
+			received <- td
+			return nil
+		}},
+	}
+	trace := simpleTraces()
+
+	// test
+	require.NoError(t, sp.onLateSpans(pcommon.NewTraceID([16]byte{1, 2, 3, 4}), trace))
+
+	// verify
+	select {
+	case td := <-received:
+		assert.Equal(t, trace, td)
+	case <-time.After(time.Second):
+		t.Fatal("late spans weren't forwarded")
+	}
+}
+
 func BenchmarkConsumeTracesCompleteOnFirstBatch(b *testing.B) {
 	// prepare
 	config := Config{
@@ -617,6 +744,7 @@ type mockStorage struct {
 	onGet            func(pcommon.TraceID) ([]ptrace.ResourceSpans, error)
 	onDelete         func(pcommon.TraceID) ([]ptrace.ResourceSpans, error)
 	onStart          func() error
+	onRestore        func() ([]ptrace.Traces, error)
 	onShutdown       func() error
 }
 
@@ -640,12 +768,18 @@ func (st *mockStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans,
 	}
 	return nil, nil
 }
-func (st *mockStorage) start() error {
+func (st *mockStorage) start(context.Context, component.Host) error {
 	if st.onStart != nil {
 		return st.onStart()
 	}
 	return nil
 }
+func (st *mockStorage) restore() ([]ptrace.Traces, error) {
+	if st.onRestore != nil {
+		return st.onRestore()
+	}
+	return nil, nil
+}
 func (st *mockStorage) shutdown() error {
 	if st.onShutdown != nil {
 		return st.onShutdown()
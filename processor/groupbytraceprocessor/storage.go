@@ -15,6 +15,9 @@
 package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
 
 import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
@@ -36,7 +39,11 @@ type storage interface {
 	delete(pcommon.TraceID) ([]ptrace.ResourceSpans, error)
 
 	// start gives the storage the opportunity to initialize any resources or procedures
-	start() error
+	start(context.Context, component.Host) error
+
+	// restore returns the traces kept by the storage from a previous run, removing them from the storage,
+	// so that they can be scheduled for release again
+	restore() ([]ptrace.Traces, error)
 
 	// shutdown signals the storage that the processor is shutting down
 	shutdown() error
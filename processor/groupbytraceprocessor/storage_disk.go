@@ -0,0 +1,417 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	extstorage "go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/storageutils"
+)
+
+const (
+	// indexKey is the key under which a snapshot of the IDs of the stored traces is persisted, along with the sequence
+	// number of the first journal entry that isn't part of it yet. The batches of a trace are stored under the hex
+	// representation of its ID followed by the batch number, so this key and the journal keys never clash with them.
+	indexKey = "index"
+	// journalKeyPrefix prefixes the keys of the journal entries, each one recording the creation or the deletion of a trace.
+	journalKeyPrefix = "journal-"
+	// minCompactionLen is the minimum number of journal entries before they are compacted into the index.
+	minCompactionLen = 1024
+
+	journalCreate byte = '+'
+	journalDelete byte = '-'
+)
+
+var (
+	errNoStorageExtension        = errors.New("a storage extension is required when 'store_on_disk' is enabled")
+	errMultipleStorageExtensions = errors.New("multiple storage extensions found, the 'storage' option has to be set")
+	errInvalidIndex              = errors.New("the persisted trace index is invalid")
+	errInvalidJournal            = errors.New("the persisted trace journal is invalid")
+)
+
+// diskStorage keeps only the trace IDs in memory, serializing the spans to a storage extension, like the file_storage.
+// Each batch of spans is stored under its own key, so that appending spans to a trace doesn't rewrite the previous ones.
+// The creation and deletion of the traces are journaled along with their batches, so that the traces can be restored
+// on the next start, even after a crash. Once the journal has as many entries as there are traces, it's compacted into
+// the index, so that keeping track of the stored traces costs a constant amount of writes per trace.
+type diskStorage struct {
+	sync.RWMutex
+	processorID config.ComponentID
+	storageID   *config.ComponentID
+	client      extstorage.Client
+	// batches holds the number of batches stored for each trace
+	batches map[pcommon.TraceID]int
+	// persisted holds the IDs of the traces found in the index and the journal on start, until they are restored
+	persisted []pcommon.TraceID
+	// indexSeq is the sequence number of the first journal entry not compacted into the index,
+	// journalSeq the one of the next journal entry
+	indexSeq    uint64
+	journalSeq  uint64
+	marshaler   ptrace.Marshaler
+	unmarshaler ptrace.Unmarshaler
+}
+
+var _ storage = (*diskStorage)(nil)
+
+func newDiskStorage(processorID config.ComponentID, storageID *config.ComponentID) *diskStorage {
+	return &diskStorage{
+		processorID: processorID,
+		storageID:   storageID,
+		client:      extstorage.NewNopClient(),
+		batches:     make(map[pcommon.TraceID]int),
+		marshaler:   ptrace.NewProtoMarshaler(),
+		unmarshaler: ptrace.NewProtoUnmarshaler(),
+	}
+}
+
+func (st *diskStorage) createOrAppend(traceID pcommon.TraceID, td ptrace.Traces) error {
+	st.Lock()
+	defer st.Unlock()
+
+	bytes, err := st.marshaler.MarshalTraces(td)
+	if err != nil {
+		return fmt.Errorf("failed to serialize trace: %w", err)
+	}
+
+	numBatches, known := st.batches[traceID]
+	st.batches[traceID] = numBatches + 1
+	op := extstorage.SetOperation(batchKey(traceID, numBatches), bytes)
+	if known {
+		err = st.client.Batch(context.Background(), op)
+	} else {
+		err = st.journal(journalCreate, traceID, op)
+	}
+
+	if err != nil {
+		if known {
+			st.batches[traceID] = numBatches
+		} else {
+			delete(st.batches, traceID)
+		}
+		return fmt.Errorf("failed to store trace: %w", err)
+	}
+
+	return nil
+}
+
+func (st *diskStorage) get(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	st.RLock()
+	defer st.RUnlock()
+
+	numBatches, ok := st.batches[traceID]
+	if !ok {
+		return nil, nil
+	}
+
+	td, err := st.load(traceID, numBatches)
+	if err != nil {
+		return nil, err
+	}
+	return resourceSpansFromTraces(td), nil
+}
+
+func (st *diskStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	st.Lock()
+	defer st.Unlock()
+
+	numBatches, ok := st.batches[traceID]
+	if !ok {
+		return nil, nil
+	}
+
+	td, err := st.load(traceID, numBatches)
+	if err != nil {
+		return nil, err
+	}
+
+	delete(st.batches, traceID)
+	ops := make([]extstorage.Operation, 0, numBatches)
+	for i := 0; i < numBatches; i++ {
+		ops = append(ops, extstorage.DeleteOperation(batchKey(traceID, i)))
+	}
+
+	if err := st.journal(journalDelete, traceID, ops...); err != nil {
+		st.batches[traceID] = numBatches
+		return nil, fmt.Errorf("failed to delete trace: %w", err)
+	}
+
+	return resourceSpansFromTraces(td), nil
+}
+
+// start obtains the client from the storage extension and loads the IDs of the traces persisted
+// by a previous run, replaying the journal over the index. Those traces are handed over to the processor by restore.
+func (st *diskStorage) start(ctx context.Context, host component.Host) error {
+	client, err := storageutils.GetClient(ctx, host, st.storageID, component.KindProcessor, st.processorID)
+	switch {
+	case errors.Is(err, storageutils.ErrNoStorageExtension):
+		return errNoStorageExtension
+	case errors.Is(err, storageutils.ErrMultipleStorageExtensions):
+		return errMultipleStorageExtensions
+	case err != nil:
+		return err
+	}
+
+	persisted, indexSeq, journalSeq, err := loadIndex(ctx, client)
+	if err != nil {
+		return multierr.Append(err, client.Close(ctx))
+	}
+
+	st.Lock()
+	defer st.Unlock()
+
+	st.client = client
+	st.persisted = persisted
+	st.indexSeq = indexSeq
+	st.journalSeq = journalSeq
+	return nil
+}
+
+func (st *diskStorage) restore() ([]ptrace.Traces, error) {
+	st.Lock()
+	defer st.Unlock()
+
+	if len(st.persisted) == 0 {
+		return nil, nil
+	}
+
+	var ops []extstorage.Operation
+	restored := make([]ptrace.Traces, 0, len(st.persisted))
+	for _, traceID := range st.persisted {
+		// the number of batches isn't persisted, they are read until one is missing
+		td := ptrace.NewTraces()
+		for i := 0; ; i++ {
+			batch, found, err := st.loadBatch(traceID, i)
+			if err != nil {
+				return nil, err
+			}
+			if !found {
+				break
+			}
+			batch.ResourceSpans().MoveAndAppendTo(td.ResourceSpans())
+			ops = append(ops, extstorage.DeleteOperation(batchKey(traceID, i)))
+		}
+		if td.ResourceSpans().Len() > 0 {
+			restored = append(restored, td)
+		}
+	}
+
+	persisted := st.persisted
+	st.persisted = nil
+	if err := st.compact(ops...); err != nil {
+		st.persisted = persisted
+		return nil, fmt.Errorf("failed to remove the restored traces from the storage: %w", err)
+	}
+
+	return restored, nil
+}
+
+// shutdown compacts the journal and closes the storage client. The traces that haven't been released
+// yet are restored on the next start.
+func (st *diskStorage) shutdown() error {
+	st.Lock()
+	defer st.Unlock()
+
+	var err error
+	if st.journalSeq > st.indexSeq {
+		err = st.compact()
+	}
+	return multierr.Append(err, st.client.Close(context.Background()))
+}
+
+// journal applies the operations on the batches of a trace along with the journal entry recording its creation
+// or deletion. Once the journal has as many entries as there are traces, it's compacted into the index instead.
+// The caller is expected to hold the lock, and to have updated the batches already.
+func (st *diskStorage) journal(change byte, traceID pcommon.TraceID, ops ...extstorage.Operation) error {
+	compactionLen := len(st.batches) + len(st.persisted)
+	if compactionLen < minCompactionLen {
+		compactionLen = minCompactionLen
+	}
+	if st.journalSeq-st.indexSeq >= uint64(compactionLen) {
+		return st.compact(ops...)
+	}
+
+	id := traceID.Bytes()
+	entry := append([]byte{change}, id[:]...)
+	ops = append(ops, extstorage.SetOperation(journalKey(st.journalSeq), entry))
+	if err := st.client.Batch(context.Background(), ops...); err != nil {
+		return err
+	}
+	st.journalSeq++
+	return nil
+}
+
+// compact applies the operations along with a new index of the stored traces, deleting the journal entries the
+// index supersedes. The caller is expected to hold the lock.
+func (st *diskStorage) compact(ops ...extstorage.Operation) error {
+	ops = append(ops, extstorage.SetOperation(indexKey, st.index()))
+	for seq := st.indexSeq; seq < st.journalSeq; seq++ {
+		ops = append(ops, extstorage.DeleteOperation(journalKey(seq)))
+	}
+	if err := st.client.Batch(context.Background(), ops...); err != nil {
+		return err
+	}
+	st.indexSeq = st.journalSeq
+	return nil
+}
+
+// index encodes the sequence number of the next journal entry, followed by the IDs of the stored traces,
+// including the ones not restored yet. The caller is expected to hold the lock.
+func (st *diskStorage) index() []byte {
+	index := make([]byte, 8, 8+(len(st.batches)+len(st.persisted))*16)
+	binary.BigEndian.PutUint64(index, st.journalSeq)
+	for _, traceID := range st.persisted {
+		id := traceID.Bytes()
+		index = append(index, id[:]...)
+	}
+	for traceID := range st.batches {
+		id := traceID.Bytes()
+		index = append(index, id[:]...)
+	}
+	return index
+}
+
+// loadIndex reads the IDs of the traces in the index and replays the journal entries written after it,
+// returning the IDs of the stored traces along with the sequence numbers of the first and the next journal entries.
+func loadIndex(ctx context.Context, client extstorage.Client) ([]pcommon.TraceID, uint64, uint64, error) {
+	index, err := client.Get(ctx, indexKey)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to load the trace index: %w", err)
+	}
+	if index == nil {
+		index = make([]byte, 8)
+	}
+	if len(index) < 8 || (len(index)-8)%16 != 0 {
+		return nil, 0, 0, errInvalidIndex
+	}
+
+	// the order in which the traces were stored is kept, so that they are restored in the same order
+	var order []pcommon.TraceID
+	stored := make(map[pcommon.TraceID]bool)
+	add := func(traceID pcommon.TraceID) {
+		order = append(order, traceID)
+		stored[traceID] = true
+	}
+	for i := 8; i < len(index); i += 16 {
+		add(traceIDFromBytes(index[i : i+16]))
+	}
+
+	indexSeq := binary.BigEndian.Uint64(index)
+	journalSeq := indexSeq
+	for ; ; journalSeq++ {
+		entry, err := client.Get(ctx, journalKey(journalSeq))
+		if err != nil {
+			return nil, 0, 0, fmt.Errorf("failed to load the trace journal: %w", err)
+		}
+		if entry == nil {
+			break
+		}
+		if len(entry) != 17 {
+			return nil, 0, 0, errInvalidJournal
+		}
+		switch entry[0] {
+		case journalCreate:
+			add(traceIDFromBytes(entry[1:]))
+		case journalDelete:
+			delete(stored, traceIDFromBytes(entry[1:]))
+		default:
+			return nil, 0, 0, errInvalidJournal
+		}
+	}
+
+	var persisted []pcommon.TraceID
+	for _, traceID := range order {
+		if stored[traceID] {
+			persisted = append(persisted, traceID)
+			delete(stored, traceID)
+		}
+	}
+	return persisted, indexSeq, journalSeq, nil
+}
+
+// load reads the batches of the trace from the storage. The caller is expected to hold the lock.
+func (st *diskStorage) load(traceID pcommon.TraceID, numBatches int) (ptrace.Traces, error) {
+	td := ptrace.NewTraces()
+	for i := 0; i < numBatches; i++ {
+		batch, found, err := st.loadBatch(traceID, i)
+		if err != nil {
+			return ptrace.Traces{}, err
+		}
+		if !found {
+			// the batch is gone from the storage, which might happen if it was altered externally
+			continue
+		}
+		batch.ResourceSpans().MoveAndAppendTo(td.ResourceSpans())
+	}
+	return td, nil
+}
+
+// loadBatch reads a single batch of the trace from the storage, and returns whether it was found.
+// The caller is expected to hold the lock.
+func (st *diskStorage) loadBatch(traceID pcommon.TraceID, batch int) (ptrace.Traces, bool, error) {
+	bytes, err := st.client.Get(context.Background(), batchKey(traceID, batch))
+	if err != nil {
+		return ptrace.Traces{}, false, fmt.Errorf("failed to load trace: %w", err)
+	}
+	if bytes == nil {
+		return ptrace.Traces{}, false, nil
+	}
+
+	td, err := st.unmarshaler.UnmarshalTraces(bytes)
+	if err != nil {
+		return ptrace.Traces{}, false, fmt.Errorf("failed to deserialize trace: %w", err)
+	}
+	return td, true, nil
+}
+
+// journalKey returns the key under which the journal entry with the given sequence number is stored.
+func journalKey(seq uint64) string {
+	return journalKeyPrefix + strconv.FormatUint(seq, 10)
+}
+
+func traceIDFromBytes(b []byte) pcommon.TraceID {
+	var id [16]byte
+	copy(id[:], b)
+	return pcommon.NewTraceID(id)
+}
+
+// batchKey returns the key under which a batch of spans of the trace is stored.
+func batchKey(traceID pcommon.TraceID, batch int) string {
+	return traceID.HexString() + "-" + strconv.Itoa(batch)
+}
+
+func resourceSpansFromTraces(td ptrace.Traces) []ptrace.ResourceSpans {
+	rss := td.ResourceSpans()
+	if rss.Len() == 0 {
+		return nil
+	}
+
+	result := make([]ptrace.ResourceSpans, 0, rss.Len())
+	for i := 0; i < rss.Len(); i++ {
+		result = append(result, rss.At(i))
+	}
+	return result
+}
@@ -0,0 +1,336 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbytraceprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	extstorage "go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func TestDiskTracesSurviveRestart(t *testing.T) {
+	// prepare
+	dir := t.TempDir()
+	traceID := pcommon.NewTraceID([16]byte{1, 2, 3, 4})
+	trace := simpleTracesWithID(traceID)
+
+	st := newDiskStorage(config.NewComponentID(typeStr), nil)
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", dir)
+	require.NoError(t, st.start(context.Background(), host))
+	require.NoError(t, st.createOrAppend(traceID, trace))
+	require.NoError(t, st.shutdown())
+
+	// test
+	st = newDiskStorage(config.NewComponentID(typeStr), nil)
+	host = storagetest.NewStorageHost().WithFileBackedStorageExtension("test", dir)
+	require.NoError(t, st.start(context.Background(), host))
+	restored, err := st.restore()
+
+	// verify
+	require.NoError(t, err)
+	require.Len(t, restored, 1)
+	assert.Equal(t, trace, restored[0])
+
+	// restored traces are removed from the storage
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	assert.Nil(t, retrieved)
+	require.NoError(t, st.shutdown())
+
+	st = newDiskStorage(config.NewComponentID(typeStr), nil)
+	host = storagetest.NewStorageHost().WithFileBackedStorageExtension("test", dir)
+	require.NoError(t, st.start(context.Background(), host))
+	restored, err = st.restore()
+	require.NoError(t, err)
+	assert.Empty(t, restored)
+}
+
+func TestDiskTracesSurviveCrash(t *testing.T) {
+	// prepare
+	ext := &sharedClientStorage{client: storagetest.NewInMemoryClient(component.KindProcessor, config.NewComponentID(typeStr), "")}
+	host := &sharedClientHost{Host: componenttest.NewNopHost(), ext: ext}
+	traceID := pcommon.NewTraceID([16]byte{1, 2, 3, 4})
+	releasedID := pcommon.NewTraceID([16]byte{2, 3, 4, 5})
+
+	st := newDiskStorage(config.NewComponentID(typeStr), nil)
+	require.NoError(t, st.start(context.Background(), host))
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	require.NoError(t, st.createOrAppend(releasedID, simpleTracesWithID(releasedID)))
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	_, err := st.delete(releasedID)
+	require.NoError(t, err)
+
+	// test: the storage is started again without being shut down
+	st = newDiskStorage(config.NewComponentID(typeStr), nil)
+	require.NoError(t, st.start(context.Background(), host))
+	restored, err := st.restore()
+
+	// verify
+	require.NoError(t, err)
+	require.Len(t, restored, 1)
+	assert.Equal(t, 2, restored[0].SpanCount())
+	assert.Equal(t, traceID, restored[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceID())
+}
+
+func TestDiskStorageExtensionSelection(t *testing.T) {
+	storageID := config.NewComponentIDWithName("test_storage", "second")
+	missingID := config.NewComponentIDWithName("test_storage", "missing")
+	nonStorageID := config.NewComponentIDWithName("non_storage", "other")
+
+	for _, tt := range []struct {
+		name      string
+		host      *storagetest.StorageHost
+		storageID *config.ComponentID
+		expectErr bool
+	}{
+		{
+			name: "single extension",
+			host: storagetest.NewStorageHost().WithInMemoryStorageExtension("first"),
+		},
+		{
+			name:      "configured extension",
+			host:      storagetest.NewStorageHost().WithInMemoryStorageExtension("first").WithInMemoryStorageExtension("second"),
+			storageID: &storageID,
+		},
+		{
+			name:      "no extension",
+			host:      storagetest.NewStorageHost().WithNonStorageExtension("other"),
+			expectErr: true,
+		},
+		{
+			name:      "multiple extensions",
+			host:      storagetest.NewStorageHost().WithInMemoryStorageExtension("first").WithInMemoryStorageExtension("second"),
+			expectErr: true,
+		},
+		{
+			name:      "configured extension is missing",
+			host:      storagetest.NewStorageHost().WithInMemoryStorageExtension("first"),
+			storageID: &missingID,
+			expectErr: true,
+		},
+		{
+			name:      "configured extension is not a storage",
+			host:      storagetest.NewStorageHost().WithNonStorageExtension("other"),
+			storageID: &nonStorageID,
+			expectErr: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newDiskStorage(config.NewComponentID(typeStr), tt.storageID)
+			err := st.start(context.Background(), tt.host)
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDiskStorageRestoredByProcessor(t *testing.T) {
+	// prepare
+	dir := t.TempDir()
+	traceID := pcommon.NewTraceID([16]byte{1, 2, 3, 4})
+
+	st := newDiskStorage(config.NewComponentID(typeStr), nil)
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", dir)
+	require.NoError(t, st.start(context.Background(), host))
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	require.NoError(t, st.shutdown())
+
+	cfg := Config{
+		WaitDuration: time.Millisecond,
+		NumTraces:    10,
+		NumWorkers:   1,
+	}
+	received := make(chan ptrace.Traces, 1)
+	next := &mockProcessor{
+		onTraces: func(_ context.Context, td ptrace.Traces) error {
+			received <- td
+			return nil
+		},
+	}
+
+	st = newDiskStorage(config.NewComponentID(typeStr), nil)
+	p := newGroupByTraceProcessor(zap.NewNop(), st, next, cfg)
+
+	// test
+	require.NoError(t, p.Start(context.Background(), storagetest.NewStorageHost().WithFileBackedStorageExtension("test", dir)))
+	defer func() {
+		assert.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	// verify
+	select {
+	case td := <-received:
+		assert.Equal(t, 1, td.SpanCount())
+	case <-time.After(time.Second):
+		t.Fatal("the restored trace wasn't released")
+	}
+}
+
+func TestDiskStorageJournal(t *testing.T) {
+	// prepare
+	dir := t.TempDir()
+	client := storagetest.NewFileBackedClient(component.KindProcessor, config.NewComponentID(typeStr), "", dir)
+	host := &sharedClientHost{Host: componenttest.NewNopHost(), ext: &sharedClientStorage{client: client}}
+	traceID := pcommon.NewTraceID([16]byte{1, 2, 3, 4})
+	releasedID := pcommon.NewTraceID([16]byte{2, 3, 4, 5})
+
+	st := newDiskStorage(config.NewComponentID(typeStr), nil)
+	require.NoError(t, st.start(context.Background(), host))
+
+	// test
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	require.NoError(t, st.createOrAppend(releasedID, simpleTracesWithID(releasedID)))
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	_, err := st.delete(releasedID)
+	require.NoError(t, err)
+
+	// verify: the changes are journaled without writing the index
+	index, err := client.Get(context.Background(), indexKey)
+	require.NoError(t, err)
+	assert.Nil(t, index)
+	for seq, change := range []byte{journalCreate, journalCreate, journalDelete} {
+		entry, err := client.Get(context.Background(), journalKey(uint64(seq)))
+		require.NoError(t, err)
+		require.Len(t, entry, 17)
+		assert.Equal(t, change, entry[0])
+	}
+
+	// the journal is compacted into the index on shutdown
+	require.NoError(t, st.shutdown())
+	client = storagetest.NewFileBackedClient(component.KindProcessor, config.NewComponentID(typeStr), "", dir)
+	index, err = client.Get(context.Background(), indexKey)
+	require.NoError(t, err)
+	id := traceID.Bytes()
+	assert.Equal(t, append([]byte{0, 0, 0, 0, 0, 0, 0, 3}, id[:]...), index)
+	for seq := uint64(0); seq < 3; seq++ {
+		entry, err := client.Get(context.Background(), journalKey(seq))
+		require.NoError(t, err)
+		assert.Nil(t, entry)
+	}
+}
+
+func TestDiskStorageJournalCompaction(t *testing.T) {
+	// prepare
+	client := storagetest.NewInMemoryClient(component.KindProcessor, config.NewComponentID(typeStr), "")
+	host := &sharedClientHost{Host: componenttest.NewNopHost(), ext: &sharedClientStorage{client: client}}
+
+	st := newDiskStorage(config.NewComponentID(typeStr), nil)
+	require.NoError(t, st.start(context.Background(), host))
+
+	traceIDs := make([]pcommon.TraceID, minCompactionLen)
+	for i := range traceIDs {
+		traceIDs[i] = pcommon.NewTraceID([16]byte{byte(i), byte(i >> 8)})
+		require.NoError(t, st.createOrAppend(traceIDs[i], simpleTracesWithID(traceIDs[i])))
+	}
+	index, err := client.Get(context.Background(), indexKey)
+	require.NoError(t, err)
+	assert.Nil(t, index)
+
+	// test
+	_, err = st.delete(traceIDs[0])
+	require.NoError(t, err)
+
+	// verify: the deletion is compacted into the index along with the journaled creations
+	index, err = client.Get(context.Background(), indexKey)
+	require.NoError(t, err)
+	assert.Len(t, index, 8+(minCompactionLen-1)*16)
+	entry, err := client.Get(context.Background(), journalKey(0))
+	require.NoError(t, err)
+	assert.Nil(t, entry)
+
+	// the journal continues after the index
+	traceID := pcommon.NewTraceID([16]byte{0xff, 0xff})
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	entry, err = client.Get(context.Background(), journalKey(minCompactionLen))
+	require.NoError(t, err)
+	assert.NotNil(t, entry)
+
+	st = newDiskStorage(config.NewComponentID(typeStr), nil)
+	require.NoError(t, st.start(context.Background(), host))
+	restored, err := st.restore()
+	require.NoError(t, err)
+	assert.Len(t, restored, minCompactionLen)
+}
+
+func TestDiskStorageInvalidIndex(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		key  string
+		data []byte
+		err  error
+	}{
+		{
+			name: "index",
+			key:  indexKey,
+			data: []byte{1, 2, 3},
+			err:  errInvalidIndex,
+		},
+		{
+			name: "journal",
+			key:  journalKey(0),
+			data: []byte{'?', 1, 2, 3},
+			err:  errInvalidJournal,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			client := storagetest.NewInMemoryClient(component.KindProcessor, config.NewComponentID(typeStr), "")
+			require.NoError(t, client.Set(context.Background(), tt.key, tt.data))
+			host := &sharedClientHost{Host: componenttest.NewNopHost(), ext: &sharedClientStorage{client: client}}
+
+			st := newDiskStorage(config.NewComponentID(typeStr), nil)
+			assert.ErrorIs(t, st.start(context.Background(), host), tt.err)
+
+			// the client isn't leaked
+			_, err := client.Get(context.Background(), tt.key)
+			assert.Error(t, err)
+		})
+	}
+}
+
+// sharedClientStorage is a storage extension handing out the same client to every storage,
+// so that a storage can be started again without the previous one being shut down.
+type sharedClientStorage struct {
+	component.StartFunc
+	component.ShutdownFunc
+	client extstorage.Client
+}
+
+func (s *sharedClientStorage) GetClient(context.Context, component.Kind, config.ComponentID, string) (extstorage.Client, error) {
+	return s.client, nil
+}
+
+type sharedClientHost struct {
+	component.Host
+	ext *sharedClientStorage
+}
+
+func (h *sharedClientHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return map[config.ComponentID]component.Extension{config.NewComponentID("shared_storage"): h.ext}
+}
@@ -0,0 +1,205 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbytraceprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+// storageImplementations are the storages every test in this file runs against.
+var storageImplementations = []struct {
+	name       string
+	newStorage func(t *testing.T) storage
+}{
+	{
+		name: "memory",
+		newStorage: func(*testing.T) storage {
+			return newMemoryStorage()
+		},
+	},
+	{
+		name: "disk",
+		newStorage: func(t *testing.T) storage {
+			st := newDiskStorage(config.NewComponentID(typeStr), nil)
+			host := storagetest.NewStorageHost().WithInMemoryStorageExtension("test")
+			require.NoError(t, st.start(context.Background(), host))
+			return st
+		},
+	},
+}
+
+func TestStorageCreateAndGetTrace(t *testing.T) {
+	for _, tt := range storageImplementations {
+		t.Run(tt.name, func(t *testing.T) {
+			// prepare
+			st := tt.newStorage(t)
+
+			traceIDs := []pcommon.TraceID{
+				pcommon.NewTraceID([16]byte{1, 2, 3, 4}),
+				pcommon.NewTraceID([16]byte{2, 3, 4, 5}),
+			}
+
+			baseTrace := ptrace.NewTraces()
+			rss := baseTrace.ResourceSpans()
+			rs := rss.AppendEmpty()
+			ils := rs.ScopeSpans().AppendEmpty()
+			span := ils.Spans().AppendEmpty()
+
+			// test
+			for _, traceID := range traceIDs {
+				span.SetTraceID(traceID)
+				assert.NoError(t, st.createOrAppend(traceID, baseTrace))
+			}
+
+			// verify
+			for _, traceID := range traceIDs {
+				expected := []ptrace.ResourceSpans{baseTrace.ResourceSpans().At(0)}
+				expected[0].ScopeSpans().At(0).Spans().At(0).SetTraceID(traceID)
+
+				retrieved, err := st.get(traceID)
+				require.NoError(t, err)
+				assert.Equal(t, expected, retrieved)
+			}
+		})
+	}
+}
+
+func TestStorageDeleteTrace(t *testing.T) {
+	for _, tt := range storageImplementations {
+		t.Run(tt.name, func(t *testing.T) {
+			// prepare
+			st := tt.newStorage(t)
+
+			traceID := pcommon.NewTraceID([16]byte{1, 2, 3, 4})
+
+			trace := ptrace.NewTraces()
+			rss := trace.ResourceSpans()
+			rs := rss.AppendEmpty()
+			ils := rs.ScopeSpans().AppendEmpty()
+			span := ils.Spans().AppendEmpty()
+			span.SetTraceID(traceID)
+
+			assert.NoError(t, st.createOrAppend(traceID, trace))
+
+			// test
+			deleted, err := st.delete(traceID)
+
+			// verify
+			require.NoError(t, err)
+			assert.Equal(t, []ptrace.ResourceSpans{trace.ResourceSpans().At(0)}, deleted)
+
+			retrieved, err := st.get(traceID)
+			require.NoError(t, err)
+			assert.Nil(t, retrieved)
+
+			deleted, err = st.delete(traceID)
+			require.NoError(t, err)
+			assert.Nil(t, deleted)
+		})
+	}
+}
+
+func TestStorageAppendSpans(t *testing.T) {
+	for _, tt := range storageImplementations {
+		t.Run(tt.name, func(t *testing.T) {
+			// prepare
+			st := tt.newStorage(t)
+
+			traceID := pcommon.NewTraceID([16]byte{1, 2, 3, 4})
+
+			trace := ptrace.NewTraces()
+			rss := trace.ResourceSpans()
+			rs := rss.AppendEmpty()
+			ils := rs.ScopeSpans().AppendEmpty()
+			span := ils.Spans().AppendEmpty()
+			span.SetTraceID(traceID)
+			span.SetSpanID(pcommon.NewSpanID([8]byte{1, 2, 3, 4}))
+
+			assert.NoError(t, st.createOrAppend(traceID, trace))
+
+			secondTrace := ptrace.NewTraces()
+			secondRss := secondTrace.ResourceSpans()
+			secondRs := secondRss.AppendEmpty()
+			secondIls := secondRs.ScopeSpans().AppendEmpty()
+			secondSpan := secondIls.Spans().AppendEmpty()
+			secondSpan.SetName("second-name")
+			secondSpan.SetTraceID(traceID)
+			secondSpan.SetSpanID(pcommon.NewSpanID([8]byte{5, 6, 7, 8}))
+
+			expected := []ptrace.ResourceSpans{
+				ptrace.NewResourceSpans(),
+				ptrace.NewResourceSpans(),
+			}
+			ils.CopyTo(expected[0].ScopeSpans().AppendEmpty())
+			secondIls.CopyTo(expected[1].ScopeSpans().AppendEmpty())
+
+			// test
+			err := st.createOrAppend(traceID, secondTrace)
+			require.NoError(t, err)
+
+			// override something in the second span, to make sure we are storing a copy
+			secondSpan.SetName("changed-second-name")
+
+			// verify
+			retrieved, err := st.get(traceID)
+			require.NoError(t, err)
+			require.Len(t, retrieved, 2)
+			assert.Equal(t, "second-name", retrieved[1].ScopeSpans().At(0).Spans().At(0).Name())
+
+			// now that we checked that the secondSpan change here didn't have an effect, revert
+			// so that we can compare the that everything else has the same value
+			secondSpan.SetName("second-name")
+			assert.Equal(t, expected, retrieved)
+		})
+	}
+}
+
+func TestStorageTraceIsBeingCloned(t *testing.T) {
+	for _, tt := range storageImplementations {
+		t.Run(tt.name, func(t *testing.T) {
+			// prepare
+			st := tt.newStorage(t)
+			traceID := pcommon.NewTraceID([16]byte{1, 2, 3, 4})
+
+			trace := ptrace.NewTraces()
+			rss := trace.ResourceSpans()
+			rs := rss.AppendEmpty()
+			ils := rs.ScopeSpans().AppendEmpty()
+			span := ils.Spans().AppendEmpty()
+			span.SetTraceID(traceID)
+			span.SetSpanID(pcommon.NewSpanID([8]byte{1, 2, 3, 4}))
+			span.SetName("should-not-be-changed")
+
+			// test
+			err := st.createOrAppend(traceID, trace)
+			require.NoError(t, err)
+			span.SetName("changed-trace")
+
+			// verify
+			retrieved, err := st.get(traceID)
+			require.NoError(t, err)
+			assert.Equal(t, "should-not-be-changed", retrieved[0].ScopeSpans().At(0).Spans().At(0).Name())
+		})
+	}
+}
@@ -34,6 +34,7 @@ type fakeClient struct {
 	Informer          cache.SharedInformer
 	NamespaceInformer cache.SharedInformer
 	Namespaces        map[string]*kube.Namespace
+	Nodes             map[string]*kube.Node
 	StopCh            chan struct{}
 }
 
@@ -43,12 +44,13 @@ func selectors() (labels.Selector, fields.Selector) {
 }
 
 // newFakeClient instantiates a new FakeClient object and satisfies the ClientProvider type
-func newFakeClient(_ *zap.Logger, apiCfg k8sconfig.APIConfig, rules kube.ExtractionRules, filters kube.Filters, associations []kube.Association, exclude kube.Excludes, _ kube.APIClientsetProvider, _ kube.InformerProvider, _ kube.InformerProviderNamespace) (kube.Client, error) {
+func newFakeClient(_ *zap.Logger, apiCfg k8sconfig.APIConfig, rules kube.ExtractionRules, filters kube.Filters, associations []kube.Association, exclude kube.Excludes, _ kube.APIClientsetProvider, _ kube.InformerProvider, _ kube.InformerProviderNamespace, _ kube.InformerProviderReplicaSet, _ kube.InformerProviderJob, _ kube.InformerProviderNode) (kube.Client, error) {
 	cs := fake.NewSimpleClientset()
 
 	ls, fs := selectors()
 	return &fakeClient{
 		Pods:              map[kube.PodIdentifier]*kube.Pod{},
+		Nodes:             map[string]*kube.Node{},
 		Rules:             rules,
 		Filters:           filters,
 		Associations:      associations,
@@ -70,6 +72,11 @@ func (f *fakeClient) GetNamespace(namespace string) (*kube.Namespace, bool) {
 	return ns, ok
 }
 
+func (f *fakeClient) GetNode(nodeName string) (*kube.Node, bool) {
+	node, ok := f.Nodes[nodeName]
+	return node, ok
+}
+
 // Start is a noop for FakeClient.
 func (f *fakeClient) Start() {
 	if f.Informer != nil {
@@ -95,6 +95,12 @@ type ExtractConfig struct {
 	// It is a list of FieldExtractConfig type. See FieldExtractConfig
 	// documentation for more details.
 	Labels []FieldExtractConfig `mapstructure:"labels"`
+
+	// OwnerLookup enables watching the ReplicaSets and Jobs owning the pods, so that
+	// k8s.deployment.name and k8s.cronjob.name are taken from their ownerReferences
+	// instead of being derived from the ReplicaSet and Job names. ReplicaSets and Jobs
+	// are only watched in the namespace set in the filter section, if any.
+	OwnerLookup bool `mapstructure:"owner_lookup"`
 }
 
 // FieldExtractConfig allows specifying an extraction rule to extract a value from exactly one field.
@@ -161,7 +167,7 @@ type FieldExtractConfig struct {
 	KeyRegex string `mapstructure:"key_regex"`
 	Regex    string `mapstructure:"regex"`
 	// From represents the source of the labels/annotations.
-	// Allowed values are "pod" and "namespace". The default is pod.
+	// Allowed values are "pod", "namespace" and "node". The default is pod.
 	From string `mapstructure:"from"`
 }
 
@@ -58,6 +58,7 @@ func TestLoadConfig(t *testing.T) {
 						{TagName: "l1", Key: "label1", From: "pod"},
 						{TagName: "l2", Key: "label2", Regex: "field=(?P<value>.+)", From: kube.MetadataFromPod},
 					},
+					OwnerLookup: true,
 				},
 				Filter: FilterConfig{
 					Namespace:      "ns2",
@@ -70,12 +70,22 @@
 //     require identifier of a particular container run set as `k8s.container.restart_count` in resource attributes:
 //     - container.id
 //
-// The k8sattributesprocessor can be used for automatic tagging of spans, metrics and logs with k8s labels and annotations from pods and namespaces.
-// The config for associating the data passing through the processor (spans, metrics and logs) with specific Pod/Namespace annotations/labels is configured via "annotations"  and "labels" keys.
-// This config represents a list of annotations/labels that are extracted from pods/namespaces and added to spans, metrics and logs.
+// By default, `k8s.deployment.name` and `k8s.cronjob.name` are derived from the names of the ReplicaSet and Job owning the pod,
+// which fails for custom controllers, Argo Rollouts or ReplicaSets with unusual names. With `owner_lookup: true` in the `extract`
+// section, the processor watches the ReplicaSets and Jobs, and follows their ownerReferences to the Deployment and CronJob
+// controlling them. No name is added for ReplicaSets and Jobs that are not controlled by a Deployment or a CronJob.
+// The names are still derived from the ReplicaSets and Jobs that are not known yet. ReplicaSets and Jobs are only watched
+// in the namespace set in the `filter` section, if any; the other filters only apply to pods.
+//
+// The k8sattributesprocessor can be used for automatic tagging of spans, metrics and logs with k8s labels and annotations from pods, namespaces and nodes.
+// The config for associating the data passing through the processor (spans, metrics and logs) with specific Pod/Namespace/Node annotations/labels is configured via "annotations"  and "labels" keys.
+// This config represents a list of annotations/labels that are extracted from pods/namespaces/nodes and added to spans, metrics and logs.
 // Each item is specified as a config of tag_name (representing the tag name to tag the spans with),
 // key (representing the key used to extract value) and from (representing the kubernetes object used to extract the value).
-// The "from" field has only two possible values "pod" and "namespace" and defaults to "pod" if none is specified.
+// The "from" field has three possible values "pod", "namespace" and "node" and defaults to "pod" if none is specified.
+// Node annotations/labels are looked up through the node the pod is scheduled on, falling back to the `k8s.node.name`
+// resource attribute when the pod is not known. Nodes are only watched when at least one rule uses `from: node`,
+// and only the node set in the `filter` section, if any.
 //
 // A few examples to use this config are as follows:
 // annotations:
@@ -95,10 +105,16 @@
 //     key: label2
 //     regex: field=(?P<value>.+)
 //     from: pod
+//   - tag_name: zone # extracts value of label from nodes with key `topology.kubernetes.io/zone` and inserts it as a tag with key `zone`
+//     key: topology.kubernetes.io/zone
+//     from: node
 //
 // # RBAC
 //
 // The k8sattributesprocessor needs `get`, `watch` and `list` permissions on both `pods` and `namespaces` resources, for all namespaces and pods included in the configured filters.
+// When node annotations/labels are extracted, the same permissions are needed on the `nodes` resource.
+// When `owner_lookup` is enabled, the same permissions are needed on the `replicasets` and `jobs` resources of the `apps` and `batch` API groups.
+// Without them, the processor warns that the ReplicaSets and Jobs failed to sync after 10 seconds, and derives the names from the pods.
 // Here is an example of a `ClusterRole` to give a `ServiceAccount` the necessary permissions for all pods and namespaces in the cluster (replace `<OTEL_COL_NAMESPACE>` with a namespace where collector is deployed):
 //
 //	apiVersion: v1
@@ -115,6 +131,12 @@
 //	- apiGroups: [""]
 //	  resources: ["pods", "namespaces"]
 //	  verbs: ["get", "watch", "list"]
+//	- apiGroups: ["apps"]
+//	  resources: ["replicasets"]
+//	  verbs: ["get", "watch", "list"]
+//	- apiGroups: ["batch"]
+//	  resources: ["jobs"]
+//	  verbs: ["get", "watch", "list"]
 //	---
 //	apiVersion: rbac.authorization.k8s.io/v1
 //	kind: ClusterRoleBinding
@@ -15,6 +15,7 @@
 package kube // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sattributesprocessor/internal/kube"
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"strings"
@@ -23,7 +24,10 @@ import (
 
 	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
 	"go.uber.org/zap"
+	apps_v1 "k8s.io/api/apps/v1"
+	batch_v1 "k8s.io/api/batch/v1"
 	api_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
@@ -36,16 +40,20 @@ import (
 
 // WatchClient is the main interface provided by this package to a kubernetes cluster.
 type WatchClient struct {
-	m                 sync.RWMutex
-	deleteMut         sync.Mutex
-	logger            *zap.Logger
-	kc                kubernetes.Interface
-	informer          cache.SharedInformer
-	namespaceInformer cache.SharedInformer
-	replicasetRegex   *regexp.Regexp
-	cronJobRegex      *regexp.Regexp
-	deleteQueue       []deleteRequest
-	stopCh            chan struct{}
+	m                  sync.RWMutex
+	deleteMut          sync.Mutex
+	logger             *zap.Logger
+	kc                 kubernetes.Interface
+	informer           cache.SharedInformer
+	namespaceInformer  cache.SharedInformer
+	nodeInformer       cache.SharedInformer
+	replicasetInformer cache.SharedInformer
+	jobInformer        cache.SharedInformer
+	replicasetRegex    *regexp.Regexp
+	cronJobRegex       *regexp.Regexp
+	deleteQueue        []deleteRequest
+	stopCh             chan struct{}
+	ownerSyncTimeout   time.Duration
 
 	// A map containing Pod related data, used to associate them with resources.
 	// Key can be either an IP address or Pod UID
@@ -58,6 +66,18 @@ type WatchClient struct {
 	// A map containing Namespace related data, used to associate them with resources.
 	// Key is namespace name
 	Namespaces map[string]*Namespace
+
+	// A map containing Node related data, used to associate them with resources.
+	// Key is node name
+	Nodes map[string]*Node
+
+	// A map containing ReplicaSet related data, used to find the Deployment owning the pods.
+	// Key is the ReplicaSet UID
+	ReplicaSets map[string]*ReplicaSet
+
+	// A map containing Job related data, used to find the CronJob owning the pods.
+	// Key is the Job UID
+	Jobs map[string]*Job
 }
 
 // Extract replicaset name from the pod name. Pod name is created using
@@ -69,21 +89,25 @@ var rRegex = regexp.MustCompile(`^(.*)-[0-9a-zA-Z]+$`)
 var cronJobRegex = regexp.MustCompile(`^(.*)-[0-9]+$`)
 
 // New initializes a new k8s Client.
-func New(logger *zap.Logger, apiCfg k8sconfig.APIConfig, rules ExtractionRules, filters Filters, associations []Association, exclude Excludes, newClientSet APIClientsetProvider, newInformer InformerProvider, newNamespaceInformer InformerProviderNamespace) (Client, error) {
+func New(logger *zap.Logger, apiCfg k8sconfig.APIConfig, rules ExtractionRules, filters Filters, associations []Association, exclude Excludes, newClientSet APIClientsetProvider, newInformer InformerProvider, newNamespaceInformer InformerProviderNamespace, newReplicaSetInformer InformerProviderReplicaSet, newJobInformer InformerProviderJob, newNodeInformer InformerProviderNode) (Client, error) {
 	c := &WatchClient{
-		logger:          logger,
-		Rules:           rules,
-		Filters:         filters,
-		Associations:    associations,
-		Exclude:         exclude,
-		replicasetRegex: rRegex,
-		cronJobRegex:    cronJobRegex,
-		stopCh:          make(chan struct{}),
+		logger:           logger,
+		Rules:            rules,
+		Filters:          filters,
+		Associations:     associations,
+		Exclude:          exclude,
+		replicasetRegex:  rRegex,
+		cronJobRegex:     cronJobRegex,
+		stopCh:           make(chan struct{}),
+		ownerSyncTimeout: defaultOwnerSyncTimeout,
 	}
 	go c.deleteLoop(time.Second*30, defaultPodDeleteGracePeriod)
 
 	c.Pods = map[PodIdentifier]*Pod{}
 	c.Namespaces = map[string]*Namespace{}
+	c.Nodes = map[string]*Node{}
+	c.ReplicaSets = map[string]*ReplicaSet{}
+	c.Jobs = map[string]*Job{}
 	if newClientSet == nil {
 		newClientSet = k8sconfig.MakeClient
 	}
@@ -111,17 +135,70 @@ func New(logger *zap.Logger, apiCfg k8sconfig.APIConfig, rules ExtractionRules,
 		newNamespaceInformer = newNamespaceSharedInformer
 	}
 
+	if newNodeInformer == nil {
+		newNodeInformer = newNodeSharedInformer
+	}
+
+	if newReplicaSetInformer == nil {
+		newReplicaSetInformer = newReplicaSetSharedInformer
+	}
+
+	if newJobInformer == nil {
+		newJobInformer = newJobSharedInformer
+	}
+
 	c.informer = newInformer(c.kc, c.Filters.Namespace, labelSelector, fieldSelector)
 	if c.extractNamespaceLabelsAnnotations() {
 		c.namespaceInformer = newNamespaceInformer(c.kc)
 	} else {
 		c.namespaceInformer = NewNoOpInformer(c.kc)
 	}
+
+	if c.extractNodeLabelsAnnotations() {
+		c.nodeInformer = newNodeInformer(c.kc, c.Filters.Node)
+	} else {
+		c.nodeInformer = NewNoOpInformer(c.kc)
+	}
+
+	// ReplicaSets and Jobs are only scoped by the namespace filter, as the other filters select pods
+	if c.Rules.OwnerLookup && c.Rules.Deployment {
+		c.replicasetInformer = newReplicaSetInformer(c.kc, c.Filters.Namespace)
+	} else {
+		c.replicasetInformer = NewNoOpInformer(c.kc)
+	}
+	if c.Rules.OwnerLookup && c.Rules.CronJobName {
+		c.jobInformer = newJobInformer(c.kc, c.Filters.Namespace)
+	} else {
+		c.jobInformer = NewNoOpInformer(c.kc)
+	}
 	return c, err
 }
 
 // Start registers pod event handlers and starts watching the kubernetes cluster for pod changes.
+// The owners of the pods are synced first, so that they can be found when the pods are added.
+// The pods are watched anyway when the owners don't sync in time, for instance without the
+// permissions to list them, as their names are then derived from the names of the pods.
 func (c *WatchClient) Start() {
+	c.replicasetInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.handleReplicaSetAdd,
+		UpdateFunc: c.handleReplicaSetUpdate,
+		DeleteFunc: c.handleReplicaSetDelete,
+	})
+	go c.replicasetInformer.Run(c.stopCh)
+
+	c.jobInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.handleJobAdd,
+		UpdateFunc: c.handleJobUpdate,
+		DeleteFunc: c.handleJobDelete,
+	})
+	go c.jobInformer.Run(c.stopCh)
+
+	if !c.waitForOwnersSync() {
+		c.logger.Warn("failed to sync the replicasets and jobs before watching the pods, "+
+			"the deployment and cronjob names are derived from the pod names until they sync",
+			zap.Duration("timeout", c.ownerSyncTimeout))
+	}
+
 	c.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.handlePodAdd,
 		UpdateFunc: c.handlePodUpdate,
@@ -135,6 +212,28 @@ func (c *WatchClient) Start() {
 		DeleteFunc: c.handleNamespaceDelete,
 	})
 	go c.namespaceInformer.Run(c.stopCh)
+
+	c.nodeInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.handleNodeAdd,
+		UpdateFunc: c.handleNodeUpdate,
+		DeleteFunc: c.handleNodeDelete,
+	})
+	go c.nodeInformer.Run(c.stopCh)
+}
+
+// waitForOwnersSync waits for the replicasets and jobs to sync, until the
+// client is stopped or the owner sync timeout expires.
+func (c *WatchClient) waitForOwnersSync() bool {
+	ctx, cancel := context.WithTimeout(context.Background(), c.ownerSyncTimeout)
+	defer cancel()
+	go func() {
+		select {
+		case <-c.stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return cache.WaitForCacheSync(ctx.Done(), c.replicasetInformer.HasSynced, c.jobInformer.HasSynced)
 }
 
 // Stop signals the the k8s watcher/informer to stop watching for new events.
@@ -210,6 +309,87 @@ func (c *WatchClient) handleNamespaceDelete(obj interface{}) {
 	}
 }
 
+func (c *WatchClient) handleNodeAdd(obj interface{}) {
+	if node, ok := obj.(*api_v1.Node); ok {
+		c.addOrUpdateNode(node)
+	} else {
+		c.logger.Error("object received was not of type api_v1.Node", zap.Any("received", obj))
+	}
+}
+
+func (c *WatchClient) handleNodeUpdate(old, new interface{}) {
+	if node, ok := new.(*api_v1.Node); ok {
+		c.addOrUpdateNode(node)
+	} else {
+		c.logger.Error("object received was not of type api_v1.Node", zap.Any("received", new))
+	}
+}
+
+func (c *WatchClient) handleNodeDelete(obj interface{}) {
+	if node, ok := obj.(*api_v1.Node); ok {
+		c.m.Lock()
+		// The pods running on a deleted node are deleted as well, so the node doesn't go through the deleteQueue.
+		delete(c.Nodes, node.Name)
+		c.m.Unlock()
+	} else {
+		c.logger.Error("object received was not of type api_v1.Node", zap.Any("received", obj))
+	}
+}
+
+func (c *WatchClient) handleReplicaSetAdd(obj interface{}) {
+	if replicaset, ok := obj.(*apps_v1.ReplicaSet); ok {
+		c.addOrUpdateReplicaSet(replicaset)
+	} else {
+		c.logger.Error("object received was not of type apps_v1.ReplicaSet", zap.Any("received", obj))
+	}
+}
+
+func (c *WatchClient) handleReplicaSetUpdate(old, new interface{}) {
+	if replicaset, ok := new.(*apps_v1.ReplicaSet); ok {
+		c.addOrUpdateReplicaSet(replicaset)
+	} else {
+		c.logger.Error("object received was not of type apps_v1.ReplicaSet", zap.Any("received", new))
+	}
+}
+
+func (c *WatchClient) handleReplicaSetDelete(obj interface{}) {
+	if replicaset, ok := obj.(*apps_v1.ReplicaSet); ok {
+		c.m.Lock()
+		// The attributes of the pods are extracted when they are added, so the pods of a deleted
+		// ReplicaSet keep their Deployment name during their grace period.
+		delete(c.ReplicaSets, string(replicaset.UID))
+		c.m.Unlock()
+	} else {
+		c.logger.Error("object received was not of type apps_v1.ReplicaSet", zap.Any("received", obj))
+	}
+}
+
+func (c *WatchClient) handleJobAdd(obj interface{}) {
+	if job, ok := obj.(*batch_v1.Job); ok {
+		c.addOrUpdateJob(job)
+	} else {
+		c.logger.Error("object received was not of type batch_v1.Job", zap.Any("received", obj))
+	}
+}
+
+func (c *WatchClient) handleJobUpdate(old, new interface{}) {
+	if job, ok := new.(*batch_v1.Job); ok {
+		c.addOrUpdateJob(job)
+	} else {
+		c.logger.Error("object received was not of type batch_v1.Job", zap.Any("received", new))
+	}
+}
+
+func (c *WatchClient) handleJobDelete(obj interface{}) {
+	if job, ok := obj.(*batch_v1.Job); ok {
+		c.m.Lock()
+		delete(c.Jobs, string(job.UID))
+		c.m.Unlock()
+	} else {
+		c.logger.Error("object received was not of type batch_v1.Job", zap.Any("received", obj))
+	}
+}
+
 func (c *WatchClient) deleteLoop(interval time.Duration, gracePeriod time.Duration) {
 	// This loop runs after N seconds and deletes pods from cache.
 	// It iterates over the delete queue and deletes all that aren't
@@ -313,10 +493,8 @@ func (c *WatchClient) extractPodAttributes(pod *api_v1.Pod) map[string]string {
 					tags[conventions.AttributeK8SReplicaSetName] = ref.Name
 				}
 				if c.Rules.Deployment {
-					// format: [deployment-name]-[Random-String-For-ReplicaSet]
-					parts := c.replicasetRegex.FindStringSubmatch(ref.Name)
-					if len(parts) == 2 {
-						tags[conventions.AttributeK8SDeploymentName] = parts[1]
+					if name, ok := c.deploymentName(ref); ok {
+						tags[conventions.AttributeK8SDeploymentName] = name
 					}
 				}
 			case "DaemonSet":
@@ -335,9 +513,8 @@ func (c *WatchClient) extractPodAttributes(pod *api_v1.Pod) map[string]string {
 				}
 			case "Job":
 				if c.Rules.CronJobName {
-					parts := c.cronJobRegex.FindStringSubmatch(ref.Name)
-					if len(parts) == 2 {
-						tags[conventions.AttributeK8SCronJobName] = parts[1]
+					if name, ok := c.cronJobName(ref); ok {
+						tags[conventions.AttributeK8SCronJobName] = name
 					}
 				}
 				if c.Rules.JobUID {
@@ -364,6 +541,57 @@ func (c *WatchClient) extractPodAttributes(pod *api_v1.Pod) map[string]string {
 	return tags
 }
 
+// GetNode takes a node name and returns the node object the node name is associated with.
+func (c *WatchClient) GetNode(nodeName string) (*Node, bool) {
+	c.m.RLock()
+	node, ok := c.Nodes[nodeName]
+	c.m.RUnlock()
+	if ok {
+		return node, ok
+	}
+	return nil, false
+}
+
+// deploymentName returns the name of the Deployment owning the given ReplicaSet. When the owner lookup
+// is enabled and the ReplicaSet is known, its ownerReferences are followed, so that no name is returned
+// for ReplicaSets that are not owned by a Deployment. Otherwise, the name is derived from the ReplicaSet name.
+func (c *WatchClient) deploymentName(ref meta_v1.OwnerReference) (string, bool) {
+	if c.Rules.OwnerLookup {
+		c.m.RLock()
+		replicaset, ok := c.ReplicaSets[string(ref.UID)]
+		c.m.RUnlock()
+		if ok {
+			return replicaset.Deployment.Name, replicaset.Deployment.Name != ""
+		}
+	}
+
+	// format: [deployment-name]-[Random-String-For-ReplicaSet]
+	parts := c.replicasetRegex.FindStringSubmatch(ref.Name)
+	if len(parts) == 2 {
+		return parts[1], true
+	}
+	return "", false
+}
+
+// cronJobName returns the name of the CronJob owning the given Job, following the same rules as deploymentName.
+func (c *WatchClient) cronJobName(ref meta_v1.OwnerReference) (string, bool) {
+	if c.Rules.OwnerLookup {
+		c.m.RLock()
+		job, ok := c.Jobs[string(ref.UID)]
+		c.m.RUnlock()
+		if ok {
+			return job.CronJob.Name, job.CronJob.Name != ""
+		}
+	}
+
+	// format: [cronjob-name]-[time-hash-int]
+	parts := c.cronJobRegex.FindStringSubmatch(ref.Name)
+	if len(parts) == 2 {
+		return parts[1], true
+	}
+	return "", false
+}
+
 func (c *WatchClient) extractPodContainersAttributes(pod *api_v1.Pod) map[string]*Container {
 	containers := map[string]*Container{}
 
@@ -420,10 +648,25 @@ func (c *WatchClient) extractNamespaceAttributes(namespace *api_v1.Namespace) ma
 	return tags
 }
 
+func (c *WatchClient) extractNodeAttributes(node *api_v1.Node) map[string]string {
+	tags := map[string]string{}
+
+	for _, r := range c.Rules.Labels {
+		r.extractFromNodeMetadata(node.Labels, tags, "k8s.node.labels.%s")
+	}
+
+	for _, r := range c.Rules.Annotations {
+		r.extractFromNodeMetadata(node.Annotations, tags, "k8s.node.annotations.%s")
+	}
+
+	return tags
+}
+
 func (c *WatchClient) podFromAPI(pod *api_v1.Pod) *Pod {
 	newPod := &Pod{
 		Name:        pod.Name,
 		Namespace:   pod.GetNamespace(),
+		NodeName:    pod.Spec.NodeName,
 		Address:     pod.Status.PodIP,
 		HostNetwork: pod.Spec.HostNetwork,
 		PodUID:      string(pod.UID),
@@ -613,6 +856,61 @@ func (c *WatchClient) addOrUpdateNamespace(namespace *api_v1.Namespace) {
 	c.m.Unlock()
 }
 
+func (c *WatchClient) addOrUpdateNode(node *api_v1.Node) {
+	newNode := &Node{
+		Name:      node.Name,
+		NodeUID:   string(node.UID),
+		StartTime: node.GetCreationTimestamp(),
+	}
+	newNode.Attributes = c.extractNodeAttributes(node)
+
+	c.m.Lock()
+	if node.Name != "" {
+		c.Nodes[node.Name] = newNode
+	}
+	c.m.Unlock()
+}
+
+func (c *WatchClient) addOrUpdateReplicaSet(replicaset *apps_v1.ReplicaSet) {
+	newReplicaSet := &ReplicaSet{
+		Name:      replicaset.Name,
+		Namespace: replicaset.Namespace,
+		UID:       string(replicaset.UID),
+	}
+	for _, ref := range replicaset.OwnerReferences {
+		if ref.Kind == "Deployment" && ref.Controller != nil && *ref.Controller {
+			newReplicaSet.Deployment = Deployment{Name: ref.Name, UID: string(ref.UID)}
+			break
+		}
+	}
+
+	c.m.Lock()
+	if replicaset.UID != "" {
+		c.ReplicaSets[string(replicaset.UID)] = newReplicaSet
+	}
+	c.m.Unlock()
+}
+
+func (c *WatchClient) addOrUpdateJob(job *batch_v1.Job) {
+	newJob := &Job{
+		Name:      job.Name,
+		Namespace: job.Namespace,
+		UID:       string(job.UID),
+	}
+	for _, ref := range job.OwnerReferences {
+		if ref.Kind == "CronJob" && ref.Controller != nil && *ref.Controller {
+			newJob.CronJob = CronJob{Name: ref.Name, UID: string(ref.UID)}
+			break
+		}
+	}
+
+	c.m.Lock()
+	if job.UID != "" {
+		c.Jobs[string(job.UID)] = newJob
+	}
+	c.m.Unlock()
+}
+
 func (c *WatchClient) extractNamespaceLabelsAnnotations() bool {
 	for _, r := range c.Rules.Labels {
 		if r.From == MetadataFromNamespace {
@@ -629,6 +927,22 @@ func (c *WatchClient) extractNamespaceLabelsAnnotations() bool {
 	return false
 }
 
+func (c *WatchClient) extractNodeLabelsAnnotations() bool {
+	for _, r := range c.Rules.Labels {
+		if r.From == MetadataFromNode {
+			return true
+		}
+	}
+
+	for _, r := range c.Rules.Annotations {
+		if r.From == MetadataFromNode {
+			return true
+		}
+	}
+
+	return false
+}
+
 func needContainerAttributes(rules ExtractionRules) bool {
 	return rules.ContainerImageName || rules.ContainerImageTag || rules.ContainerID
 }
@@ -26,6 +26,8 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest/observer"
+	apps_v1 "k8s.io/api/apps/v1"
+	batch_v1 "k8s.io/api/batch/v1"
 	api_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
@@ -33,6 +35,7 @@ import (
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 )
@@ -128,12 +131,12 @@ func namespaceAddAndUpdateTest(t *testing.T, c *WatchClient, handler func(obj in
 }
 
 func TestDefaultClientset(t *testing.T) {
-	c, err := New(zap.NewNop(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{}, []Association{}, Excludes{}, nil, nil, nil)
+	c, err := New(zap.NewNop(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{}, []Association{}, Excludes{}, nil, nil, nil, nil, nil, nil)
 	assert.Error(t, err)
 	assert.Equal(t, "invalid authType for kubernetes: ", err.Error())
 	assert.Nil(t, c)
 
-	c, err = New(zap.NewNop(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{}, []Association{}, Excludes{}, newFakeAPIClientset, nil, nil)
+	c, err = New(zap.NewNop(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{}, []Association{}, Excludes{}, newFakeAPIClientset, nil, nil, nil, nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, c)
 }
@@ -149,6 +152,9 @@ func TestBadFilters(t *testing.T) {
 		newFakeAPIClientset,
 		NewFakeInformer,
 		NewFakeNamespaceInformer,
+		NewFakeReplicaSetInformer,
+		NewFakeJobInformer,
+		NewFakeNodeInformer,
 	)
 	assert.Error(t, err)
 	assert.Nil(t, c)
@@ -173,6 +179,36 @@ func TestClientStartStop(t *testing.T) {
 	assert.True(t, fctr.HasStopped())
 }
 
+// unsyncedInformer is an informer that never syncs, like the informers
+// without the permissions to list their resources
+type unsyncedInformer struct {
+	cache.SharedInformer
+}
+
+func (i unsyncedInformer) HasSynced() bool {
+	return false
+}
+
+func TestClientStartWithUnsyncedOwners(t *testing.T) {
+	c, logs := newTestClientWithRulesAndFilters(t, ExtractionRules{Deployment: true, OwnerLookup: true}, Filters{})
+	c.replicasetInformer = unsyncedInformer{c.replicasetInformer}
+	c.ownerSyncTimeout = 100 * time.Millisecond
+	defer c.Stop()
+
+	// the pods are watched anyway once the timeout expires
+	done := make(chan struct{})
+	go func() {
+		c.Start()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "Timed out waiting for the client to start")
+	}
+	assert.Equal(t, 1, logs.FilterMessageSnippet("failed to sync the replicasets and jobs").Len())
+}
+
 func TestConstructorErrors(t *testing.T) {
 	er := ExtractionRules{}
 	ff := Filters{}
@@ -185,7 +221,7 @@ func TestConstructorErrors(t *testing.T) {
 			gotAPIConfig = c
 			return nil, fmt.Errorf("error creating k8s client")
 		}
-		c, err := New(zap.NewNop(), apiCfg, er, ff, []Association{}, Excludes{}, clientProvider, NewFakeInformer, NewFakeNamespaceInformer)
+		c, err := New(zap.NewNop(), apiCfg, er, ff, []Association{}, Excludes{}, clientProvider, NewFakeInformer, NewFakeNamespaceInformer, NewFakeReplicaSetInformer, NewFakeJobInformer, NewFakeNodeInformer)
 		assert.Nil(t, c)
 		assert.Error(t, err)
 		assert.Equal(t, err.Error(), "error creating k8s client")
@@ -715,6 +751,265 @@ func TestExtractionRules(t *testing.T) {
 	}
 }
 
+func TestReplicaSetAddUpdateDelete(t *testing.T) {
+	c, _ := newTestClient(t)
+	controller := true
+
+	replicaset := &apps_v1.ReplicaSet{}
+	c.handleReplicaSetAdd(replicaset)
+	assert.Equal(t, 0, len(c.ReplicaSets))
+
+	replicaset = &apps_v1.ReplicaSet{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "checkout-66f5996c7c",
+			Namespace: "ns1",
+			UID:       "207ea729-c779-401d-8347-008ecbc137e3",
+		},
+	}
+	c.handleReplicaSetAdd(replicaset)
+	assert.Equal(t, 1, len(c.ReplicaSets))
+	got := c.ReplicaSets["207ea729-c779-401d-8347-008ecbc137e3"]
+	assert.Equal(t, "checkout-66f5996c7c", got.Name)
+	assert.Equal(t, "ns1", got.Namespace)
+	assert.Equal(t, Deployment{}, got.Deployment)
+
+	updated := replicaset.DeepCopy()
+	updated.OwnerReferences = []meta_v1.OwnerReference{{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Name:       "checkout",
+		UID:        "ffff-gggg",
+		Controller: &controller,
+	}}
+	c.handleReplicaSetUpdate(replicaset, updated)
+	assert.Equal(t, 1, len(c.ReplicaSets))
+	got = c.ReplicaSets["207ea729-c779-401d-8347-008ecbc137e3"]
+	assert.Equal(t, Deployment{Name: "checkout", UID: "ffff-gggg"}, got.Deployment)
+
+	c.handleReplicaSetDelete(updated)
+	assert.Equal(t, 0, len(c.ReplicaSets))
+}
+
+func TestJobAddUpdateDelete(t *testing.T) {
+	c, _ := newTestClient(t)
+	controller := true
+
+	job := &batch_v1.Job{}
+	c.handleJobAdd(job)
+	assert.Equal(t, 0, len(c.Jobs))
+
+	job = &batch_v1.Job{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "report-27667920",
+			Namespace: "ns1",
+			UID:       "59f27ac1-5c71-42e5-abe9-2c499d603706",
+		},
+	}
+	c.handleJobAdd(job)
+	assert.Equal(t, 1, len(c.Jobs))
+	got := c.Jobs["59f27ac1-5c71-42e5-abe9-2c499d603706"]
+	assert.Equal(t, "report-27667920", got.Name)
+	assert.Equal(t, CronJob{}, got.CronJob)
+
+	updated := job.DeepCopy()
+	updated.OwnerReferences = []meta_v1.OwnerReference{{
+		APIVersion: "batch/v1",
+		Kind:       "CronJob",
+		Name:       "report",
+		UID:        "hhhh-iiii",
+		Controller: &controller,
+	}}
+	c.handleJobUpdate(job, updated)
+	got = c.Jobs["59f27ac1-5c71-42e5-abe9-2c499d603706"]
+	assert.Equal(t, CronJob{Name: "report", UID: "hhhh-iiii"}, got.CronJob)
+
+	c.handleJobDelete(updated)
+	assert.Equal(t, 0, len(c.Jobs))
+}
+
+func TestOwnerHandlersWrongType(t *testing.T) {
+	c, logs := newTestClientWithRulesAndFilters(t, ExtractionRules{}, Filters{})
+	c.handleReplicaSetAdd(1)
+	c.handleReplicaSetUpdate(1, 2)
+	c.handleReplicaSetDelete(1)
+	c.handleJobAdd(1)
+	c.handleJobUpdate(1, 2)
+	c.handleJobDelete(1)
+	assert.Equal(t, 6, logs.Len())
+}
+
+func TestOwnerLookupExtractionRules(t *testing.T) {
+	pod := &api_v1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name: "checkout-rollout-66f5996c7c-xyz3",
+			UID:  "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee",
+			OwnerReferences: []meta_v1.OwnerReference{
+				{
+					APIVersion: "apps/v1",
+					Kind:       "ReplicaSet",
+					Name:       "checkout-rollout-66f5996c7c",
+					UID:        "207ea729-c779-401d-8347-008ecbc137e3",
+				},
+				{
+					APIVersion: "batch/v1",
+					Kind:       "Job",
+					Name:       "report-27667920",
+					UID:        "59f27ac1-5c71-42e5-abe9-2c499d603706",
+				},
+			},
+		},
+		Status: api_v1.PodStatus{
+			PodIP: "1.1.1.1",
+		},
+	}
+
+	testCases := []struct {
+		name        string
+		ownerLookup bool
+		replicasets map[string]*ReplicaSet
+		jobs        map[string]*Job
+		attributes  map[string]string
+	}{{
+		name:        "lookup-disabled",
+		ownerLookup: false,
+		replicasets: map[string]*ReplicaSet{
+			"207ea729-c779-401d-8347-008ecbc137e3": {Deployment: Deployment{Name: "checkout"}},
+		},
+		jobs: map[string]*Job{
+			"59f27ac1-5c71-42e5-abe9-2c499d603706": {CronJob: CronJob{Name: "nightly-report"}},
+		},
+		attributes: map[string]string{
+			"k8s.deployment.name": "checkout-rollout",
+			"k8s.cronjob.name":    "report",
+		},
+	}, {
+		name:        "owners-found",
+		ownerLookup: true,
+		replicasets: map[string]*ReplicaSet{
+			"207ea729-c779-401d-8347-008ecbc137e3": {Deployment: Deployment{Name: "checkout"}},
+		},
+		jobs: map[string]*Job{
+			"59f27ac1-5c71-42e5-abe9-2c499d603706": {CronJob: CronJob{Name: "nightly-report"}},
+		},
+		attributes: map[string]string{
+			"k8s.deployment.name": "checkout",
+			"k8s.cronjob.name":    "nightly-report",
+		},
+	}, {
+		name:        "owners-without-controller",
+		ownerLookup: true,
+		replicasets: map[string]*ReplicaSet{
+			"207ea729-c779-401d-8347-008ecbc137e3": {},
+		},
+		jobs: map[string]*Job{
+			"59f27ac1-5c71-42e5-abe9-2c499d603706": {},
+		},
+		attributes: map[string]string{},
+	}, {
+		name:        "owners-unknown",
+		ownerLookup: true,
+		replicasets: map[string]*ReplicaSet{},
+		jobs:        map[string]*Job{},
+		attributes: map[string]string{
+			"k8s.deployment.name": "checkout-rollout",
+			"k8s.cronjob.name":    "report",
+		},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, _ := newTestClient(t)
+			c.Rules = ExtractionRules{Deployment: true, CronJobName: true, OwnerLookup: tc.ownerLookup}
+			c.ReplicaSets = tc.replicasets
+			c.Jobs = tc.jobs
+
+			c.handlePodAdd(pod)
+			p, ok := c.GetPod(newPodIdentifier("connection", "", pod.Status.PodIP))
+			require.True(t, ok)
+			assert.Equal(t, tc.attributes, p.Attributes)
+		})
+	}
+}
+
+func TestOwnerLookupInformers(t *testing.T) {
+	controller := true
+	clientset := fake.NewSimpleClientset(
+		&apps_v1.ReplicaSet{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "checkout-rollout-66f5996c7c",
+				Namespace: "ns1",
+				UID:       "207ea729-c779-401d-8347-008ecbc137e3",
+				OwnerReferences: []meta_v1.OwnerReference{{
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+					Name:       "checkout",
+					UID:        "ffff-gggg",
+					Controller: &controller,
+				}},
+			},
+		},
+		&batch_v1.Job{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "report-27667920",
+				Namespace: "ns1",
+				UID:       "59f27ac1-5c71-42e5-abe9-2c499d603706",
+				OwnerReferences: []meta_v1.OwnerReference{{
+					APIVersion: "batch/v1",
+					Kind:       "CronJob",
+					Name:       "nightly-report",
+					UID:        "hhhh-iiii",
+					Controller: &controller,
+				}},
+			},
+		},
+		&api_v1.Pod{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "checkout-rollout-66f5996c7c-xyz3",
+				Namespace: "ns1",
+				UID:       "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee",
+				OwnerReferences: []meta_v1.OwnerReference{
+					{
+						APIVersion: "apps/v1",
+						Kind:       "ReplicaSet",
+						Name:       "checkout-rollout-66f5996c7c",
+						UID:        "207ea729-c779-401d-8347-008ecbc137e3",
+					},
+					{
+						APIVersion: "batch/v1",
+						Kind:       "Job",
+						Name:       "report-27667920",
+						UID:        "59f27ac1-5c71-42e5-abe9-2c499d603706",
+					},
+				},
+			},
+			Status: api_v1.PodStatus{
+				PodIP: "1.1.1.1",
+			},
+		},
+	)
+	clientsetProvider := func(_ k8sconfig.APIConfig) (kubernetes.Interface, error) {
+		return clientset, nil
+	}
+
+	rules := ExtractionRules{Deployment: true, CronJobName: true, OwnerLookup: true}
+	kc, err := New(zap.NewNop(), k8sconfig.APIConfig{}, rules, Filters{Namespace: "ns1"}, []Association{}, Excludes{}, clientsetProvider, nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+	c := kc.(*WatchClient)
+	go c.Start()
+	defer c.Stop()
+
+	var pod *Pod
+	require.Eventually(t, func() bool {
+		var ok bool
+		pod, ok = c.GetPod(newPodIdentifier("connection", "", "1.1.1.1"))
+		return ok
+	}, 10*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, map[string]string{
+		"k8s.deployment.name": "checkout",
+		"k8s.cronjob.name":    "nightly-report",
+	}, pod.Attributes)
+}
+
 func TestNamespaceExtractionRules(t *testing.T) {
 	c, _ := newTestClientWithRulesAndFilters(t, ExtractionRules{}, Filters{})
 
@@ -805,6 +1100,214 @@ func TestNamespaceExtractionRules(t *testing.T) {
 	}
 }
 
+func TestNodeAddUpdateDelete(t *testing.T) {
+	c, _ := newTestClientWithRulesAndFilters(t, ExtractionRules{
+		Labels: []FieldExtractionRule{{
+			Name: "zone",
+			Key:  "topology.kubernetes.io/zone",
+			From: MetadataFromNode,
+		},
+		},
+	}, Filters{})
+
+	node := &api_v1.Node{}
+	node.Name = "node1"
+	node.UID = "ffffffff-0000-0000-0000-000000000000"
+	node.Labels = map[string]string{"topology.kubernetes.io/zone": "eu-west-1a"}
+	c.handleNodeAdd(node)
+
+	got, ok := c.GetNode("node1")
+	require.True(t, ok)
+	assert.Equal(t, "node1", got.Name)
+	assert.Equal(t, "ffffffff-0000-0000-0000-000000000000", got.NodeUID)
+	assert.Equal(t, map[string]string{"zone": "eu-west-1a"}, got.Attributes)
+
+	updated := node.DeepCopy()
+	updated.Labels = map[string]string{"topology.kubernetes.io/zone": "eu-west-1b"}
+	c.handleNodeUpdate(node, updated)
+	got, ok = c.GetNode("node1")
+	require.True(t, ok)
+	assert.Equal(t, map[string]string{"zone": "eu-west-1b"}, got.Attributes)
+
+	// nodes without a name are not stored
+	c.handleNodeAdd(&api_v1.Node{})
+	assert.Len(t, c.Nodes, 1)
+
+	// delete non-existent node
+	other := &api_v1.Node{}
+	other.Name = "node2"
+	c.handleNodeDelete(other)
+	assert.Len(t, c.Nodes, 1)
+
+	c.handleNodeDelete(updated)
+	assert.Len(t, c.Nodes, 0)
+	got, ok = c.GetNode("node1")
+	assert.False(t, ok)
+	assert.Nil(t, got)
+}
+
+func TestNodeInformer(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&api_v1.Node{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:   "node1",
+				Labels: map[string]string{"topology.kubernetes.io/zone": "eu-west-1a"},
+			},
+		},
+	)
+	clientsetProvider := func(_ k8sconfig.APIConfig) (kubernetes.Interface, error) {
+		return clientset, nil
+	}
+
+	rules := ExtractionRules{
+		Labels: []FieldExtractionRule{{
+			Name: "zone",
+			Key:  "topology.kubernetes.io/zone",
+			From: MetadataFromNode,
+		},
+		},
+	}
+	kc, err := New(zap.NewNop(), k8sconfig.APIConfig{}, rules, Filters{}, []Association{}, Excludes{}, clientsetProvider, nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+	c := kc.(*WatchClient)
+	go c.Start()
+	defer c.Stop()
+
+	var node *Node
+	require.Eventually(t, func() bool {
+		var ok bool
+		node, ok = c.GetNode("node1")
+		return ok
+	}, 10*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, map[string]string{"zone": "eu-west-1a"}, node.Attributes)
+}
+
+func TestNodeHandlersWrongType(t *testing.T) {
+	c, logs := newTestClientWithRulesAndFilters(t, ExtractionRules{}, Filters{})
+	assert.Equal(t, logs.Len(), 0)
+	c.handleNodeAdd(1)
+	c.handleNodeUpdate(1, 2)
+	c.handleNodeDelete(1)
+	assert.Equal(t, logs.Len(), 3)
+	for _, l := range logs.All() {
+		assert.Equal(t, l.Message, "object received was not of type api_v1.Node")
+	}
+}
+
+func TestNodeExtractionRules(t *testing.T) {
+	c, _ := newTestClientWithRulesAndFilters(t, ExtractionRules{}, Filters{})
+
+	node := &api_v1.Node{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:              "k8s-node-example-1",
+			UID:               "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee",
+			CreationTimestamp: meta_v1.Now(),
+			Labels: map[string]string{
+				"label1": "lv1",
+			},
+			Annotations: map[string]string{
+				"annotation1": "av1",
+			},
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		rules      ExtractionRules
+		attributes map[string]string
+	}{{
+		name:       "no-rules",
+		rules:      ExtractionRules{},
+		attributes: nil,
+	}, {
+		name: "pod-rules",
+		rules: ExtractionRules{
+			Labels: []FieldExtractionRule{{
+				Name: "l1",
+				Key:  "label1",
+				From: MetadataFromPod,
+			},
+			},
+		},
+		attributes: nil,
+	}, {
+		name: "labels",
+		rules: ExtractionRules{
+			Annotations: []FieldExtractionRule{{
+				Name: "a1",
+				Key:  "annotation1",
+				From: MetadataFromNode,
+			},
+			},
+			Labels: []FieldExtractionRule{{
+				Name: "l1",
+				Key:  "label1",
+				From: MetadataFromNode,
+			},
+			},
+		},
+		attributes: map[string]string{
+			"l1": "lv1",
+			"a1": "av1",
+		},
+	}, {
+		name: "regex",
+		rules: ExtractionRules{
+			Labels: []FieldExtractionRule{{
+				Name:  "l1",
+				Key:   "label1",
+				Regex: regexp.MustCompile(`l(?P<value>.+)`),
+				From:  MetadataFromNode,
+			},
+			},
+		},
+		attributes: map[string]string{
+			"l1": "v1",
+		},
+	}, {
+		name: "all-labels",
+		rules: ExtractionRules{
+			Labels: []FieldExtractionRule{{
+				KeyRegex: regexp.MustCompile("la*"),
+				From:     MetadataFromNode,
+			},
+			},
+		},
+		attributes: map[string]string{
+			"k8s.node.labels.label1": "lv1",
+		},
+	}, {
+		name: "all-annotations",
+		rules: ExtractionRules{
+			Annotations: []FieldExtractionRule{{
+				KeyRegex: regexp.MustCompile("an*"),
+				From:     MetadataFromNode,
+			},
+			},
+		},
+		attributes: map[string]string{
+			"k8s.node.annotations.annotation1": "av1",
+		},
+	},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c.Rules = tc.rules
+			c.handleNodeAdd(node)
+			n, ok := c.GetNode(node.Name)
+			require.True(t, ok)
+
+			assert.Equal(t, len(tc.attributes), len(n.Attributes))
+			for k, v := range tc.attributes {
+				got, ok := n.Attributes[k]
+				assert.True(t, ok)
+				assert.Equal(t, v, got)
+			}
+		})
+	}
+}
+
 func TestFilters(t *testing.T) {
 	testCases := []struct {
 		name    string
@@ -1290,7 +1793,7 @@ func newTestClientWithRulesAndFilters(t *testing.T, e ExtractionRules, f Filters
 			},
 		},
 	}
-	c, err := New(logger, k8sconfig.APIConfig{}, e, f, associations, exclude, newFakeAPIClientset, NewFakeInformer, NewFakeNamespaceInformer)
+	c, err := New(logger, k8sconfig.APIConfig{}, e, f, associations, exclude, newFakeAPIClientset, NewFakeInformer, NewFakeNamespaceInformer, NewFakeReplicaSetInformer, NewFakeJobInformer, NewFakeNodeInformer)
 	require.NoError(t, err)
 	return c.(*WatchClient), logs
 }
@@ -1298,3 +1801,56 @@ func newTestClientWithRulesAndFilters(t *testing.T, e ExtractionRules, f Filters
 func newTestClient(t *testing.T) (*WatchClient, *observer.ObservedLogs) {
 	return newTestClientWithRulesAndFilters(t, ExtractionRules{}, Filters{})
 }
+
+func TestExtractNodeLabelsAnnotations(t *testing.T) {
+	c, _ := newTestClientWithRulesAndFilters(t, ExtractionRules{}, Filters{})
+	testCases := []struct {
+		name              string
+		shouldExtractNode bool
+		rules             ExtractionRules
+	}{{
+		name:              "empty-rules",
+		shouldExtractNode: false,
+		rules:             ExtractionRules{},
+	}, {
+		name:              "namespace-rules",
+		shouldExtractNode: false,
+		rules: ExtractionRules{
+			Labels: []FieldExtractionRule{{
+				Name: "l1",
+				Key:  "label1",
+				From: MetadataFromNamespace,
+			},
+			},
+		},
+	}, {
+		name:              "node-rules-only-annotations",
+		shouldExtractNode: true,
+		rules: ExtractionRules{
+			Annotations: []FieldExtractionRule{{
+				Name: "a1",
+				Key:  "annotation1",
+				From: MetadataFromNode,
+			},
+			},
+		},
+	}, {
+		name:              "node-rules-only-labels",
+		shouldExtractNode: true,
+		rules: ExtractionRules{
+			Labels: []FieldExtractionRule{{
+				Name: "l1",
+				Key:  "label1",
+				From: MetadataFromNode,
+			},
+			},
+		},
+	},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c.Rules = tc.rules
+			assert.Equal(t, tc.shouldExtractNode, c.extractNodeLabelsAnnotations())
+		})
+	}
+}
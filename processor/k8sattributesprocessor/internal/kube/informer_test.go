@@ -20,9 +20,14 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	apps_v1 "k8s.io/api/apps/v1"
+	batch_v1 "k8s.io/api/batch/v1"
 	api_v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
@@ -44,6 +49,27 @@ func Test_newSharedNamespaceInformer(t *testing.T) {
 	assert.NotNil(t, informer)
 }
 
+func Test_newSharedReplicaSetInformer(t *testing.T) {
+	client, err := newFakeAPIClientset(k8sconfig.APIConfig{})
+	require.NoError(t, err)
+	informer := newReplicaSetSharedInformer(client, "testns")
+	assert.NotNil(t, informer)
+}
+
+func Test_newSharedJobInformer(t *testing.T) {
+	client, err := newFakeAPIClientset(k8sconfig.APIConfig{})
+	require.NoError(t, err)
+	informer := newJobSharedInformer(client, "testns")
+	assert.NotNil(t, informer)
+}
+
+func Test_newSharedNodeInformer(t *testing.T) {
+	client, err := newFakeAPIClientset(k8sconfig.APIConfig{})
+	require.NoError(t, err)
+	informer := newNodeSharedInformer(client, "testnode")
+	assert.NotNil(t, informer)
+}
+
 func Test_informerListFuncWithSelectors(t *testing.T) {
 	ls, fs, err := selectorsFromFilters(Filters{
 		Fields: []FieldFilter{
@@ -81,6 +107,66 @@ func Test_namespaceInformerListFunc(t *testing.T) {
 	assert.NotNil(t, obj)
 }
 
+func Test_replicasetInformerListFunc(t *testing.T) {
+	c := fake.NewSimpleClientset(
+		&apps_v1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "rs1", Namespace: "test-ns"}},
+		&apps_v1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "rs2", Namespace: "other-ns"}},
+	)
+	listFunc := replicasetInformerListFunc(c, "test-ns")
+	obj, err := listFunc(metav1.ListOptions{})
+	require.NoError(t, err)
+	list, ok := obj.(*apps_v1.ReplicaSetList)
+	require.True(t, ok)
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, "rs1", list.Items[0].Name)
+}
+
+func Test_jobInformerListFunc(t *testing.T) {
+	c := fake.NewSimpleClientset(
+		&batch_v1.Job{ObjectMeta: metav1.ObjectMeta{Name: "job1", Namespace: "test-ns"}},
+		&batch_v1.Job{ObjectMeta: metav1.ObjectMeta{Name: "job2", Namespace: "other-ns"}},
+	)
+	listFunc := jobInformerListFunc(c, "test-ns")
+	obj, err := listFunc(metav1.ListOptions{})
+	require.NoError(t, err)
+	list, ok := obj.(*batch_v1.JobList)
+	require.True(t, ok)
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, "job1", list.Items[0].Name)
+}
+
+func Test_nodeInformerListFunc(t *testing.T) {
+	tests := []struct {
+		name          string
+		nodeName      string
+		fieldSelector string
+	}{
+		{
+			name: "all-nodes",
+		},
+		{
+			name:          "single-node",
+			nodeName:      "node1",
+			fieldSelector: "metadata.name=node1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewSimpleClientset(&api_v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}})
+			var fieldSelector string
+			c.PrependReactor("list", "nodes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				fieldSelector = action.(k8stesting.ListAction).GetListRestrictions().Fields.String()
+				return false, nil, nil
+			})
+			listFunc := nodeInformerListFunc(c, tt.nodeName)
+			obj, err := listFunc(metav1.ListOptions{})
+			require.NoError(t, err)
+			assert.IsType(t, &api_v1.NodeList{}, obj)
+			assert.Equal(t, tt.fieldSelector, fieldSelector)
+		})
+	}
+}
+
 func Test_informerWatchFuncWithSelectors(t *testing.T) {
 	ls, fs, err := selectorsFromFilters(Filters{
 		Fields: []FieldFilter{
@@ -118,6 +204,33 @@ func Test_namespaceInformerWatchFunc(t *testing.T) {
 	assert.NotNil(t, obj)
 }
 
+func Test_replicasetInformerWatchFunc(t *testing.T) {
+	c, err := newFakeAPIClientset(k8sconfig.APIConfig{})
+	assert.NoError(t, err)
+	watchFunc := replicasetInformerWatchFunc(c, "test-ns")
+	obj, err := watchFunc(metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.NotNil(t, obj)
+}
+
+func Test_jobInformerWatchFunc(t *testing.T) {
+	c, err := newFakeAPIClientset(k8sconfig.APIConfig{})
+	assert.NoError(t, err)
+	watchFunc := jobInformerWatchFunc(c, "test-ns")
+	obj, err := watchFunc(metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.NotNil(t, obj)
+}
+
+func Test_nodeInformerWatchFunc(t *testing.T) {
+	c, err := newFakeAPIClientset(k8sconfig.APIConfig{})
+	assert.NoError(t, err)
+	watchFunc := nodeInformerWatchFunc(c, "node1")
+	obj, err := watchFunc(metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.NotNil(t, obj)
+}
+
 func Test_fakeInformer(t *testing.T) {
 	// nothing real to test here. just to make coverage happy
 	c, err := newFakeAPIClientset(k8sconfig.APIConfig{})
@@ -141,3 +254,15 @@ func Test_fakeNamespaceInformer(t *testing.T) {
 	store := i.GetStore()
 	assert.NoError(t, store.Add(api_v1.Namespace{}))
 }
+
+func Test_fakeNodeInformer(t *testing.T) {
+	// nothing real to test here. just to make coverage happy
+	c, err := newFakeAPIClientset(k8sconfig.APIConfig{})
+	assert.NoError(t, err)
+	i := NewFakeNodeInformer(c, "node1")
+	i.AddEventHandlerWithResyncPeriod(cache.ResourceEventHandlerFuncs{}, time.Second)
+	i.HasSynced()
+	i.LastSyncResourceVersion()
+	store := i.GetStore()
+	assert.NoError(t, store.Add(api_v1.Node{}))
+}
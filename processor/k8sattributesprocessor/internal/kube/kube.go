@@ -29,13 +29,16 @@ import (
 
 const (
 	podNodeField            = "spec.nodeName"
+	nodeNameField           = "metadata.name"
 	ignoreAnnotation string = "opentelemetry.io/k8s-processor/ignore"
 	tagNodeName             = "k8s.node.name"
 	tagStartTime            = "k8s.pod.start_time"
 	// MetadataFromPod is used to specify to extract metadata/labels/annotations from pod
 	MetadataFromPod = "pod"
 	// MetadataFromNamespace is used to specify to extract metadata/labels/annotations from namespace
-	MetadataFromNamespace  = "namespace"
+	MetadataFromNamespace = "namespace"
+	// MetadataFromNode is used to specify to extract metadata/labels/annotations from the node running the pod
+	MetadataFromNode       = "node"
 	PodIdentifierMaxLength = 4
 
 	ResourceSource   = "resource_attribute"
@@ -90,18 +93,20 @@ var (
 	// TODO: move these to config with default values
 	defaultPodDeleteGracePeriod = time.Second * 120
 	watchSyncPeriod             = time.Minute * 5
+	defaultOwnerSyncTimeout     = time.Second * 10
 )
 
 // Client defines the main interface that allows querying pods by metadata.
 type Client interface {
 	GetPod(PodIdentifier) (*Pod, bool)
 	GetNamespace(string) (*Namespace, bool)
+	GetNode(string) (*Node, bool)
 	Start()
 	Stop()
 }
 
 // ClientProvider defines a func type that returns a new Client.
-type ClientProvider func(*zap.Logger, k8sconfig.APIConfig, ExtractionRules, Filters, []Association, Excludes, APIClientsetProvider, InformerProvider, InformerProviderNamespace) (Client, error)
+type ClientProvider func(*zap.Logger, k8sconfig.APIConfig, ExtractionRules, Filters, []Association, Excludes, APIClientsetProvider, InformerProvider, InformerProviderNamespace, InformerProviderReplicaSet, InformerProviderJob, InformerProviderNode) (Client, error)
 
 // APIClientsetProvider defines a func type that initializes and return a new kubernetes
 // Clientset object.
@@ -116,6 +121,7 @@ type Pod struct {
 	StartTime   *metav1.Time
 	Ignore      bool
 	Namespace   string
+	NodeName    string
 	HostNetwork bool
 
 	// Containers is a map of container name to Container struct.
@@ -147,6 +153,42 @@ type Namespace struct {
 	DeletedAt    time.Time
 }
 
+// Node represents a kubernetes node.
+type Node struct {
+	Name       string
+	NodeUID    string
+	Attributes map[string]string
+	StartTime  metav1.Time
+}
+
+// ReplicaSet represents a kubernetes replicaset.
+type ReplicaSet struct {
+	Name       string
+	Namespace  string
+	UID        string
+	Deployment Deployment
+}
+
+// Deployment represents a kubernetes deployment owning a replicaset.
+type Deployment struct {
+	Name string
+	UID  string
+}
+
+// Job represents a kubernetes job.
+type Job struct {
+	Name      string
+	Namespace string
+	UID       string
+	CronJob   CronJob
+}
+
+// CronJob represents a kubernetes cronjob owning a job.
+type CronJob struct {
+	Name string
+	UID  string
+}
+
 type deleteRequest struct {
 	// id is identifier (IP address or Pod UID) of pod to remove from pods map
 	id PodIdentifier
@@ -201,6 +243,10 @@ type ExtractionRules struct {
 	ContainerImageName bool
 	ContainerImageTag  bool
 
+	// OwnerLookup enables following the ownerReferences of the ReplicaSets and Jobs
+	// owning the pods, instead of deriving the Deployment and CronJob names from theirs.
+	OwnerLookup bool
+
 	Annotations []FieldExtractionRule
 	Labels      []FieldExtractionRule
 }
@@ -219,9 +265,10 @@ type FieldExtractionRule struct {
 	// Full value is extracted when no regexp is provided.
 	Regex *regexp.Regexp
 	// From determines the kubernetes object the field should be retrieved from.
-	// Currently only two values are supported,
+	// Currently only three values are supported,
 	//  - pod
 	//  - namespace
+	//  - node
 	From string
 }
 
@@ -238,6 +285,12 @@ func (r *FieldExtractionRule) extractFromNamespaceMetadata(metadata map[string]s
 	}
 }
 
+func (r *FieldExtractionRule) extractFromNodeMetadata(metadata map[string]string, tags map[string]string, formatter string) {
+	if r.From == MetadataFromNode {
+		r.extractFromMetadata(metadata, tags, formatter)
+	}
+}
+
 func (r *FieldExtractionRule) extractFromMetadata(metadata map[string]string, tags map[string]string, formatter string) {
 	if r.KeyRegex != nil {
 		for k, v := range metadata {
@@ -134,6 +134,15 @@ func withExtractMetadata(fields ...string) option {
 	}
 }
 
+// withExtractOwnerLookup enables following the ownerReferences of the ReplicaSets and Jobs
+// owning the pods to find their Deployments and CronJobs.
+func withExtractOwnerLookup(enabled bool) option {
+	return func(p *kubernetesprocessor) error {
+		p.rules.OwnerLookup = enabled
+		return nil
+	}
+}
+
 // withExtractLabels allows specifying options to control extraction of pod labels.
 func withExtractLabels(labels ...FieldExtractConfig) option {
 	return func(p *kubernetesprocessor) error {
@@ -169,8 +178,10 @@ func extractFieldRules(fieldType string, fields ...FieldExtractConfig) ([]kube.F
 			a.From = kube.MetadataFromPod
 		case kube.MetadataFromNamespace:
 			a.From = kube.MetadataFromNamespace
+		case kube.MetadataFromNode:
+			a.From = kube.MetadataFromNode
 		default:
-			return rules, fmt.Errorf("%s is not a valid choice for From. Must be one of: pod, namespace", a.From)
+			return rules, fmt.Errorf("%s is not a valid choice for From. Must be one of: pod, namespace, node", a.From)
 		}
 
 		if name == "" && a.Key != "" {
@@ -179,6 +190,8 @@ func extractFieldRules(fieldType string, fields ...FieldExtractConfig) ([]kube.F
 				name = fmt.Sprintf("k8s.pod.%s.%s", fieldType, a.Key)
 			} else if a.From == kube.MetadataFromNamespace {
 				name = fmt.Sprintf("k8s.namespace.%s.%s", fieldType, a.Key)
+			} else if a.From == kube.MetadataFromNode {
+				name = fmt.Sprintf("k8s.node.%s.%s", fieldType, a.Key)
 			}
 		}
 
@@ -67,6 +67,12 @@ func TestWithPassthrough(t *testing.T) {
 	assert.True(t, p.passthroughMode)
 }
 
+func TestWithExtractOwnerLookup(t *testing.T) {
+	p := &kubernetesprocessor{}
+	assert.NoError(t, withExtractOwnerLookup(true)(p))
+	assert.True(t, p.rules.OwnerLookup)
+}
+
 func TestWithExtractAnnotations(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -250,6 +256,24 @@ func TestWithExtractLabels(t *testing.T) {
 			},
 			"",
 		},
+		{
+			"basic-node",
+			[]FieldExtractConfig{
+				{
+					TagName: "tag1",
+					Key:     "key1",
+					From:    kube.MetadataFromNode,
+				},
+			},
+			[]kube.FieldExtractionRule{
+				{
+					Name: "tag1",
+					Key:  "key1",
+					From: kube.MetadataFromNode,
+				},
+			},
+			"",
+		},
 		{
 			"basic-pod-keyregex",
 			[]FieldExtractConfig{
@@ -623,6 +647,34 @@ func Test_extractFieldRules(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"default-node",
+			args{"labels", []FieldExtractConfig{
+				{
+					Key:  "key",
+					From: kube.MetadataFromNode,
+				},
+			}},
+			[]kube.FieldExtractionRule{
+				{
+					Name: "k8s.node.labels.key",
+					Key:  "key",
+					From: kube.MetadataFromNode,
+				},
+			},
+			false,
+		},
+		{
+			"invalid-from",
+			args{"labels", []FieldExtractConfig{
+				{
+					Key:  "key",
+					From: "deployment",
+				},
+			}},
+			[]kube.FieldExtractionRule{},
+			true,
+		},
 		{
 			"basic",
 			args{"field", []FieldExtractConfig{
@@ -52,7 +52,7 @@ func (kp *kubernetesprocessor) initKubeClient(logger *zap.Logger, kubeClient kub
 		kubeClient = kube.New
 	}
 	if !kp.passthroughMode {
-		kc, err := kubeClient(logger, kp.apiConfig, kp.rules, kp.filters, kp.podAssociations, kp.podIgnore, nil, nil, nil)
+		kc, err := kubeClient(logger, kp.apiConfig, kp.rules, kp.filters, kp.podAssociations, kp.podIgnore, nil, nil, nil, nil, nil, nil)
 		if err != nil {
 			return err
 		}
@@ -120,6 +120,7 @@ func (kp *kubernetesprocessor) processResource(ctx context.Context, resource pco
 		return
 	}
 
+	var nodeName string
 	if podIdentifierValue.IsNotEmpty() {
 		if pod, ok := kp.kc.GetPod(podIdentifierValue); ok {
 			kp.logger.Debug("getting the pod", zap.Any("pod", pod))
@@ -128,6 +129,7 @@ func (kp *kubernetesprocessor) processResource(ctx context.Context, resource pco
 				resource.Attributes().InsertString(key, val)
 			}
 			kp.addContainerAttributes(resource.Attributes(), pod)
+			nodeName = pod.NodeName
 		}
 	}
 
@@ -138,6 +140,16 @@ func (kp *kubernetesprocessor) processResource(ctx context.Context, resource pco
 			resource.Attributes().InsertString(key, val)
 		}
 	}
+
+	if nodeName == "" {
+		nodeName = stringAttributeFromMap(resource.Attributes(), conventions.AttributeK8SNodeName)
+	}
+	if nodeName != "" {
+		attrsToAdd := kp.getAttributesForPodsNode(nodeName)
+		for key, val := range attrsToAdd {
+			resource.Attributes().InsertString(key, val)
+		}
+	}
 }
 
 // addContainerAttributes looks if pod has any container identifiers and adds additional container attributes
@@ -179,6 +191,14 @@ func (kp *kubernetesprocessor) getAttributesForPodsNamespace(namespace string) m
 	return ns.Attributes
 }
 
+func (kp *kubernetesprocessor) getAttributesForPodsNode(nodeName string) map[string]string {
+	node, ok := kp.kc.GetNode(nodeName)
+	if !ok {
+		return nil
+	}
+	return node.Attributes
+}
+
 // intFromAttribute extracts int value from an attribute stored as string or int
 func intFromAttribute(val pcommon.Value) (int, error) {
 	switch val.Type() {
@@ -236,7 +236,7 @@ func TestProcessorBadConfig(t *testing.T) {
 }
 
 func TestProcessorBadClientProvider(t *testing.T) {
-	clientProvider := func(_ *zap.Logger, _ k8sconfig.APIConfig, _ kube.ExtractionRules, _ kube.Filters, _ []kube.Association, _ kube.Excludes, _ kube.APIClientsetProvider, _ kube.InformerProvider, _ kube.InformerProviderNamespace) (kube.Client, error) {
+	clientProvider := func(_ *zap.Logger, _ k8sconfig.APIConfig, _ kube.ExtractionRules, _ kube.Filters, _ []kube.Association, _ kube.Excludes, _ kube.APIClientsetProvider, _ kube.InformerProvider, _ kube.InformerProviderNamespace, _ kube.InformerProviderReplicaSet, _ kube.InformerProviderJob, _ kube.InformerProviderNode) (kube.Client, error) {
 		return nil, fmt.Errorf("bad client error")
 	}
 
@@ -301,6 +301,12 @@ func withPodUID(uid string) generateResourceFunc {
 	}
 }
 
+func withNodeName(nodeName string) generateResourceFunc {
+	return func(res pcommon.Resource) {
+		res.Attributes().InsertString(conventions.AttributeK8SNodeName, nodeName)
+	}
+}
+
 func withContainerName(containerName string) generateResourceFunc {
 	return func(res pcommon.Resource) {
 		res.Attributes().InsertString(conventions.AttributeK8SContainerName, containerName)
@@ -742,6 +748,79 @@ func TestProcessorAddLabels(t *testing.T) {
 	}
 }
 
+func TestProcessorAddNodeAttributes(t *testing.T) {
+	m := newMultiTest(
+		t,
+		NewFactory().CreateDefaultConfig(),
+		nil,
+	)
+
+	m.kubernetesProcessorOperation(func(kp *kubernetesprocessor) {
+		kp.podAssociations = []kube.Association{
+			{
+				Sources: []kube.AssociationSource{
+					{
+						From: "connection",
+					},
+				},
+			},
+		}
+		pi := kube.PodIdentifier{
+			kube.PodIdentifierAttributeFromConnection("1.1.1.1"),
+		}
+		kp.kc.(*fakeClient).Pods[pi] = &kube.Pod{
+			Name:     "PodA",
+			NodeName: "node-1",
+			Attributes: map[string]string{
+				"k8s.pod.name": "PodA",
+			},
+		}
+		kp.kc.(*fakeClient).Nodes["node-1"] = &kube.Node{
+			Name: "node-1",
+			Attributes: map[string]string{
+				"k8s.node.labels.zone": "eu-west-1a",
+			},
+		}
+		kp.kc.(*fakeClient).Nodes["node-2"] = &kube.Node{
+			Name: "node-2",
+			Attributes: map[string]string{
+				"k8s.node.labels.zone": "eu-west-1b",
+			},
+		}
+	})
+
+	ctx := client.NewContext(context.Background(), client.Info{
+		Addr: &net.IPAddr{
+			IP: net.ParseIP("1.1.1.1"),
+		},
+	})
+	m.testConsume(ctx, generateTraces(), generateMetrics(), generateLogs(), func(err error) {
+		assert.NoError(t, err)
+	})
+
+	m.assertBatchesLen(1)
+	m.assertResourceObjectLen(0)
+	m.assertResource(0, func(res pcommon.Resource) {
+		assertResourceHasStringAttribute(t, res, "k8s.pod.name", "PodA")
+		assertResourceHasStringAttribute(t, res, "k8s.node.labels.zone", "eu-west-1a")
+	})
+
+	// unknown pods are joined through the node name set on the resource
+	m.testConsume(context.Background(),
+		generateTraces(withNodeName("node-2")),
+		generateMetrics(withNodeName("node-2")),
+		generateLogs(withNodeName("node-2")),
+		func(err error) {
+			assert.NoError(t, err)
+		})
+
+	m.assertBatchesLen(2)
+	m.assertResourceObjectLen(1)
+	m.assertResource(1, func(res pcommon.Resource) {
+		assertResourceHasStringAttribute(t, res, "k8s.node.labels.zone", "eu-west-1b")
+	})
+}
+
 func TestProcessorAddContainerAttributes(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -15,30 +15,79 @@
 package redactionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor"
 
 import (
+	"fmt"
+
 	"go.opentelemetry.io/collector/config"
 )
 
+const (
+	// actionMask replaces the blocked values with asterisks
+	actionMask = "mask"
+	// actionHash replaces the blocked values with their salted hash
+	actionHash = "hash"
+	// actionDrop removes the attributes or the log bodies with blocked values
+	actionDrop = "drop"
+)
+
 type Config struct {
 	config.ProcessorSettings `mapstructure:",squash"`
 
-	// AllowAllKeys is a flag to allow all span attribute keys. Setting this
+	// AllowAllKeys is a flag to allow all attribute keys. Setting this
 	// to true disables the AllowedKeys list. The list of BlockedValues is
 	// applied regardless. If you just want to block values, set this to true.
 	AllowAllKeys bool `mapstructure:"allow_all_keys"`
 
-	// AllowedKeys is a list of allowed span attribute keys. Span attributes
-	// not on the list are removed. The list fails closed if it's empty. To
-	// allow all keys, you should explicitly set AllowAllKeys
+	// AllowedKeys is a list of allowed attribute keys. Attributes not on the
+	// list are removed. The list fails closed if it's empty. To allow all
+	// keys, you should explicitly set AllowAllKeys
 	AllowedKeys []string `mapstructure:"allowed_keys"`
 
 	// BlockedValues is a list of regular expressions for blocking values of
-	// allowed span attributes. Values that match are masked
+	// allowed attributes and of log bodies. Values that match are redacted
+	// according to Action
 	BlockedValues []string `mapstructure:"blocked_values"`
 
+	// Detectors is a list of named, built-in detectors of sensitive values,
+	// such as `credit_card` or `jwt`. Values they detect are redacted like
+	// the BlockedValues
+	Detectors []string `mapstructure:"detectors"`
+
+	// Action controls how blocked values are redacted. Possible values are
+	// `mask` to replace them with asterisks, `hash` to replace them with
+	// their HMAC-SHA256 keyed by HashSalt, and `drop` to remove the whole
+	// attribute or log body
+	Action string `mapstructure:"action"`
+
+	// HashSalt is the key of the hashes of the blocked values when Action is
+	// `hash`. Without a salt, low-entropy values such as card numbers can be
+	// recovered from their hash
+	HashSalt string `mapstructure:"hash_salt"`
+
 	// Summary controls the verbosity level of the diagnostic attributes that
-	// the processor adds to the spans when it redacts or masks other
-	// attributes. In some contexts a list of redacted attributes leaks
-	// information, while it is valuable when integrating and testing a new
-	// configuration. Possible values are `debug`, `info`, and `silent`.
+	// the processor adds to the spans and log records when it redacts or
+	// masks other attributes. In some contexts a list of redacted
+	// attributes leaks information, while it is valuable when integrating and
+	// testing a new configuration. Possible values are `debug`, `info`, and
+	// `silent`.
 	Summary string `mapstructure:"summary"`
 }
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	switch cfg.Action {
+	case actionMask, actionHash, actionDrop:
+	default:
+		return fmt.Errorf("unknown action %q, must be one of %q, %q or %q", cfg.Action, actionMask, actionHash, actionDrop)
+	}
+	if cfg.Action == actionHash && cfg.HashSalt == "" {
+		return fmt.Errorf("hash_salt must be set when the action is %q", actionHash)
+	}
+	for _, name := range cfg.Detectors {
+		if _, ok := detectors[name]; !ok {
+			return fmt.Errorf("unknown detector %q", name)
+		}
+	}
+	return nil
+}
@@ -28,8 +28,9 @@ func TestLoadConfig(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
-		id       config.ComponentID
-		expected config.Processor
+		id          config.ComponentID
+		expected    config.Processor
+		expectedErr string
 	}{
 		{
 			id: config.NewComponentIDWithName(typeStr, ""),
@@ -38,6 +39,9 @@ func TestLoadConfig(t *testing.T) {
 				AllowAllKeys:      false,
 				AllowedKeys:       []string{"description", "group", "id", "name"},
 				BlockedValues:     []string{"4[0-9]{12}(?:[0-9]{3})?", "(5[1-5][0-9]{14})"},
+				Detectors:         []string{"jwt", "us_ssn"},
+				Action:            actionHash,
+				HashSalt:          "pepper",
 				Summary:           debug,
 			},
 		},
@@ -45,6 +49,18 @@ func TestLoadConfig(t *testing.T) {
 			id:       config.NewComponentIDWithName(typeStr, "empty"),
 			expected: createDefaultConfig(),
 		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "invalid_action"),
+			expectedErr: `unknown action "encrypt", must be one of "mask", "hash" or "drop"`,
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "missing_salt"),
+			expectedErr: `hash_salt must be set when the action is "hash"`,
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "unknown_detector"),
+			expectedErr: `unknown detector "phone_number"`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -59,6 +75,10 @@ func TestLoadConfig(t *testing.T) {
 			require.NoError(t, err)
 			require.NoError(t, config.UnmarshalProcessor(sub, cfg))
 
+			if tt.expectedErr != "" {
+				assert.EqualError(t, cfg.Validate(), tt.expectedErr)
+				return
+			}
 			assert.NoError(t, cfg.Validate())
 			assert.Equal(t, tt.expected, cfg)
 		})
@@ -0,0 +1,117 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redactionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor"
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// detector finds sensitive values. The matches of the regular expression are
+// only candidates when there's a validator, they are blocked if it accepts them
+type detector struct {
+	re       *regexp.Regexp
+	validate func(match string) bool
+}
+
+// matches reports whether a match of the regular expression is a sensitive value
+func (d *detector) matches(match string) bool {
+	return d.validate == nil || d.validate(match)
+}
+
+// detectors are the named detectors that can be enabled with Config.Detectors
+var detectors = map[string]*detector{
+	// Payment card numbers, optionally grouped by spaces or dashes
+	"credit_card": {
+		re:       regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`),
+		validate: isCreditCardNumber,
+	},
+	"email": {
+		re: regexp.MustCompile(`[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}`),
+	},
+	"ipv4": {
+		re:       regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`),
+		validate: func(match string) bool { return net.ParseIP(match) != nil },
+	},
+	// JSON Web Tokens, signed or not
+	"jwt": {
+		re:       regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`),
+		validate: isJWT,
+	},
+	// US social security numbers, in their usual AAA-GG-SSSS format
+	"us_ssn": {
+		re:       regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`),
+		validate: isSSN,
+	},
+	// AWS access key IDs, long-term or temporary
+	"aws_access_key_id": {
+		re: regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`),
+	},
+}
+
+// isCreditCardNumber validates the number of digits and the Luhn checksum of
+// a payment card number
+func isCreditCardNumber(match string) bool {
+	var digits []byte
+	for i := 0; i < len(match); i++ {
+		if match[i] >= '0' && match[i] <= '9' {
+			digits = append(digits, match[i]-'0')
+		}
+	}
+	if len(digits) < 13 || len(digits) > 19 {
+		return false
+	}
+
+	sum := 0
+	for i := range digits {
+		d := int(digits[len(digits)-1-i])
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+// isJWT checks that the header of a token is a JSON object declaring an
+// algorithm, which rules out other dot-separated base64 values
+func isJWT(match string) bool {
+	// the regular expression makes sure there's a dot after the header
+	header, err := base64.RawURLEncoding.DecodeString(match[:strings.IndexByte(match, '.')])
+	if err != nil {
+		return false
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(header, &fields); err != nil {
+		return false
+	}
+	_, ok := fields["alg"]
+	return ok
+}
+
+// isSSN rules out the area, group and serial numbers that are never assigned
+func isSSN(match string) bool {
+	area, _ := strconv.Atoi(match[0:3])
+	group, _ := strconv.Atoi(match[4:6])
+	serial, _ := strconv.Atoi(match[7:11])
+	return area != 0 && area != 666 && area < 900 && group != 0 && serial != 0
+}
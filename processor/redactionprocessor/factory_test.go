@@ -28,6 +28,8 @@ func TestDefaultConfiguration(t *testing.T) {
 	c := createDefaultConfig().(*Config)
 	assert.Empty(t, c.AllowedKeys)
 	assert.Empty(t, c.BlockedValues)
+	assert.Empty(t, c.Detectors)
+	assert.Equal(t, actionMask, c.Action)
 }
 
 func TestCreateTestProcessor(t *testing.T) {
@@ -40,3 +42,17 @@ func TestCreateTestProcessor(t *testing.T) {
 	assert.NotNil(t, tp)
 	assert.Equal(t, true, tp.Capabilities().MutatesData)
 }
+
+func TestCreateLogsAndMetricsProcessors(t *testing.T) {
+	cfg := createDefaultConfig()
+
+	lp, err := createLogsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, consumertest.NewNop())
+	assert.NoError(t, err)
+	assert.NotNil(t, lp)
+	assert.Equal(t, true, lp.Capabilities().MutatesData)
+
+	mp, err := createMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, consumertest.NewNop())
+	assert.NoError(t, err)
+	assert.NotNil(t, mp)
+	assert.Equal(t, true, mp.Capabilities().MutatesData)
+}
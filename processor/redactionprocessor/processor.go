@@ -16,6 +16,9 @@ package redactionprocessor // import "github.com/open-telemetry/opentelemetry-co
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"regexp"
 	"sort"
@@ -24,44 +27,41 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
 )
 
-var _ component.TracesProcessor = (*redaction)(nil)
-
 type redaction struct {
-	// Attribute keys allowed in a span
+	// Attribute keys allowed in a span, log record or data point
 	allowList map[string]string
-	// Attribute values blocked in a span
-	blockRegexList map[string]*regexp.Regexp
+	// Detectors of the values blocked in the attributes and log bodies
+	blockList []*detector
 	// Redaction processor configuration
 	config *Config
 	// Logger
 	logger *zap.Logger
-	// Next trace consumer in line
-	next consumer.Traces
 }
 
 // newRedaction creates a new instance of the redaction processor
-func newRedaction(ctx context.Context, config *Config, logger *zap.Logger, next consumer.Traces) (*redaction, error) {
+func newRedaction(ctx context.Context, config *Config, logger *zap.Logger) (*redaction, error) {
 	allowList := makeAllowList(config)
-	blockRegexList, err := makeBlockRegexList(ctx, config)
+	blockList, err := makeBlockList(ctx, config)
 	if err != nil {
 		// TODO: Placeholder for an error metric in the next PR
 		return nil, fmt.Errorf("failed to process block list: %w", err)
 	}
 
 	return &redaction{
-		allowList:      allowList,
-		blockRegexList: blockRegexList,
-		config:         config,
-		logger:         logger,
-		next:           next,
+		allowList: allowList,
+		blockList: blockList,
+		config:    config,
+		logger:    logger,
 	}, nil
 }
 
-// processTraces implements ProcessMetricsFunc. It processes the incoming data
+// processTraces implements ProcessTracesFunc. It processes the incoming data
 // and returns the data to be sent to the next component
 func (s *redaction) processTraces(ctx context.Context, batch ptrace.Traces) (ptrace.Traces, error) {
 	for i := 0; i < batch.ResourceSpans().Len(); i++ {
@@ -71,6 +71,26 @@ func (s *redaction) processTraces(ctx context.Context, batch ptrace.Traces) (ptr
 	return batch, nil
 }
 
+// processLogs implements ProcessLogsFunc. It processes the incoming data
+// and returns the data to be sent to the next component
+func (s *redaction) processLogs(ctx context.Context, logs plog.Logs) (plog.Logs, error) {
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		rl := logs.ResourceLogs().At(i)
+		s.processResourceLog(ctx, rl)
+	}
+	return logs, nil
+}
+
+// processMetrics implements ProcessMetricsFunc. It processes the incoming data
+// and returns the data to be sent to the next component
+func (s *redaction) processMetrics(ctx context.Context, metrics pmetric.Metrics) (pmetric.Metrics, error) {
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		rm := metrics.ResourceMetrics().At(i)
+		s.processResourceMetric(ctx, rm)
+	}
+	return metrics, nil
+}
+
 // processResourceSpan processes the RS and all of its spans and then returns the last
 // view metric context. The context can be used for tests
 func (s *redaction) processResourceSpan(ctx context.Context, rs ptrace.ResourceSpans) {
@@ -91,16 +111,97 @@ func (s *redaction) processResourceSpan(ctx context.Context, rs ptrace.ResourceS
 	}
 }
 
-// processAttrs redacts the attributes of a resource span or a span
-func (s *redaction) processAttrs(_ context.Context, attributes *pcommon.Map) {
+// processResourceLog processes the resource and all of its log records
+func (s *redaction) processResourceLog(ctx context.Context, rl plog.ResourceLogs) {
+	rlAttrs := rl.Resource().Attributes()
+	s.processAttrs(ctx, &rlAttrs)
+
+	for j := 0; j < rl.ScopeLogs().Len(); j++ {
+		ils := rl.ScopeLogs().At(j)
+		for k := 0; k < ils.LogRecords().Len(); k++ {
+			log := ils.LogRecords().At(k)
+			logAttrs := log.Attributes()
+
+			s.processAttrs(ctx, &logAttrs)
+			s.processBody(ctx, log.Body())
+		}
+	}
+}
+
+// processResourceMetric processes the resource and the data points of all of
+// its metrics
+func (s *redaction) processResourceMetric(ctx context.Context, rm pmetric.ResourceMetrics) {
+	rmAttrs := rm.Resource().Attributes()
+	s.redactAttrs(ctx, &rmAttrs)
+
+	for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+		ils := rm.ScopeMetrics().At(j)
+		for k := 0; k < ils.Metrics().Len(); k++ {
+			s.processMetricAttrs(ctx, ils.Metrics().At(k))
+		}
+	}
+}
+
+// processMetricAttrs redacts the attributes of every data point of a metric,
+// as there are no attributes at the metric level
+func (s *redaction) processMetricAttrs(ctx context.Context, m pmetric.Metric) {
+	switch m.DataType() {
+	case pmetric.MetricDataTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			attrs := dps.At(i).Attributes()
+			s.redactAttrs(ctx, &attrs)
+		}
+	case pmetric.MetricDataTypeSum:
+		dps := m.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			attrs := dps.At(i).Attributes()
+			s.redactAttrs(ctx, &attrs)
+		}
+	case pmetric.MetricDataTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			attrs := dps.At(i).Attributes()
+			s.redactAttrs(ctx, &attrs)
+		}
+	case pmetric.MetricDataTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			attrs := dps.At(i).Attributes()
+			s.redactAttrs(ctx, &attrs)
+		}
+	case pmetric.MetricDataTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			attrs := dps.At(i).Attributes()
+			s.redactAttrs(ctx, &attrs)
+		}
+	}
+}
+
+// processAttrs redacts the attributes of a resource, a span or a log record,
+// adding the diagnostic information about the redaction to them
+func (s *redaction) processAttrs(ctx context.Context, attributes *pcommon.Map) {
+	toDelete, toBlock := s.redactAttrs(ctx, attributes)
+	s.summarizeRedactedSpan(toDelete, attributes)
+	s.summarizeMaskedSpan(toBlock, attributes)
+}
+
+// redactAttrs redacts the attributes and returns the keys of the deleted and
+// masked ones. It is used on its own for the metrics, as adding the
+// diagnostic attributes to their resources or data points would change the
+// identity of their series
+func (s *redaction) redactAttrs(_ context.Context, attributes *pcommon.Map) ([]string, []string) {
 	// TODO: Use the context for recording metrics
 	var toDelete []string
 	var toBlock []string
+	var toDrop []string
 
 	// Identify attributes to redact and mask in the following sequence
 	// 1. Make a list of attribute keys to redact
 	// 2. Mask any blocked values for the other attributes
-	// 3. Delete the attributes from 1
+	// 3. Delete the attributes from 1, and the ones with blocked values when
+	//    they are dropped
 	//
 	// This sequence satisfies these performance constraints:
 	// - Only range through all attributes once
@@ -116,15 +217,18 @@ func (s *redaction) processAttrs(_ context.Context, attributes *pcommon.Map) {
 		}
 
 		// Mask any blocked values for the other attributes
-		for _, compiledRE := range s.blockRegexList {
-			match := compiledRE.MatchString(value.StringVal())
-			if match {
-				toBlock = append(toBlock, k)
-
-				valueCopy := value.StringVal()
-				maskedValue := compiledRE.ReplaceAllString(valueCopy, "****")
-				attributes.Update(k, pcommon.NewValueString(maskedValue))
-			}
+		if value.Type() != pcommon.ValueTypeString {
+			return true
+		}
+		redactedValue, blocked := s.redactValue(value.StringVal())
+		if !blocked {
+			return true
+		}
+		toBlock = append(toBlock, k)
+		if s.config.Action == actionDrop {
+			toDrop = append(toDrop, k)
+		} else {
+			attributes.Update(k, pcommon.NewValueString(redactedValue))
 		}
 		return true
 	})
@@ -133,20 +237,54 @@ func (s *redaction) processAttrs(_ context.Context, attributes *pcommon.Map) {
 	for _, k := range toDelete {
 		attributes.Remove(k)
 	}
-	// Add diagnostic information to the span
-	s.summarizeRedactedSpan(toDelete, attributes)
-	s.summarizeMaskedSpan(toBlock, attributes)
+	for _, k := range toDrop {
+		attributes.Remove(k)
+	}
+	return toDelete, toBlock
 }
 
-// ConsumeTraces implements the SpanProcessor interface
-func (s *redaction) ConsumeTraces(ctx context.Context, batch ptrace.Traces) error {
-	batch, err := s.processTraces(ctx, batch)
-	if err != nil {
-		return err
+// processBody redacts the blocked values of a log body. Only string bodies are
+// checked, the structured ones are left as they are
+func (s *redaction) processBody(_ context.Context, body pcommon.Value) {
+	if body.Type() != pcommon.ValueTypeString {
+		return
+	}
+	redactedValue, blocked := s.redactValue(body.StringVal())
+	if !blocked {
+		return
 	}
+	if s.config.Action == actionDrop {
+		pcommon.NewValueEmpty().CopyTo(body)
+	} else {
+		body.SetStringVal(redactedValue)
+	}
+}
 
-	err = s.next.ConsumeTraces(ctx, batch)
-	return err
+// redactValue replaces the parts of a value matched by the block list
+// according to the configured action. It reports whether any part matched
+func (s *redaction) redactValue(value string) (string, bool) {
+	blocked := false
+	for _, d := range s.blockList {
+		value = d.re.ReplaceAllStringFunc(value, func(match string) string {
+			if !d.matches(match) {
+				return match
+			}
+			blocked = true
+			return s.replacement(match)
+		})
+	}
+	return value, blocked
+}
+
+// replacement returns the value replacing a blocked match
+func (s *redaction) replacement(match string) string {
+	if s.config.Action != actionHash {
+		return "****"
+	}
+	mac := hmac.New(sha256.New, []byte(s.config.HashSalt))
+	// writing to a hash never fails
+	_, _ = mac.Write([]byte(match))
+	return hex.EncodeToString(mac.Sum(nil))
 }
 
 // summarizeRedactedSpan adds diagnostic information about redacted attribute keys
@@ -190,7 +328,7 @@ const (
 	maskedValueCount = "redaction.masked.count"
 )
 
-// makeAllowList sets up a lookup table of allowed span attribute keys
+// makeAllowList sets up a lookup table of allowed attribute keys
 func makeAllowList(c *Config) map[string]string {
 	// redactionKeys are additional span attributes created by the processor to
 	// summarize the changes it made to a span. If the processor removes
@@ -216,18 +354,26 @@ func makeAllowList(c *Config) map[string]string {
 	return allowList
 }
 
-// makeBlockRegexList precompiles all the blocked regex patterns
-func makeBlockRegexList(_ context.Context, config *Config) (map[string]*regexp.Regexp, error) {
-	blockRegexList := make(map[string]*regexp.Regexp, len(config.BlockedValues))
+// makeBlockList precompiles all the blocked regex patterns and adds the
+// enabled named detectors
+func makeBlockList(_ context.Context, config *Config) ([]*detector, error) {
+	blockList := make([]*detector, 0, len(config.BlockedValues)+len(config.Detectors))
 	for _, pattern := range config.BlockedValues {
 		re, err := regexp.Compile(pattern)
 		if err != nil {
 			// TODO: Placeholder for an error metric in the next PR
 			return nil, fmt.Errorf("error compiling regex in block list: %w", err)
 		}
-		blockRegexList[pattern] = re
+		blockList = append(blockList, &detector{re: re})
+	}
+	for _, name := range config.Detectors {
+		d, ok := detectors[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown detector %q", name)
+		}
+		blockList = append(blockList, d)
 	}
-	return blockRegexList, nil
+	return blockList, nil
 }
 
 // Capabilities specifies what this processor does, such as whether it mutates data
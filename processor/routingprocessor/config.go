@@ -42,7 +42,7 @@ type Config struct {
 	// this could be the HTTP/gRPC header from the original request/RPC. Typically, aggregation processors (batch, groupbytrace)
 	// will create a new context, so, those should be avoided when using this processor.Although the HTTP spec allows headers to be repeated,
 	// this processor will only use the first value.
-	// Required.
+	// Required when the routing table contains items with a value.
 	FromAttribute string `mapstructure:"from_attribute"`
 
 	// DropRoutingResourceAttribute controls whether to remove the resource attribute used for routing.
@@ -57,15 +57,28 @@ type Config struct {
 
 // Validate checks if the processor configuration is valid.
 func (c *Config) Validate() error {
-	// validate that every route has a value for the routing attribute and has
-	// at least one exporter
+	// validate that every route has a value for the routing attribute or a
+	// statement, and has at least one exporter
+	hasValueRoutes := false
 	for _, item := range c.Table {
-		if len(item.Value) == 0 {
+		if len(item.Value) == 0 && len(item.Statement) == 0 {
 			return fmt.Errorf("invalid (empty) route : %w", errEmptyRoute)
 		}
 
+		if len(item.Value) != 0 && len(item.Statement) != 0 {
+			return fmt.Errorf("invalid route %s: %w", item.Value, errValueAndStatement)
+		}
+
+		if len(item.Statement) != 0 {
+			if _, err := parseStatement(item.Statement); err != nil {
+				return fmt.Errorf("invalid route %s: %w", item.Statement, err)
+			}
+		} else {
+			hasValueRoutes = true
+		}
+
 		if len(item.Exporters) == 0 {
-			return fmt.Errorf("invalid route %s: %w", item.Value, errNoExporters)
+			return fmt.Errorf("invalid route %s: %w", item.route(), errNoExporters)
 		}
 	}
 
@@ -74,8 +87,8 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid routing table: %w", errNoTableItems)
 	}
 
-	// we also need a "FromAttribute" value
-	if len(c.FromAttribute) == 0 {
+	// we also need a "FromAttribute" value when routing based on values
+	if hasValueRoutes && len(c.FromAttribute) == 0 {
 		return fmt.Errorf(
 			"invalid attribute to read the route's value from: %w",
 			errNoMissingFromAttribute,
@@ -100,12 +113,27 @@ const (
 
 // RoutingTableItem specifies how data should be routed to the different exporters
 type RoutingTableItem struct {
-	// Value represents a possible value for the field specified under FromAttribute. Required.
+	// Value represents a possible value for the field specified under FromAttribute.
+	// Either Value or Statement is required.
 	Value string `mapstructure:"value"`
 
+	// Statement is a TQL statement that is evaluated against each resource, for example:
+	// route() where resource.attributes["k8s.namespace.name"] == "payments"
+	// Statements are evaluated in the order they appear in the table and the
+	// first matching one is used. Either Value or Statement is required.
+	Statement string `mapstructure:"statement"`
+
 	// Exporters contains the list of exporters to use when the value from the FromAttribute field matches this table item.
 	// When no exporters are specified, the ones specified under DefaultExporters are used, if any.
 	// The routing processor will fail upon the first failure from these exporters.
 	// Optional.
 	Exporters []string `mapstructure:"exporters"`
 }
+
+// route returns the identifier of this table item, which is either its value or its statement.
+func (i RoutingTableItem) route() string {
+	if len(i.Statement) != 0 {
+		return i.Statement
+	}
+	return i.Value
+}
@@ -86,6 +86,24 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			configPath: "config_statements.yaml",
+			expected: &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				DefaultExporters:  []string{"otlp"},
+				AttributeSource:   "context",
+				Table: []RoutingTableItem{
+					{
+						Statement: `route() where resource.attributes["k8s.namespace.name"] == "payments" and resource.attributes["env"] != "dev"`,
+						Exporters: []string{"otlp/payments"},
+					},
+					{
+						Statement: `route() where resource.attributes["env"] == "dev"`,
+						Exporters: []string{"otlp/dev"},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range testcases {
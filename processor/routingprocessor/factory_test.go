@@ -115,6 +115,58 @@ func TestProcessorFailsWithNoFromAttribute(t *testing.T) {
 	assert.ErrorIs(t, cfg.Validate(), errNoMissingFromAttribute)
 }
 
+func TestProcessorFailsWithValueAndStatement(t *testing.T) {
+	cfg := &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		DefaultExporters:  []string{"otlp"},
+		FromAttribute:     "X-Tenant",
+		Table: []RoutingTableItem{
+			{
+				Value:     "acme",
+				Statement: `route() where resource.attributes["X-Tenant"] == "acme"`,
+				Exporters: []string{"otlp"},
+			},
+		},
+	}
+	assert.ErrorIs(t, cfg.Validate(), errValueAndStatement)
+}
+
+func TestProcessorFailsWithInvalidStatement(t *testing.T) {
+	for _, statement := range []string{
+		`route() where`,
+		`route() where attributes["X-Tenant"] == "acme"`,
+		`set(resource.attributes["X-Tenant"], "acme")`,
+	} {
+		t.Run(statement, func(t *testing.T) {
+			cfg := &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				DefaultExporters:  []string{"otlp"},
+				Table: []RoutingTableItem{
+					{
+						Statement: statement,
+						Exporters: []string{"otlp"},
+					},
+				},
+			}
+			assert.Error(t, cfg.Validate())
+		})
+	}
+}
+
+func TestProcessorWithStatementsDoesNotRequireFromAttribute(t *testing.T) {
+	cfg := &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		DefaultExporters:  []string{"otlp"},
+		Table: []RoutingTableItem{
+			{
+				Statement: `route() where resource.attributes["X-Tenant"] == "acme"`,
+				Exporters: []string{"otlp"},
+			},
+		},
+	}
+	assert.NoError(t, cfg.Validate())
+}
+
 func TestShouldNotFailWhenNextIsProcessor(t *testing.T) {
 	// prepare
 	factory := NewFactory()
@@ -30,6 +30,7 @@ import (
 var (
 	errEmptyRoute                   = errors.New("empty routing attribute provided")
 	errNoExporters                  = errors.New("no exporters defined for the route")
+	errValueAndStatement            = errors.New("both value and statement defined for the route")
 	errNoTableItems                 = errors.New("the routing table is empty")
 	errNoMissingFromAttribute       = errors.New("the FromAttribute property is empty")
 	errDefaultExporterNotFound      = errors.New("default exporter not found")
@@ -85,7 +86,10 @@ func (e *processorImp) Start(_ context.Context, host component.Host) error {
 		len(e.metricsRouter.exporters) == 0 &&
 		len(e.metricsRouter.defaultExporters) == 0 &&
 		len(e.logsRouter.exporters) == 0 &&
-		len(e.logsRouter.defaultExporters) == 0 {
+		len(e.logsRouter.defaultExporters) == 0 &&
+		len(e.tracesRouter.statementRoutes) == 0 &&
+		len(e.metricsRouter.statementRoutes) == 0 &&
+		len(e.logsRouter.statementRoutes) == 0 {
 		return errNoExportersAfterRegistration
 	}
 
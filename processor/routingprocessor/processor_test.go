@@ -399,6 +399,152 @@ func TestTraces_RoutingWorks_ResourceAttribute_DropsRoutingAttribute(t *testing.
 	assert.Equal(t, "acme", v.StringVal())
 }
 
+func TestTraces_RoutingWorks_Statement(t *testing.T) {
+	defaultExp := &mockTracesExporter{}
+	paymentsExp := &mockTracesExporter{}
+	prodExp := &mockTracesExporter{}
+
+	host := &mockHost{
+		Host: componenttest.NewNopHost(),
+		GetExportersFunc: func() map[config.DataType]map[config.ComponentID]component.Exporter {
+			return map[config.DataType]map[config.ComponentID]component.Exporter{
+				config.TracesDataType: {
+					config.NewComponentID("otlp"):          defaultExp,
+					config.NewComponentID("otlp/payments"): paymentsExp,
+					config.NewComponentID("otlp/prod"):     prodExp,
+				},
+			}
+		},
+	}
+
+	exp := newProcessor(zap.NewNop(), &Config{
+		DefaultExporters: []string{"otlp"},
+		Table: []RoutingTableItem{
+			{
+				Statement: `route() where resource.attributes["k8s.namespace.name"] == "payments" and resource.attributes["env"] != "dev"`,
+				Exporters: []string{"otlp/payments"},
+			},
+			{
+				Statement: `route() where resource.attributes["env"] == "prod"`,
+				Exporters: []string{"otlp/prod"},
+			},
+		},
+	})
+	require.NoError(t, exp.Start(context.Background(), host))
+
+	tr := ptrace.NewTraces()
+
+	rs := tr.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("k8s.namespace.name", "payments")
+	rs.Resource().Attributes().InsertString("env", "prod")
+	rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("payments")
+
+	rs = tr.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("k8s.namespace.name", "checkout")
+	rs.Resource().Attributes().InsertString("env", "prod")
+	rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("checkout")
+
+	rs = tr.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("k8s.namespace.name", "payments")
+	rs.Resource().Attributes().InsertString("env", "dev")
+	rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("payments-dev")
+
+	require.NoError(t, exp.ConsumeTraces(context.Background(), tr))
+
+	// the first matching statement wins, even if the following ones match as well
+	require.Len(t, paymentsExp.AllTraces(), 1)
+	assert.Equal(t, 1, paymentsExp.AllTraces()[0].ResourceSpans().Len())
+	assert.Equal(t, "payments", paymentsExp.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+
+	require.Len(t, prodExp.AllTraces(), 1)
+	assert.Equal(t, 1, prodExp.AllTraces()[0].ResourceSpans().Len())
+	assert.Equal(t, "checkout", prodExp.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+
+	require.Len(t, defaultExp.AllTraces(), 1)
+	assert.Equal(t, 1, defaultExp.AllTraces()[0].ResourceSpans().Len())
+	assert.Equal(t, "payments-dev", defaultExp.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+}
+
+func TestTraces_RoutingWorks_StatementAndValue(t *testing.T) {
+	defaultExp := &mockTracesExporter{}
+	sExp := &mockTracesExporter{}
+	vExp := &mockTracesExporter{}
+
+	host := &mockHost{
+		Host: componenttest.NewNopHost(),
+		GetExportersFunc: func() map[config.DataType]map[config.ComponentID]component.Exporter {
+			return map[config.DataType]map[config.ComponentID]component.Exporter{
+				config.TracesDataType: {
+					config.NewComponentID("otlp"):   defaultExp,
+					config.NewComponentID("otlp/2"): sExp,
+					config.NewComponentID("otlp/3"): vExp,
+				},
+			}
+		},
+	}
+
+	exp := newProcessor(zap.NewNop(), &Config{
+		FromAttribute:    "X-Tenant",
+		AttributeSource:  contextAttributeSource,
+		DefaultExporters: []string{"otlp"},
+		Table: []RoutingTableItem{
+			{
+				Statement: `route() where resource.attributes["env"] == "prod"`,
+				Exporters: []string{"otlp/2"},
+			},
+			{
+				Value:     "acme",
+				Exporters: []string{"otlp/3"},
+			},
+		},
+	})
+	require.NoError(t, exp.Start(context.Background(), host))
+
+	tr := ptrace.NewTraces()
+	tr.ResourceSpans().AppendEmpty().Resource().Attributes().InsertString("env", "prod")
+	tr.ResourceSpans().AppendEmpty().Resource().Attributes().InsertString("env", "dev")
+
+	require.NoError(t, exp.ConsumeTraces(
+		metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+			"X-Tenant": "acme",
+		})),
+		tr,
+	))
+
+	assert.Len(t, sExp.AllTraces(), 1,
+		"resource matching the statement should be routed to the statement's exporter",
+	)
+	assert.Len(t, vExp.AllTraces(), 1,
+		"resource not matching the statement should be routed based on the value",
+	)
+	assert.Len(t, defaultExp.AllTraces(), 0,
+		"nothing should be routed to the default exporter",
+	)
+}
+
+func TestTraces_InvalidStatement(t *testing.T) {
+	host := &mockHost{
+		Host: componenttest.NewNopHost(),
+		GetExportersFunc: func() map[config.DataType]map[config.ComponentID]component.Exporter {
+			return map[config.DataType]map[config.ComponentID]component.Exporter{
+				config.TracesDataType: {
+					config.NewComponentID("otlp"): &mockTracesExporter{},
+				},
+			}
+		},
+	}
+
+	exp := newProcessor(zap.NewNop(), &Config{
+		Table: []RoutingTableItem{
+			{
+				Statement: `route() where attributes["env"] == "prod"`,
+				Exporters: []string{"otlp"},
+			},
+		},
+	})
+	assert.Error(t, exp.Start(context.Background(), host))
+}
+
 func TestProcessorCapabilities(t *testing.T) {
 	// prepare
 	config := &Config{
@@ -649,6 +795,50 @@ func TestMetrics_RoutingWorks_ResourceAttribute_DropsRoutingAttribute(t *testing
 	assert.Equal(t, "acme", v.StringVal())
 }
 
+func TestMetrics_RoutingWorks_Statement(t *testing.T) {
+	defaultExp := &mockMetricsExporter{}
+	mExp := &mockMetricsExporter{}
+
+	host := &mockHost{
+		Host: componenttest.NewNopHost(),
+		GetExportersFunc: func() map[config.DataType]map[config.ComponentID]component.Exporter {
+			return map[config.DataType]map[config.ComponentID]component.Exporter{
+				config.MetricsDataType: {
+					config.NewComponentID("otlp"):   defaultExp,
+					config.NewComponentID("otlp/2"): mExp,
+				},
+			}
+		},
+	}
+
+	exp := newProcessor(zap.NewNop(), &Config{
+		DefaultExporters: []string{"otlp"},
+		Table: []RoutingTableItem{
+			{
+				Statement: `route() where resource.attributes["k8s.namespace.name"] == "payments"`,
+				Exporters: []string{"otlp/2"},
+			},
+		},
+	})
+	require.NoError(t, exp.Start(context.Background(), host))
+
+	m := pmetric.NewMetrics()
+	m.ResourceMetrics().AppendEmpty().Resource().Attributes().InsertString("k8s.namespace.name", "payments")
+	m.ResourceMetrics().AppendEmpty().Resource().Attributes().InsertString("k8s.namespace.name", "payments")
+	m.ResourceMetrics().AppendEmpty().Resource().Attributes().InsertString("k8s.namespace.name", "checkout")
+
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), m))
+
+	require.Len(t, mExp.AllMetrics(), 1,
+		"matching resources should be grouped and routed to non default exporter",
+	)
+	assert.Equal(t, 2, mExp.AllMetrics()[0].ResourceMetrics().Len())
+	require.Len(t, defaultExp.AllMetrics(), 1,
+		"non matching resource should be routed to default exporter",
+	)
+	assert.Equal(t, 1, defaultExp.AllMetrics()[0].ResourceMetrics().Len())
+}
+
 func TestLogs_RoutingWorks_Context(t *testing.T) {
 	defaultExp := &mockLogsExporter{}
 	lExp := &mockLogsExporter{}
@@ -875,6 +1065,49 @@ func TestLogs_AreCorrectlySplitPerResourceAttributeRouting(t *testing.T) {
 	)
 }
 
+func TestLogs_RoutingWorks_Statement(t *testing.T) {
+	defaultExp := &mockLogsExporter{}
+	lExp := &mockLogsExporter{}
+
+	host := &mockHost{
+		Host: componenttest.NewNopHost(),
+		GetExportersFunc: func() map[config.DataType]map[config.ComponentID]component.Exporter {
+			return map[config.DataType]map[config.ComponentID]component.Exporter{
+				config.LogsDataType: {
+					config.NewComponentID("otlp"):   defaultExp,
+					config.NewComponentID("otlp/2"): lExp,
+				},
+			}
+		},
+	}
+
+	exp := newProcessor(zap.NewNop(), &Config{
+		DefaultExporters: []string{"otlp"},
+		Table: []RoutingTableItem{
+			{
+				Statement: `route() where resource.attributes["env"] != "dev"`,
+				Exporters: []string{"otlp/2"},
+			},
+		},
+	})
+	require.NoError(t, exp.Start(context.Background(), host))
+
+	l := plog.NewLogs()
+	l.ResourceLogs().AppendEmpty().Resource().Attributes().InsertString("env", "prod")
+	l.ResourceLogs().AppendEmpty().Resource().Attributes().InsertString("env", "dev")
+
+	require.NoError(t, exp.ConsumeLogs(context.Background(), l))
+
+	require.Len(t, lExp.AllLogs(), 1,
+		"matching resource should be routed to non default exporter",
+	)
+	assert.Equal(t, 1, lExp.AllLogs()[0].ResourceLogs().Len())
+	require.Len(t, defaultExp.AllLogs(), 1,
+		"non matching resource should be routed to default exporter",
+	)
+	assert.Equal(t, 1, defaultExp.AllLogs()[0].ResourceLogs().Len())
+}
+
 func Benchmark_MetricsRouting_ResourceAttribute(b *testing.B) {
 	cfg := &Config{
 		FromAttribute:    "X-Tenant",
@@ -26,6 +26,8 @@ import (
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
 )
 
 // router routes logs, metrics and traces using the configured attributes and
@@ -41,6 +43,15 @@ type router[E component.Exporter] struct {
 
 	defaultExporters []E
 	exporters        map[string][]E
+	statementRoutes  []statementRoute[E]
+}
+
+// statementRoute is a routing table item that routes the resources matching
+// its statement's condition to its exporters.
+type statementRoute[E component.Exporter] struct {
+	statement string
+	query     tql.Query
+	exporters []E
 }
 
 // newRouter creates a new router instance with its type parameter constrained
@@ -67,6 +78,11 @@ type routedSignal[E component.Exporter, S signal] struct {
 }
 
 func (r *router[E]) RouteMetrics(ctx context.Context, tm pmetric.Metrics) []routedSignal[E, pmetric.Metrics] {
+	// statements are evaluated against each resource
+	if len(r.statementRoutes) > 0 {
+		return r.routeMetricsForResource(ctx, tm)
+	}
+
 	switch r.config.AttributeSource {
 	case resourceAttributeSource:
 		return r.routeMetricsForResource(ctx, tm)
@@ -81,7 +97,39 @@ func (r *router[E]) removeRoutingAttribute(resource pcommon.Resource) {
 	resource.Attributes().Remove(r.config.FromAttribute)
 }
 
-func (r *router[E]) routeMetricsForResource(_ context.Context, tm pmetric.Metrics) []routedSignal[E, pmetric.Metrics] {
+// routeResource returns the route and the exporters for the given resource.
+// The statement routes are evaluated first, in the order they were configured,
+// and the first matching one wins. Otherwise the value of the routing attribute
+// is looked up in the routing table, falling back to the default exporters.
+func (r *router[E]) routeResource(ctx context.Context, resource pcommon.Resource) (string, []E) {
+	if len(r.statementRoutes) > 0 {
+		tCtx := newResourceContext(resource)
+		for _, sr := range r.statementRoutes {
+			if sr.query.Condition(tCtx) {
+				return sr.statement, sr.exporters
+			}
+		}
+	}
+
+	var attrValue string
+	if r.config.AttributeSource == resourceAttributeSource {
+		attrValue = r.extractor.extractAttrFromResource(resource)
+	} else {
+		attrValue = r.extractor.extractFromContext(ctx)
+	}
+
+	// If we have an exporter list defined for that attribute value then use it.
+	if e, ok := r.exporters[attrValue]; ok {
+		if r.config.DropRoutingResourceAttribute {
+			r.removeRoutingAttribute(resource)
+		}
+		return attrValue, e
+	}
+
+	return attrValue, r.defaultExporters
+}
+
+func (r *router[E]) routeMetricsForResource(ctx context.Context, tm pmetric.Metrics) []routedSignal[E, pmetric.Metrics] {
 	// routingEntry is used to group pmetric.ResourceMetrics that are routed to
 	// the same set of exporters.
 	// This way we're not ending up with all the metrics split up which would cause
@@ -95,15 +143,7 @@ func (r *router[E]) routeMetricsForResource(_ context.Context, tm pmetric.Metric
 	for i := 0; i < resMetricsSlice.Len(); i++ {
 		resMetrics := resMetricsSlice.At(i)
 
-		attrValue := r.extractor.extractAttrFromResource(resMetrics.Resource())
-		exp := r.defaultExporters
-		// If we have an exporter list defined for that attribute value then use it.
-		if e, ok := r.exporters[attrValue]; ok {
-			exp = e
-			if r.config.DropRoutingResourceAttribute {
-				r.removeRoutingAttribute(resMetrics.Resource())
-			}
-		}
+		attrValue, exp := r.routeResource(ctx, resMetrics.Resource())
 
 		if rEntry, ok := routingMap[attrValue]; ok {
 			resMetrics.MoveTo(rEntry.resMetrics.AppendEmpty())
@@ -156,6 +196,11 @@ func (r *router[E]) routeMetricsForContext(ctx context.Context, tm pmetric.Metri
 }
 
 func (r *router[E]) RouteTraces(ctx context.Context, tr ptrace.Traces) []routedSignal[E, ptrace.Traces] {
+	// statements are evaluated against each resource
+	if len(r.statementRoutes) > 0 {
+		return r.routeTracesForResource(ctx, tr)
+	}
+
 	switch r.config.AttributeSource {
 	case resourceAttributeSource:
 		return r.routeTracesForResource(ctx, tr)
@@ -166,7 +211,7 @@ func (r *router[E]) RouteTraces(ctx context.Context, tr ptrace.Traces) []routedS
 	}
 }
 
-func (r *router[E]) routeTracesForResource(_ context.Context, tr ptrace.Traces) []routedSignal[E, ptrace.Traces] {
+func (r *router[E]) routeTracesForResource(ctx context.Context, tr ptrace.Traces) []routedSignal[E, ptrace.Traces] {
 	// routingEntry is used to group ptrace.ResourceSpans that are routed to
 	// the same set of exporters.
 	// This way we're not ending up with all the logs split up which would cause
@@ -180,15 +225,7 @@ func (r *router[E]) routeTracesForResource(_ context.Context, tr ptrace.Traces)
 	for i := 0; i < resSpansSlice.Len(); i++ {
 		resSpans := resSpansSlice.At(i)
 
-		attrValue := r.extractor.extractAttrFromResource(resSpans.Resource())
-		exp := r.defaultExporters
-		// If we have an exporter list defined for that attribute value then use it.
-		if e, ok := r.exporters[attrValue]; ok {
-			exp = e
-			if r.config.DropRoutingResourceAttribute {
-				r.removeRoutingAttribute(resSpans.Resource())
-			}
-		}
+		attrValue, exp := r.routeResource(ctx, resSpans.Resource())
 
 		if rEntry, ok := routingMap[attrValue]; ok {
 			resSpans.MoveTo(rEntry.resSpans.AppendEmpty())
@@ -241,6 +278,11 @@ func (r *router[E]) routeTracesForContext(ctx context.Context, tr ptrace.Traces)
 }
 
 func (r *router[E]) RouteLogs(ctx context.Context, tl plog.Logs) []routedSignal[E, plog.Logs] {
+	// statements are evaluated against each resource
+	if len(r.statementRoutes) > 0 {
+		return r.routeLogsForResource(ctx, tl)
+	}
+
 	switch r.config.AttributeSource {
 	case resourceAttributeSource:
 		return r.routeLogsForResource(ctx, tl)
@@ -251,7 +293,7 @@ func (r *router[E]) RouteLogs(ctx context.Context, tl plog.Logs) []routedSignal[
 	}
 }
 
-func (r *router[E]) routeLogsForResource(_ context.Context, tl plog.Logs) []routedSignal[E, plog.Logs] {
+func (r *router[E]) routeLogsForResource(ctx context.Context, tl plog.Logs) []routedSignal[E, plog.Logs] {
 	// routingEntry is used to group plog.ResourceLogs that are routed to
 	// the same set of exporters.
 	// This way we're not ending up with all the logs split up which would cause
@@ -265,15 +307,7 @@ func (r *router[E]) routeLogsForResource(_ context.Context, tl plog.Logs) []rout
 	for i := 0; i < resLogsSlice.Len(); i++ {
 		resLogs := resLogsSlice.At(i)
 
-		attrValue := r.extractor.extractAttrFromResource(resLogs.Resource())
-		exp := r.defaultExporters
-		// If we have an exporter list defined for that attribute value then use it.
-		if e, ok := r.exporters[attrValue]; ok {
-			exp = e
-			if r.config.DropRoutingResourceAttribute {
-				r.removeRoutingAttribute(resLogs.Resource())
-			}
-		}
+		attrValue, exp := r.routeResource(ctx, resLogs.Resource())
 
 		if rEntry, ok := routingMap[attrValue]; ok {
 			resLogs.MoveTo(rEntry.resLogs.AppendEmpty())
@@ -359,8 +393,14 @@ func (r *router[E]) registerExporters(exporters map[config.ComponentID]component
 		return err
 	}
 
-	// exporters for each defined value
+	// exporters for each defined value or statement
 	for _, item := range r.config.Table {
+		if len(item.Statement) != 0 {
+			if err := r.registerExportersForStatement(item.Statement, available, item.Exporters); err != nil {
+				return err
+			}
+			continue
+		}
 		if err := r.registerExportersForRoute(item.Value, available, item.Exporters); err != nil {
 			return err
 		}
@@ -408,3 +448,39 @@ func (r *router[E]) registerExportersForRoute(
 
 	return nil
 }
+
+// registerExportersForStatement parses the provided statement and registers it
+// with the requested exporters using the provided available exporters map to
+// check if they were available.
+func (r *router[E]) registerExportersForStatement(
+	statement string,
+	available map[string]component.Exporter,
+	requested []string,
+) error {
+	r.logger.Debug("Registering exporter for statement",
+		zap.String("statement", statement),
+		zap.Any("requested", requested),
+	)
+
+	query, err := parseStatement(statement)
+	if err != nil {
+		return fmt.Errorf("error parsing statement %q: %w", statement, err)
+	}
+
+	sr := statementRoute[E]{
+		statement: statement,
+		query:     query,
+	}
+	for _, exp := range requested {
+		v, ok := available[exp]
+		if !ok {
+			return fmt.Errorf("error registering statement %q for exporter %q: %w",
+				statement, exp, errExporterNotFound,
+			)
+		}
+		sr.exporters = append(sr.exporters, v.(E))
+	}
+	r.statementRoutes = append(r.statementRoutes, sr)
+
+	return nil
+}
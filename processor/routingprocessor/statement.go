@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/routingprocessor"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqllogs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+// routingFunctions are the only functions that can be invoked by a routing statement.
+var routingFunctions = map[string]interface{}{
+	"route": route,
+}
+
+// route is a no-op function, the actual routing is done by the router based
+// on the statement's condition.
+func route() (tql.ExprFunc, error) {
+	return func(ctx tql.TransformContext) interface{} {
+		return true
+	}, nil
+}
+
+// parseStatement parses a routing statement, e.g.:
+// route() where resource.attributes["env"] == "prod"
+func parseStatement(statement string) (tql.Query, error) {
+	queries, err := tql.ParseQueries([]string{statement}, routingFunctions, parseResourcePath, tqllogs.ParseEnum)
+	if err != nil {
+		return tql.Query{}, err
+	}
+	return queries[0], nil
+}
+
+// parseResourcePath only accepts paths pointing to the resource, as the routing
+// decision is made once per resource.
+func parseResourcePath(val *tql.Path) (tql.GetSetter, error) {
+	if val == nil || len(val.Fields) == 0 || val.Fields[0].Name != "resource" {
+		return nil, errors.New("only resource paths are supported in routing statements")
+	}
+	return tqllogs.ParsePath(val)
+}
+
+// resourceContext is a transform context holding only the resource the
+// routing statements are evaluated for. The paths parsed by parseResourcePath
+// only access the resource of the context, and the route function ignores it,
+// so the context has no item or instrumentation scope.
+type resourceContext struct {
+	resource pcommon.Resource
+}
+
+var _ tql.TransformContext = resourceContext{}
+
+// newResourceContext creates a transform context that can be used to evaluate
+// routing statements for the given resource.
+func newResourceContext(resource pcommon.Resource) tql.TransformContext {
+	return resourceContext{resource: resource}
+}
+
+func (ctx resourceContext) GetItem() interface{} {
+	return nil
+}
+
+func (ctx resourceContext) GetInstrumentationScope() pcommon.InstrumentationScope {
+	return pcommon.NewInstrumentationScope()
+}
+
+func (ctx resourceContext) GetResource() pcommon.Resource {
+	return ctx.resource
+}
@@ -39,7 +39,7 @@ func getAndSubPolicyEvaluator(logger *zap.Logger, cfg *AndSubPolicyCfg) (samplin
 		return sampling.NewAlwaysSample(logger), nil
 	case NumericAttribute:
 		nafCfg := cfg.NumericAttributeCfg
-		return sampling.NewNumericAttributeFilter(logger, nafCfg.Key, nafCfg.MinValue, nafCfg.MaxValue), nil
+		return sampling.NewNumericAttributeFilter(logger, nafCfg.Key, nafCfg.MinValue, nafCfg.MaxValue, nafCfg.InvertMatch), nil
 	case StringAttribute:
 		safCfg := cfg.StringAttributeCfg
 		return sampling.NewStringAttributeFilter(logger, safCfg.Key, safCfg.Values, safCfg.EnabledRegexMatching, safCfg.CacheMaxSize, safCfg.InvertMatch), nil
@@ -57,6 +57,9 @@ func getAndSubPolicyEvaluator(logger *zap.Logger, cfg *AndSubPolicyCfg) (samplin
 	case SpanCount:
 		scfCfg := cfg.SpanCountCfg
 		return sampling.NewSpanCount(logger, scfCfg.MinSpans), nil
+	case TQLCondition:
+		tqlCfg := cfg.TQLConditionCfg
+		return sampling.NewTQLConditionFilter(logger, tqlCfg.SpanConditions, tqlCfg.SpanEventConditions)
 	default:
 		return nil, fmt.Errorf("unknown sampling policy type %s", cfg.Type)
 	}
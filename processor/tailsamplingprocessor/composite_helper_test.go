@@ -35,7 +35,7 @@ func TestCompositeHelper(t *testing.T) {
 				Type: Composite,
 				CompositeCfg: CompositeCfg{
 					MaxTotalSpansPerSecond: 1000,
-					PolicyOrder:            []string{"test-composite-policy-1", "test-composite-policy-2", "test-composite-policy-3", "test-composite-policy-4", "test-composite-policy-5", "test-composite-policy-6", "test-composite-policy-7", "test-composite-policy-8"},
+					PolicyOrder:            []string{"test-composite-policy-1", "test-composite-policy-2", "test-composite-policy-3", "test-composite-policy-4", "test-composite-policy-5", "test-composite-policy-6", "test-composite-policy-7", "test-composite-policy-8", "test-composite-policy-9"},
 					SubPolicyCfg: []SubPolicyCfg{
 						{
 							Name:                "test-composite-policy-1",
@@ -74,6 +74,11 @@ func TestCompositeHelper(t *testing.T) {
 						{
 							Name: "test-composite-policy-8",
 						},
+						{
+							Name:            "test-composite-policy-9",
+							Type:            TQLCondition,
+							TQLConditionCfg: TQLConditionCfg{SpanEventConditions: []string{`name == "exception"`}},
+						},
 					},
 					RateAllocation: []RateAllocationCfg{
 						{
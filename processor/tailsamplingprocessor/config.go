@@ -15,6 +15,7 @@
 package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
 
 import (
+	"errors"
 	"time"
 
 	"go.opentelemetry.io/collector/config"
@@ -48,6 +49,8 @@ const (
 	SpanCount PolicyType = "span_count"
 	// TraceState sample traces with specified values by the given key
 	TraceState PolicyType = "trace_state"
+	// TQLCondition sample traces with spans or span events matching the given TQL conditions.
+	TQLCondition PolicyType = "tql_condition"
 )
 
 // SubPolicyCfg holds the common configuration to all policies under composite policy.
@@ -74,6 +77,8 @@ type SubPolicyCfg struct {
 	SpanCountCfg SpanCountCfg `mapstructure:"span_count"`
 	// Configs for trace_state policy evaluator.
 	TraceStateCfg TraceStateCfg `mapstructure:"trace_state"`
+	// Configs for TQL condition filter sampling policy evaluator.
+	TQLConditionCfg TQLConditionCfg `mapstructure:"tql_condition"`
 }
 
 type AndSubPolicyCfg struct {
@@ -97,6 +102,8 @@ type AndSubPolicyCfg struct {
 	SpanCountCfg SpanCountCfg `mapstructure:"span_count"`
 	// Configs for trace_state filter sampling policy evaluator
 	TraceStateCfg TraceStateCfg `mapstructure:"trace_state"`
+	// Configs for TQL condition filter sampling policy evaluator.
+	TQLConditionCfg TQLConditionCfg `mapstructure:"tql_condition"`
 }
 
 type TraceStateCfg struct {
@@ -106,6 +113,16 @@ type TraceStateCfg struct {
 	Values []string `mapstructure:"values"`
 }
 
+// TQLConditionCfg holds the configurable settings to create a TQL condition filter
+// sampling policy evaluator. A trace is sampled when any of its spans, or span events,
+// matches any of the conditions.
+type TQLConditionCfg struct {
+	// SpanConditions are the TQL boolean expressions evaluated against each span.
+	SpanConditions []string `mapstructure:"span"`
+	// SpanEventConditions are the TQL boolean expressions evaluated against each span event.
+	SpanEventConditions []string `mapstructure:"span_event"`
+}
+
 type AndCfg struct {
 	SubPolicyCfg []AndSubPolicyCfg `mapstructure:"and_sub_policy"`
 }
@@ -151,6 +168,8 @@ type PolicyCfg struct {
 	SpanCountCfg SpanCountCfg `mapstructure:"span_count"`
 	// Configs for defining trace_state policy
 	TraceStateCfg TraceStateCfg `mapstructure:"trace_state"`
+	// Configs for TQL condition filter sampling policy evaluator.
+	TQLConditionCfg TQLConditionCfg `mapstructure:"tql_condition"`
 }
 
 // LatencyCfg holds the configurable settings to create a latency filter sampling policy
@@ -169,6 +188,10 @@ type NumericAttributeCfg struct {
 	MinValue int64 `mapstructure:"min_value"`
 	// MaxValue is the maximum value of the attribute to be considered a match.
 	MaxValue int64 `mapstructure:"max_value"`
+	// InvertMatch indicates that the attribute value must not be within the range.
+	// If InvertMatch is true, all traces will be sampled except the ones with a span with the attribute in the range,
+	// which prevents the trace from being sampled by any other policy.
+	InvertMatch bool `mapstructure:"invert_match"`
 }
 
 // ProbabilisticCfg holds the configurable settings to create a probabilistic
@@ -223,6 +246,18 @@ type SpanCountCfg struct {
 	MinSpans int32 `mapstructure:"min_spans"`
 }
 
+// DecisionCacheCfg holds the configurable settings of the cache keeping the sampling decisions
+// after the trace data has been released, so that late spans follow the decision taken for their trace.
+type DecisionCacheCfg struct {
+	// NumTraces is the number of sampling decisions kept in the cache. Zero disables the cache.
+	NumTraces int `mapstructure:"num_traces"`
+	// StorageID is the ID of a storage extension used to persist the decisions, so that they survive restarts.
+	// Collectors using the same storage backend, like a database, share their decisions with each other.
+	StorageID *config.ComponentID `mapstructure:"storage"`
+	// SyncInterval sets how often the decisions are merged with the ones persisted in the storage.
+	SyncInterval time.Duration `mapstructure:"sync_interval"`
+}
+
 // Config holds the configuration for tail-based sampling.
 type Config struct {
 	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
@@ -238,4 +273,18 @@ type Config struct {
 	// PolicyCfgs sets the tail-based sampling policy which makes a sampling decision
 	// for a given trace when requested.
 	PolicyCfgs []PolicyCfg `mapstructure:"policies"`
+	// DecisionCache configures the cache keeping the sampling decisions after the trace data has been released.
+	DecisionCache DecisionCacheCfg `mapstructure:"decision_cache"`
+}
+
+var errInvalidSyncInterval = errors.New("the decision cache sync interval must be positive when a storage is configured")
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.DecisionCache.NumTraces > 0 && cfg.DecisionCache.StorageID != nil && cfg.DecisionCache.SyncInterval <= 0 {
+		return errInvalidSyncInterval
+	}
+	return nil
 }
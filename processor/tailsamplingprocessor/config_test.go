@@ -38,6 +38,8 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, config.UnmarshalProcessor(sub, cfg))
 
+	storageID := config.NewComponentID("file_storage")
+
 	assert.Equal(t,
 		cfg,
 		&Config{
@@ -45,6 +47,11 @@ func TestLoadConfig(t *testing.T) {
 			DecisionWait:            10 * time.Second,
 			NumTraces:               100,
 			ExpectedNewTracesPerSec: 10,
+			DecisionCache: DecisionCacheCfg{
+				NumTraces:    1000,
+				StorageID:    &storageID,
+				SyncInterval: 5 * time.Second,
+			},
 			PolicyCfgs: []PolicyCfg{
 				{
 					Name: "test-policy-1",
@@ -90,6 +97,19 @@ func TestLoadConfig(t *testing.T) {
 					Type:          TraceState,
 					TraceStateCfg: TraceStateCfg{Key: "key3", Values: []string{"value1", "value2"}},
 				},
+				{
+					Name: "test-policy-10",
+					Type: TQLCondition,
+					TQLConditionCfg: TQLConditionCfg{
+						SpanConditions:      []string{`attributes["http.status_code"] >= 500`},
+						SpanEventConditions: []string{`name == "exception"`},
+					},
+				},
+				{
+					Name:                "test-policy-11",
+					Type:                NumericAttribute,
+					NumericAttributeCfg: NumericAttributeCfg{Key: "http.status_code", MinValue: 200, MaxValue: 299, InvertMatch: true},
+				},
 				{
 					Name: "and-policy-1",
 					Type: And,
@@ -145,3 +165,18 @@ func TestLoadConfig(t *testing.T) {
 			},
 		})
 }
+
+func TestValidateInvalidSyncInterval(t *testing.T) {
+	storageID := config.NewComponentID("file_storage")
+	cfg := &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		DecisionCache: DecisionCacheCfg{
+			NumTraces: 10,
+			StorageID: &storageID,
+		},
+	}
+	assert.ErrorIs(t, cfg.Validate(), errInvalidSyncInterval)
+
+	cfg.DecisionCache.SyncInterval = time.Second
+	assert.NoError(t, cfg.Validate())
+}
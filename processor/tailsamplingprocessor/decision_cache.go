@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/storageutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+// decisionsKey is the storage key under which the cached decisions are persisted.
+const decisionsKey = "decisions"
+
+// startDecisionCacheSync restores the decisions persisted in the storage extension, then periodically merges the cached
+// decisions with the persisted ones. When several collectors use the same storage backend, the merge shares the
+// decisions among them.
+func startDecisionCacheSync(ctx context.Context, host component.Host, processorID config.ComponentID, cfg DecisionCacheCfg,
+	logger *zap.Logger, decisions *cache.DecisionCache) (*storageutils.PeriodicSync, error) {
+	client, err := storageutils.GetClient(ctx, host, cfg.StorageID, component.KindProcessor, processorID)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheSync := &decisionCacheSync{logger: logger, decisions: decisions}
+	decisionsSync := storageutils.NewPeriodicSync(client, logger, cfg.SyncInterval, cacheSync.sync)
+	if err = decisionsSync.Start(ctx, cacheSync.sync); err != nil {
+		return nil, err
+	}
+	return decisionsSync, nil
+}
+
+// decisionCacheSync merges the cached decisions with the ones persisted in a storage extension.
+type decisionCacheSync struct {
+	logger    *zap.Logger
+	decisions *cache.DecisionCache
+}
+
+func (s *decisionCacheSync) sync(ctx context.Context, client storage.Client) error {
+	persisted, err := client.Get(ctx, decisionsKey)
+	if err != nil {
+		return fmt.Errorf("failed to load the sampling decisions: %w", err)
+	}
+	if persisted != nil {
+		if err = s.decisions.Merge(persisted); err != nil {
+			// the cached decisions still apply, and they replace the unreadable ones right below
+			s.logger.Warn("Discarding the persisted sampling decisions", zap.Error(err))
+		}
+	}
+
+	if err = client.Set(ctx, decisionsKey, s.decisions.Marshal()); err != nil {
+		return fmt.Errorf("failed to persist the sampling decisions: %w", err)
+	}
+	return nil
+}
+
+// processCachedDecision applies the cached decision to the spans of a trace that is no longer held in memory.
+func (tsp *tailSamplingSpanProcessor) processCachedDecision(resourceSpans ptrace.ResourceSpans, spans []*ptrace.Span, decision sampling.Decision) {
+	sampled := decision == sampling.Sampled
+	_ = stats.RecordWithTags(
+		tsp.ctx,
+		[]tag.Mutator{tag.Insert(tagSampledKey, strconv.FormatBool(sampled))},
+		statLateSpansCachedDecision.M(int64(len(spans))),
+	)
+
+	if !sampled {
+		return
+	}
+	if err := tsp.nextConsumer.ConsumeTraces(tsp.ctx, prepareTraceBatch(resourceSpans, spans)); err != nil {
+		tsp.logger.Warn("Error sending late arrived spans to destination", zap.Error(err))
+	}
+}
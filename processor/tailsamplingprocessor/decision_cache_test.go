@@ -0,0 +1,266 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+func newDecisionCacheProcessor(t *testing.T, next consumer.Traces, cacheCfg DecisionCacheCfg) *tailSamplingSpanProcessor {
+	cfg := Config{
+		ProcessorSettings:       config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		DecisionWait:            defaultTestDecisionWait,
+		NumTraces:               100,
+		ExpectedNewTracesPerSec: 64,
+		PolicyCfgs:              testPolicy,
+		DecisionCache:           cacheCfg,
+	}
+	sp, err := newTracesProcessor(zap.NewNop(), next, cfg)
+	require.NoError(t, err)
+	return sp.(*tailSamplingSpanProcessor)
+}
+
+func TestLateSpansFollowCachedDecision(t *testing.T) {
+	for _, tt := range []struct {
+		decision      sampling.Decision
+		expectedSpans int
+	}{
+		{decision: sampling.Sampled, expectedSpans: 1},
+		{decision: sampling.NotSampled, expectedSpans: 0},
+	} {
+		// prepare
+		sink := new(consumertest.TracesSink)
+		tsp := newDecisionCacheProcessor(t, sink, DecisionCacheCfg{NumTraces: 10})
+		require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
+
+		traceID := pcommon.NewTraceID([16]byte{1, 2, 3, 4})
+		tsp.decisions.Put(traceID, tt.decision)
+
+		// test
+		require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+
+		// verify
+		assert.Equal(t, tt.expectedSpans, sink.SpanCount())
+		_, ok := tsp.idToTrace.Load(traceID)
+		assert.False(t, ok, "late spans shouldn't be buffered again")
+
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}
+}
+
+func TestDecisionIsCached(t *testing.T) {
+	// prepare
+	sink := new(consumertest.TracesSink)
+	tsp := newDecisionCacheProcessor(t, sink, DecisionCacheCfg{NumTraces: 10})
+	tsp.decisionBatcher.Stop()
+	tsp.decisionBatcher = newSyncIDBatcher(1)
+	tsp.policyTicker = &manualTTicker{}
+	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	traceID := pcommon.NewTraceID([16]byte{1, 2, 3, 4})
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+
+	// test
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+
+	// verify
+	decision, ok := tsp.decisions.Get(traceID)
+	assert.True(t, ok)
+	assert.Equal(t, sampling.Sampled, decision)
+	assert.Equal(t, 1, sink.SpanCount())
+
+	// once the trace data is gone, late spans still follow the decision
+	tsp.dropTrace(traceID, time.Now())
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+	assert.Equal(t, 2, sink.SpanCount())
+}
+
+func TestDecisionCacheSurvivesRestart(t *testing.T) {
+	// prepare
+	dir := t.TempDir()
+	storageID := config.NewComponentIDWithName("test_storage", "decisions")
+	cacheCfg := DecisionCacheCfg{
+		NumTraces:    10,
+		StorageID:    &storageID,
+		SyncInterval: time.Hour,
+	}
+	traceID := pcommon.NewTraceID([16]byte{1, 2, 3, 4})
+
+	tsp := newDecisionCacheProcessor(t, consumertest.NewNop(), cacheCfg)
+	require.NoError(t, tsp.Start(context.Background(), storagetest.NewStorageHost().WithFileBackedStorageExtension("decisions", dir)))
+	tsp.decisions.Put(traceID, sampling.Sampled)
+	require.NoError(t, tsp.Shutdown(context.Background()))
+
+	// test
+	sink := new(consumertest.TracesSink)
+	tsp = newDecisionCacheProcessor(t, sink, cacheCfg)
+	require.NoError(t, tsp.Start(context.Background(), storagetest.NewStorageHost().WithFileBackedStorageExtension("decisions", dir)))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	// verify
+	decision, ok := tsp.decisions.Get(traceID)
+	assert.True(t, ok)
+	assert.Equal(t, sampling.Sampled, decision)
+
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+	assert.Equal(t, 1, sink.SpanCount())
+}
+
+func TestDecisionCacheSharedThroughStorage(t *testing.T) {
+	// prepare
+	storageID := config.NewComponentIDWithName("test_storage", "shared")
+	cacheCfg := DecisionCacheCfg{
+		NumTraces:    10,
+		StorageID:    &storageID,
+		SyncInterval: time.Hour,
+	}
+	client := storagetest.NewInMemoryClient(component.KindProcessor, config.NewComponentID(typeStr), "")
+
+	first := pcommon.NewTraceID([16]byte{1})
+	second := pcommon.NewTraceID([16]byte{2})
+
+	replica1 := newDecisionCacheProcessor(t, consumertest.NewNop(), cacheCfg)
+	replica2 := newDecisionCacheProcessor(t, consumertest.NewNop(), cacheCfg)
+	defer replica1.decisionBatcher.Stop()
+	defer replica2.decisionBatcher.Stop()
+	sync1 := &decisionCacheSync{logger: zap.NewNop(), decisions: replica1.decisions}
+	sync2 := &decisionCacheSync{logger: zap.NewNop(), decisions: replica2.decisions}
+
+	replica1.decisions.Put(first, sampling.Sampled)
+	replica2.decisions.Put(second, sampling.NotSampled)
+
+	// test
+	require.NoError(t, sync1.sync(context.Background(), client))
+	require.NoError(t, sync2.sync(context.Background(), client))
+	require.NoError(t, sync1.sync(context.Background(), client))
+
+	// verify
+	for _, replica := range []*tailSamplingSpanProcessor{replica1, replica2} {
+		decision, ok := replica.decisions.Get(first)
+		assert.True(t, ok)
+		assert.Equal(t, sampling.Sampled, decision)
+
+		decision, ok = replica.decisions.Get(second)
+		assert.True(t, ok)
+		assert.Equal(t, sampling.NotSampled, decision)
+	}
+}
+
+func TestDecisionCacheStorageErrors(t *testing.T) {
+	missingID := config.NewComponentIDWithName("test_storage", "missing")
+	nonStorageID := config.NewComponentIDWithName("non_storage", "other")
+
+	for _, tt := range []struct {
+		name      string
+		storageID config.ComponentID
+	}{
+		{name: "missing extension", storageID: missingID},
+		{name: "not a storage extension", storageID: nonStorageID},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			storageID := tt.storageID
+			tsp := newDecisionCacheProcessor(t, consumertest.NewNop(), DecisionCacheCfg{
+				NumTraces:    10,
+				StorageID:    &storageID,
+				SyncInterval: time.Second,
+			})
+			host := storagetest.NewStorageHost().WithNonStorageExtension("other")
+			assert.Error(t, tsp.Start(context.Background(), host))
+			tsp.decisionBatcher.Stop()
+		})
+	}
+}
+
+func TestDecisionCacheClosedOnFailedStart(t *testing.T) {
+	// prepare
+	storageID := config.NewComponentIDWithName("test_storage", "failing")
+	tsp := newDecisionCacheProcessor(t, consumertest.NewNop(), DecisionCacheCfg{
+		NumTraces:    10,
+		StorageID:    &storageID,
+		SyncInterval: time.Second,
+	})
+	defer tsp.decisionBatcher.Stop()
+	client := &failingClient{Client: storage.NewNopClient()}
+	host := &failingStorageHost{Host: componenttest.NewNopHost(), storageID: storageID, client: client}
+
+	// test
+	err := tsp.Start(context.Background(), host)
+
+	// verify
+	assert.ErrorIs(t, err, errLoadFailed)
+	assert.True(t, client.closed)
+}
+
+var errLoadFailed = errors.New("load failed")
+
+// failingClient fails to load any key, and records whether it has been closed.
+type failingClient struct {
+	storage.Client
+	closed bool
+}
+
+func (c *failingClient) Get(context.Context, string) ([]byte, error) {
+	return nil, errLoadFailed
+}
+
+func (c *failingClient) Close(context.Context) error {
+	c.closed = true
+	return nil
+}
+
+type failingStorageHost struct {
+	component.Host
+	storageID config.ComponentID
+	client    *failingClient
+}
+
+func (h *failingStorageHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return map[config.ComponentID]component.Extension{h.storageID: h}
+}
+
+func (h *failingStorageHost) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (h *failingStorageHost) Shutdown(context.Context) error {
+	return nil
+}
+
+func (h *failingStorageHost) GetClient(context.Context, component.Kind, config.ComponentID, string) (storage.Client, error) {
+	return h.client, nil
+}
@@ -44,6 +44,8 @@ func TestCreateProcessor(t *testing.T) {
 	sub, err := cm.Sub(config.NewComponentIDWithName(typeStr, "").String())
 	require.NoError(t, err)
 	require.NoError(t, config.UnmarshalProcessor(sub, cfg))
+	// the storage extension of the decision cache isn't available in the test host
+	cfg.(*Config).DecisionCache.StorageID = nil
 
 	params := componenttest.NewNopProcessorCreateSettings()
 	tp, err := factory.CreateTracesProcessor(context.Background(), params, cfg, consumertest.NewNop())
@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cache contains the cache keeping the sampling decisions of the traces
+// after their data has been released.
+package cache // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+// entrySize is the size of an encoded entry: the trace ID followed by the decision.
+const entrySize = 17
+
+var (
+	// ErrInvalidSize occurs when an invalid cache size is specified.
+	ErrInvalidSize = errors.New("invalid cache size, it must be greater than zero")
+	// ErrInvalidEncoding occurs when decisions are merged from data not produced by Marshal.
+	ErrInvalidEncoding = errors.New("invalid encoding of the cached decisions")
+)
+
+type entry struct {
+	id       pcommon.TraceID
+	decision sampling.Decision
+}
+
+// DecisionCache keeps the sampling decisions of the most recently decided traces.
+// Once the cache is full, the least recently used decision is forgotten. It is safe for concurrent use.
+type DecisionCache struct {
+	sync.Mutex
+	size    int
+	entries *list.List
+	byID    map[pcommon.TraceID]*list.Element
+}
+
+// NewDecisionCache creates a DecisionCache holding up to size decisions.
+func NewDecisionCache(size int) (*DecisionCache, error) {
+	if size <= 0 {
+		return nil, ErrInvalidSize
+	}
+	return &DecisionCache{
+		size:    size,
+		entries: list.New(),
+		byID:    make(map[pcommon.TraceID]*list.Element, size),
+	}, nil
+}
+
+// Put records the decision for the given trace, evicting the least recently used decision if the cache is full.
+func (c *DecisionCache) Put(id pcommon.TraceID, decision sampling.Decision) {
+	c.Lock()
+	defer c.Unlock()
+
+	if elem, ok := c.byID[id]; ok {
+		elem.Value.(*entry).decision = decision
+		c.entries.MoveToFront(elem)
+		return
+	}
+
+	c.byID[id] = c.entries.PushFront(&entry{id: id, decision: decision})
+	if c.entries.Len() > c.size {
+		oldest := c.entries.Back()
+		c.entries.Remove(oldest)
+		delete(c.byID, oldest.Value.(*entry).id)
+	}
+}
+
+// Get returns the decision for the given trace, and whether it was found.
+func (c *DecisionCache) Get(id pcommon.TraceID) (sampling.Decision, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	elem, ok := c.byID[id]
+	if !ok {
+		return sampling.Unspecified, false
+	}
+	c.entries.MoveToFront(elem)
+	return elem.Value.(*entry).decision, true
+}
+
+// Len returns the number of decisions in the cache.
+func (c *DecisionCache) Len() int {
+	c.Lock()
+	defer c.Unlock()
+	return c.entries.Len()
+}
+
+// Marshal encodes the cached decisions, from the most to the least recently used.
+func (c *DecisionCache) Marshal() []byte {
+	c.Lock()
+	defer c.Unlock()
+
+	data := make([]byte, 0, c.entries.Len()*entrySize)
+	for elem := c.entries.Front(); elem != nil; elem = elem.Next() {
+		e := elem.Value.(*entry)
+		id := e.id.Bytes()
+		data = append(data, id[:]...)
+		data = append(data, byte(e.decision))
+	}
+	return data
+}
+
+// Merge adds the decisions encoded by Marshal, possibly by another cache, to this cache.
+// The decisions already known by this cache take precedence, and the merged decisions are
+// added as the least recently used while there's room for them. Once the cache is full, the
+// merged Sampled decisions replace the least recently used decisions that aren't Sampled, as
+// forgetting that a trace was sampled loses its late spans.
+func (c *DecisionCache) Merge(data []byte) error {
+	if len(data)%entrySize != 0 {
+		return ErrInvalidEncoding
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	// the next decision that might be replaced, walking from the least recently used one
+	var victim *list.Element
+	for i := 0; i < len(data); i += entrySize {
+		var id [16]byte
+		copy(id[:], data[i:i+16])
+		traceID := pcommon.NewTraceID(id)
+		if _, ok := c.byID[traceID]; ok {
+			continue
+		}
+
+		merged := &entry{id: traceID, decision: sampling.Decision(data[i+16])}
+		if c.entries.Len() < c.size {
+			c.byID[traceID] = c.entries.PushBack(merged)
+			continue
+		}
+		if merged.decision != sampling.Sampled {
+			continue
+		}
+
+		if victim == nil {
+			victim = c.entries.Back()
+		}
+		for victim != nil && victim.Value.(*entry).decision == sampling.Sampled {
+			victim = victim.Prev()
+		}
+		if victim == nil {
+			// all the cached decisions are Sampled already
+			break
+		}
+
+		c.byID[traceID] = c.entries.InsertAfter(merged, victim)
+		replaced := victim
+		victim = victim.Prev()
+		c.entries.Remove(replaced)
+		delete(c.byID, replaced.Value.(*entry).id)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+func TestNewDecisionCacheInvalidSize(t *testing.T) {
+	_, err := NewDecisionCache(0)
+	assert.ErrorIs(t, err, ErrInvalidSize)
+}
+
+func TestDecisionCachePutAndGet(t *testing.T) {
+	c, err := NewDecisionCache(2)
+	require.NoError(t, err)
+
+	first := pcommon.NewTraceID([16]byte{1})
+	second := pcommon.NewTraceID([16]byte{2})
+	third := pcommon.NewTraceID([16]byte{3})
+
+	c.Put(first, sampling.Sampled)
+	c.Put(second, sampling.NotSampled)
+
+	decision, ok := c.Get(first)
+	assert.True(t, ok)
+	assert.Equal(t, sampling.Sampled, decision)
+
+	// the second trace is now the least recently used, and gets evicted
+	c.Put(third, sampling.Sampled)
+	assert.Equal(t, 2, c.Len())
+
+	_, ok = c.Get(second)
+	assert.False(t, ok)
+
+	decision, ok = c.Get(third)
+	assert.True(t, ok)
+	assert.Equal(t, sampling.Sampled, decision)
+
+	// updating a decision doesn't grow the cache
+	c.Put(third, sampling.NotSampled)
+	decision, _ = c.Get(third)
+	assert.Equal(t, sampling.NotSampled, decision)
+	assert.Equal(t, 2, c.Len())
+}
+
+func TestDecisionCacheMarshalAndMerge(t *testing.T) {
+	source, err := NewDecisionCache(3)
+	require.NoError(t, err)
+	source.Put(pcommon.NewTraceID([16]byte{1}), sampling.Sampled)
+	source.Put(pcommon.NewTraceID([16]byte{2}), sampling.NotSampled)
+	source.Put(pcommon.NewTraceID([16]byte{3}), sampling.Sampled)
+
+	target, err := NewDecisionCache(3)
+	require.NoError(t, err)
+	target.Put(pcommon.NewTraceID([16]byte{2}), sampling.Sampled)
+
+	require.NoError(t, target.Merge(source.Marshal()))
+
+	// the local decision takes precedence
+	decision, ok := target.Get(pcommon.NewTraceID([16]byte{2}))
+	assert.True(t, ok)
+	assert.Equal(t, sampling.Sampled, decision)
+
+	// the most recent decisions of the source fill the room left
+	assert.Equal(t, 3, target.Len())
+	decision, ok = target.Get(pcommon.NewTraceID([16]byte{3}))
+	assert.True(t, ok)
+	assert.Equal(t, sampling.Sampled, decision)
+	decision, ok = target.Get(pcommon.NewTraceID([16]byte{1}))
+	assert.True(t, ok)
+	assert.Equal(t, sampling.Sampled, decision)
+}
+
+func TestDecisionCacheMergeIntoFullCache(t *testing.T) {
+	source, err := NewDecisionCache(3)
+	require.NoError(t, err)
+	source.Put(pcommon.NewTraceID([16]byte{1}), sampling.Sampled)
+	source.Put(pcommon.NewTraceID([16]byte{2}), sampling.NotSampled)
+	source.Put(pcommon.NewTraceID([16]byte{3}), sampling.Sampled)
+
+	target, err := NewDecisionCache(2)
+	require.NoError(t, err)
+	target.Put(pcommon.NewTraceID([16]byte{4}), sampling.NotSampled)
+	target.Put(pcommon.NewTraceID([16]byte{5}), sampling.Sampled)
+
+	require.NoError(t, target.Merge(source.Marshal()))
+
+	// the most recent Sampled decision of the source replaces the oldest local decision that isn't Sampled
+	assert.Equal(t, 2, target.Len())
+	decision, ok := target.Get(pcommon.NewTraceID([16]byte{3}))
+	assert.True(t, ok)
+	assert.Equal(t, sampling.Sampled, decision)
+	_, ok = target.Get(pcommon.NewTraceID([16]byte{4}))
+	assert.False(t, ok)
+
+	// the Sampled local decisions are kept, there's no room left for the other decisions of the source
+	decision, ok = target.Get(pcommon.NewTraceID([16]byte{5}))
+	assert.True(t, ok)
+	assert.Equal(t, sampling.Sampled, decision)
+	_, ok = target.Get(pcommon.NewTraceID([16]byte{2}))
+	assert.False(t, ok)
+	_, ok = target.Get(pcommon.NewTraceID([16]byte{1}))
+	assert.False(t, ok)
+}
+
+func TestDecisionCacheMergeFillsRoomBeforeReplacing(t *testing.T) {
+	source, err := NewDecisionCache(2)
+	require.NoError(t, err)
+	source.Put(pcommon.NewTraceID([16]byte{1}), sampling.Sampled)
+	source.Put(pcommon.NewTraceID([16]byte{2}), sampling.NotSampled)
+
+	target, err := NewDecisionCache(2)
+	require.NoError(t, err)
+	target.Put(pcommon.NewTraceID([16]byte{3}), sampling.NotSampled)
+
+	require.NoError(t, target.Merge(source.Marshal()))
+
+	// the NotSampled decision of the source fills the room left, and is then replaced by its Sampled decision
+	assert.Equal(t, 2, target.Len())
+	_, ok := target.Get(pcommon.NewTraceID([16]byte{2}))
+	assert.False(t, ok)
+	decision, ok := target.Get(pcommon.NewTraceID([16]byte{1}))
+	assert.True(t, ok)
+	assert.Equal(t, sampling.Sampled, decision)
+	decision, ok = target.Get(pcommon.NewTraceID([16]byte{3}))
+	assert.True(t, ok)
+	assert.Equal(t, sampling.NotSampled, decision)
+}
+
+func TestDecisionCacheMergeInvalidEncoding(t *testing.T) {
+	c, err := NewDecisionCache(2)
+	require.NoError(t, err)
+	assert.ErrorIs(t, c.Merge([]byte{1, 2, 3}), ErrInvalidEncoding)
+	assert.Equal(t, 0, c.Len())
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
 
@@ -134,3 +135,30 @@ func TestAndEvaluatorStringInvertNotSampled(t *testing.T) {
 	assert.Equal(t, decision, NotSampled)
 
 }
+
+func TestAndEvaluatorNumericInvertNotSampled(t *testing.T) {
+	n1 := NewNumericAttributeFilter(zap.NewNop(), "http.status_code", 200, 299, true)
+	n2 := NewRateLimiting(zap.NewNop(), 10)
+
+	and := NewAnd(zap.NewNop(), []PolicyEvaluator{n1, n2})
+
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	ils := rs.ScopeSpans().AppendEmpty()
+
+	span := ils.Spans().AppendEmpty()
+	span.Attributes().InsertInt("http.status_code", 200)
+	span.SetTraceID(pcommon.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	span.SetSpanID(pcommon.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+
+	trace := &TraceData{
+		ReceivedBatches: []ptrace.Traces{traces},
+		SpanCount:       atomic.NewInt64(1),
+	}
+	decision, err := and.Evaluate(traceID, trace)
+	require.NoError(t, err, "Failed to evaluate and policy: %v", err)
+	assert.Equal(t, decision, NotSampled)
+
+	// the rate limiting sub-policy is not reached for excluded traces
+	assert.Equal(t, int64(0), n2.(*rateLimiting).spansInCurrentSecond)
+}
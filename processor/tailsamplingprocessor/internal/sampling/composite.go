@@ -28,6 +28,15 @@ type subpolicy struct {
 
 	// spans per second that each subpolicy sampled in this period
 	sampledSPS int64
+
+	// whether the subpolicy might veto the trace through an inverted match
+	inverted bool
+}
+
+// invertMatcher is implemented by the evaluators that veto the traces they match
+// when they are configured with an inverted match.
+type invertMatcher interface {
+	invertsMatch() bool
 }
 
 // Composite evaluator and its internal data
@@ -69,6 +78,9 @@ func NewComposite(
 		sub := &subpolicy{}
 		sub.evaluator = subPolicyParams[i].Evaluator
 		sub.allocatedSPS = subPolicyParams[i].MaxSpansPerSecond
+		if matcher, ok := sub.evaluator.(invertMatcher); ok {
+			sub.inverted = matcher.invertsMatch()
+		}
 
 		// We are just starting, so there is no previous input, set it to 0
 		sub.sampledSPS = 0
@@ -103,12 +115,33 @@ func (c *Composite) Evaluate(traceID pcommon.TraceID, trace *TraceData) (Decisio
 		}
 	}
 
-	for _, sub := range c.subpolicies {
+	// The inverted subpolicies are evaluated before any other subpolicy is allowed to sample the trace,
+	// as an inverted match that is not sampled (e.g. the trace belongs to a health check) vetoes the
+	// sampling by the other subpolicies. A vetoed trace does not count against the allocated spans per
+	// second, and the other subpolicies, which might keep state like the rate_limiting one, don't see it.
+	decisions := make([]Decision, len(c.subpolicies))
+	for i, sub := range c.subpolicies {
+		if !sub.inverted {
+			continue
+		}
 		decision, err := sub.evaluator.Evaluate(traceID, trace)
 		if err != nil {
 			return Unspecified, err
 		}
+		if decision == InvertNotSampled {
+			return InvertNotSampled, nil
+		}
+		decisions[i] = decision
+	}
 
+	for i, sub := range c.subpolicies {
+		decision := decisions[i]
+		if !sub.inverted {
+			var err error
+			if decision, err = sub.evaluator.Evaluate(traceID, trace); err != nil {
+				return Unspecified, err
+			}
+		}
 		if decision == Sampled || decision == InvertSampled {
 			// The subpolicy made a decision to Sample. Now we need to make our decision.
 
@@ -71,8 +71,8 @@ func newTraceWithKV(traceID pcommon.TraceID, key string, val int64) *TraceData {
 func TestCompositeEvaluatorNotSampled(t *testing.T) {
 
 	// Create 2 policies which do not match any trace
-	n1 := NewNumericAttributeFilter(zap.NewNop(), "tag", 0, 100)
-	n2 := NewNumericAttributeFilter(zap.NewNop(), "tag", 200, 300)
+	n1 := NewNumericAttributeFilter(zap.NewNop(), "tag", 0, 100, false)
+	n2 := NewNumericAttributeFilter(zap.NewNop(), "tag", 200, 300, false)
 	c := NewComposite(zap.NewNop(), 1000, []SubPolicyEvalParams{{n1, 100}, {n2, 100}}, FakeTimeProvider{})
 
 	trace := createTrace()
@@ -89,7 +89,7 @@ func TestCompositeEvaluatorNotSampled(t *testing.T) {
 func TestCompositeEvaluatorSampled(t *testing.T) {
 
 	// Create 2 subpolicies. First results in 100% NotSampled, the second in 100% Sampled.
-	n1 := NewNumericAttributeFilter(zap.NewNop(), "tag", 0, 100)
+	n1 := NewNumericAttributeFilter(zap.NewNop(), "tag", 0, 100, false)
 	n2 := NewAlwaysSample(zap.NewNop())
 	c := NewComposite(zap.NewNop(), 1000, []SubPolicyEvalParams{{n1, 100}, {n2, 100}}, FakeTimeProvider{})
 
@@ -108,7 +108,7 @@ func TestCompositeEvaluator_OverflowAlwaysSampled(t *testing.T) {
 	timeProvider := &FakeTimeProvider{second: 0}
 
 	// Create 2 subpolicies. First results in 100% NotSampled, the second in 100% Sampled.
-	n1 := NewNumericAttributeFilter(zap.NewNop(), "tag", 0, 100)
+	n1 := NewNumericAttributeFilter(zap.NewNop(), "tag", 0, 100, false)
 	n2 := NewAlwaysSample(zap.NewNop())
 	c := NewComposite(zap.NewNop(), 3, []SubPolicyEvalParams{{n1, 1}, {n2, 1}}, timeProvider)
 
@@ -145,7 +145,7 @@ func TestCompositeEvaluator_OverflowAlwaysSampled(t *testing.T) {
 func TestCompositeEvaluatorSampled_AlwaysSampled(t *testing.T) {
 
 	// Create 2 subpolicies. First results in 100% NotSampled, the second in 100% Sampled.
-	n1 := NewNumericAttributeFilter(zap.NewNop(), "tag", 0, 100)
+	n1 := NewNumericAttributeFilter(zap.NewNop(), "tag", 0, 100, false)
 	n2 := NewAlwaysSample(zap.NewNop())
 	c := NewComposite(zap.NewNop(), 10, []SubPolicyEvalParams{{n1, 20}, {n2, 20}}, FakeTimeProvider{})
 
@@ -180,6 +180,54 @@ func TestCompositeEvaluatorInverseSampled_AlwaysSampled(t *testing.T) {
 	}
 }
 
+func TestCompositeEvaluatorInverseNotSampled_Vetoes(t *testing.T) {
+
+	// The first policy matches, but the second one vetoes the trace through an inverted match
+	n1 := NewAlwaysSample(zap.NewNop())
+	n2 := NewNumericAttributeFilter(zap.NewNop(), "tag", 0, 100, true)
+	timeProvider := &FakeTimeProvider{second: 0}
+	c := NewComposite(zap.NewNop(), 1, []SubPolicyEvalParams{{n1, 1}, {n2, 1}}, timeProvider)
+
+	trace := newTraceWithKV(traceID, "tag", int64(50))
+	decision, err := c.Evaluate(traceID, trace)
+	require.NoError(t, err, "Failed to evaluate composite policy: %v", err)
+	assert.Equal(t, InvertNotSampled, decision)
+
+	// The vetoed trace did not consume the allocated spans per second
+	trace = newTraceWithKV(traceID, "tag", int64(150))
+	decision, err = c.Evaluate(traceID, trace)
+	require.NoError(t, err, "Failed to evaluate composite policy: %v", err)
+	assert.Equal(t, Sampled, decision)
+}
+
+func TestCompositeEvaluatorSampled_SkipsRateLimiting(t *testing.T) {
+
+	// The first subpolicy samples the trace, the rate_limiting one after it must not spend its rate on it
+	n1 := NewAlwaysSample(zap.NewNop())
+	n2 := NewRateLimiting(zap.NewNop(), 10)
+	c := NewComposite(zap.NewNop(), 100, []SubPolicyEvalParams{{n1, 50}, {n2, 50}}, FakeTimeProvider{})
+
+	trace := createTrace()
+	decision, err := c.Evaluate(traceID, trace)
+	require.NoError(t, err, "Failed to evaluate composite policy: %v", err)
+	assert.Equal(t, Sampled, decision)
+	assert.Equal(t, int64(0), n2.(*rateLimiting).spansInCurrentSecond)
+}
+
+func TestCompositeEvaluatorInverseNotSampled_SkipsRateLimiting(t *testing.T) {
+
+	// The inverted subpolicy vetoes the trace before the rate_limiting one placed before it sees the trace
+	n1 := NewRateLimiting(zap.NewNop(), 10)
+	n2 := NewNumericAttributeFilter(zap.NewNop(), "tag", 0, 100, true)
+	c := NewComposite(zap.NewNop(), 100, []SubPolicyEvalParams{{n1, 50}, {n2, 50}}, FakeTimeProvider{})
+
+	trace := newTraceWithKV(traceID, "tag", int64(50))
+	decision, err := c.Evaluate(traceID, trace)
+	require.NoError(t, err, "Failed to evaluate composite policy: %v", err)
+	assert.Equal(t, InvertNotSampled, decision)
+	assert.Equal(t, int64(0), n1.(*rateLimiting).spansInCurrentSecond)
+}
+
 func TestCompositeEvaluatorThrottling(t *testing.T) {
 
 	// Create only one subpolicy, with 100% Sampled policy.
@@ -223,7 +271,7 @@ func TestCompositeEvaluatorThrottling(t *testing.T) {
 
 func TestCompositeEvaluator2SubpolicyThrottling(t *testing.T) {
 
-	n1 := NewNumericAttributeFilter(zap.NewNop(), "tag", 0, 100)
+	n1 := NewNumericAttributeFilter(zap.NewNop(), "tag", 0, 100, false)
 	n2 := NewAlwaysSample(zap.NewNop())
 	timeProvider := &FakeTimeProvider{second: 0}
 	const totalSPS = 10
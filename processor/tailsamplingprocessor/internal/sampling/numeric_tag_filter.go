@@ -24,18 +24,21 @@ type numericAttributeFilter struct {
 	key                string
 	minValue, maxValue int64
 	logger             *zap.Logger
+	invertMatch        bool
 }
 
 var _ PolicyEvaluator = (*numericAttributeFilter)(nil)
 
 // NewNumericAttributeFilter creates a policy evaluator that samples all traces with
-// the given attribute in the given numeric range.
-func NewNumericAttributeFilter(logger *zap.Logger, key string, minValue, maxValue int64) PolicyEvaluator {
+// the given attribute in the given numeric range. When invertMatch is set, all traces
+// are sampled except those with the given attribute in the given numeric range.
+func NewNumericAttributeFilter(logger *zap.Logger, key string, minValue, maxValue int64, invertMatch bool) PolicyEvaluator {
 	return &numericAttributeFilter{
-		key:      key,
-		minValue: minValue,
-		maxValue: maxValue,
-		logger:   logger,
+		key:         key,
+		minValue:    minValue,
+		maxValue:    maxValue,
+		logger:      logger,
+		invertMatch: invertMatch,
 	}
 }
 
@@ -45,13 +48,27 @@ func (naf *numericAttributeFilter) Evaluate(_ pcommon.TraceID, trace *TraceData)
 	batches := trace.ReceivedBatches
 	trace.Unlock()
 
-	return hasSpanWithCondition(batches, func(span ptrace.Span) bool {
-		if v, ok := span.Attributes().Get(naf.key); ok {
-			value := v.IntVal()
-			if value >= naf.minValue && value <= naf.maxValue {
-				return true
-			}
+	if naf.invertMatch {
+		// Invert Match returns true by default, except when the attribute is within the range
+		return invertHasSpanWithCondition(batches, func(span ptrace.Span) bool {
+			return !naf.inRange(span)
+		}), nil
+	}
+
+	return hasSpanWithCondition(batches, naf.inRange), nil
+}
+
+func (naf *numericAttributeFilter) inRange(span ptrace.Span) bool {
+	if v, ok := span.Attributes().Get(naf.key); ok {
+		value := v.IntVal()
+		if value >= naf.minValue && value <= naf.maxValue {
+			return true
 		}
-		return false
-	}), nil
+	}
+	return false
+}
+
+// invertsMatch reports whether the filter vetoes the traces with a matching attribute.
+func (naf *numericAttributeFilter) invertsMatch() bool {
+	return naf.invertMatch
 }
@@ -28,7 +28,7 @@ import (
 func TestNumericTagFilter(t *testing.T) {
 
 	var empty = map[string]interface{}{}
-	filter := NewNumericAttributeFilter(zap.NewNop(), "example", math.MinInt32, math.MaxInt32)
+	filter := NewNumericAttributeFilter(zap.NewNop(), "example", math.MinInt32, math.MaxInt32, false)
 
 	resAttr := map[string]interface{}{}
 	resAttr["example"] = 8
@@ -75,6 +75,53 @@ func TestNumericTagFilter(t *testing.T) {
 	}
 }
 
+func TestNumericTagFilterInverted(t *testing.T) {
+
+	var empty = map[string]interface{}{}
+	filter := NewNumericAttributeFilter(zap.NewNop(), "example", 200, 299, true)
+
+	cases := []struct {
+		Desc     string
+		Trace    *TraceData
+		Decision Decision
+	}{
+		{
+			Desc:     "invert nonmatching span attribute",
+			Trace:    newTraceIntAttrs(empty, "non_matching", 200),
+			Decision: InvertSampled,
+		},
+		{
+			Desc:     "invert span attribute within range",
+			Trace:    newTraceIntAttrs(empty, "example", 250),
+			Decision: InvertNotSampled,
+		},
+		{
+			Desc:     "invert span attribute with lower limit",
+			Trace:    newTraceIntAttrs(empty, "example", 200),
+			Decision: InvertNotSampled,
+		},
+		{
+			Desc:     "invert span attribute below min limit",
+			Trace:    newTraceIntAttrs(empty, "example", 199),
+			Decision: InvertSampled,
+		},
+		{
+			Desc:     "invert span attribute above max limit",
+			Trace:    newTraceIntAttrs(empty, "example", 300),
+			Decision: InvertSampled,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Desc, func(t *testing.T) {
+			u, _ := uuid.NewRandom()
+			decision, err := filter.Evaluate(pcommon.NewTraceID(u), c.Trace)
+			assert.NoError(t, err)
+			assert.Equal(t, c.Decision, decision)
+		})
+	}
+}
+
 func newTraceIntAttrs(nodeAttrs map[string]interface{}, spanAttrKey string, spanAttrValue int64) *TraceData {
 	var traceBatches []ptrace.Traces
 	traces := ptrace.NewTraces()
@@ -166,3 +166,8 @@ func addFilters(exprs []string) []*regexp.Regexp {
 	}
 	return list
 }
+
+// invertsMatch reports whether the filter vetoes the traces with a matching attribute.
+func (saf *stringAttributeFilter) invertsMatch() bool {
+	return saf.invertMatch
+}
@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqltraces"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlcommon"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+type tqlConditionFilter struct {
+	spanConditions      []tql.BoolExpressionEvaluator
+	spanEventConditions []tql.BoolExpressionEvaluator
+	logger              *zap.Logger
+}
+
+var _ PolicyEvaluator = (*tqlConditionFilter)(nil)
+
+// conditionFunctions are the converters that can be used within the conditions.
+var conditionFunctions = map[string]interface{}{
+	"IsMatch": tqlcommon.IsMatch,
+	"Int":     tqlcommon.Int,
+	"Double":  tqlcommon.Double,
+	"String":  tqlcommon.String,
+	"Concat":  tqlcommon.Concat,
+	"SHA256":  tqlcommon.SHA256,
+	"Split":   tqlcommon.Split,
+}
+
+// NewTQLConditionFilter creates a policy evaluator that samples all traces with
+// a span or a span event matching any of the given TQL conditions.
+func NewTQLConditionFilter(logger *zap.Logger, spanConditions, spanEventConditions []string) (PolicyEvaluator, error) {
+	if len(spanConditions) == 0 && len(spanEventConditions) == 0 {
+		return nil, errors.New("expected at least one span or span event condition, got none")
+	}
+
+	spanEvaluators, err := tql.ParseConditions(spanConditions, conditionFunctions, tqltraces.ParsePath, tqltraces.ParseEnum)
+	if err != nil {
+		return nil, fmt.Errorf("invalid span condition: %w", err)
+	}
+
+	spanEventEvaluators, err := tql.ParseConditions(spanEventConditions, conditionFunctions, tqltraces.ParseSpanEventPath, tqltraces.ParseEnum)
+	if err != nil {
+		return nil, fmt.Errorf("invalid span event condition: %w", err)
+	}
+
+	return &tqlConditionFilter{
+		spanConditions:      spanEvaluators,
+		spanEventConditions: spanEventEvaluators,
+		logger:              logger,
+	}, nil
+}
+
+// Evaluate looks at the trace data and returns a corresponding SamplingDecision.
+func (tcf *tqlConditionFilter) Evaluate(_ pcommon.TraceID, trace *TraceData) (Decision, error) {
+	trace.Lock()
+	batches := trace.ReceivedBatches
+	trace.Unlock()
+
+	for _, batch := range batches {
+		rspans := batch.ResourceSpans()
+
+		for i := 0; i < rspans.Len(); i++ {
+			rs := rspans.At(i)
+			resource := rs.Resource()
+			ilss := rs.ScopeSpans()
+
+			for j := 0; j < ilss.Len(); j++ {
+				ils := ilss.At(j)
+				scope := ils.Scope()
+
+				for k := 0; k < ils.Spans().Len(); k++ {
+					span := ils.Spans().At(k)
+					if tcf.matchesSpan(span, scope, resource) {
+						return Sampled, nil
+					}
+				}
+			}
+		}
+	}
+	return NotSampled, nil
+}
+
+// matchesSpan returns true if the span, or any of its events, satisfies any of the conditions.
+func (tcf *tqlConditionFilter) matchesSpan(span ptrace.Span, scope pcommon.InstrumentationScope, resource pcommon.Resource) bool {
+	if len(tcf.spanConditions) > 0 {
+		ctx := tqltraces.SpanTransformContext{
+			Span:                 span,
+			InstrumentationScope: scope,
+			Resource:             resource,
+		}
+		for _, condition := range tcf.spanConditions {
+			if condition(ctx) {
+				return true
+			}
+		}
+	}
+
+	if len(tcf.spanEventConditions) > 0 {
+		events := span.Events()
+		for i := 0; i < events.Len(); i++ {
+			ctx := tqltraces.SpanEventTransformContext{
+				SpanEvent:            events.At(i),
+				Span:                 span,
+				InstrumentationScope: scope,
+				Resource:             resource,
+			}
+			for _, condition := range tcf.spanEventConditions {
+				if condition(ctx) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+func TestTQLConditionFilter(t *testing.T) {
+	cases := []struct {
+		Desc                string
+		SpanConditions      []string
+		SpanEventConditions []string
+		Trace               *TraceData
+		Decision            Decision
+	}{
+		{
+			Desc:           "matching span name",
+			SpanConditions: []string{`name == "checkout"`},
+			Trace:          newTraceWithNamedSpan("checkout", 200, ""),
+			Decision:       Sampled,
+		},
+		{
+			Desc:           "nonmatching span name",
+			SpanConditions: []string{`name == "checkout"`},
+			Trace:          newTraceWithNamedSpan("cart", 200, ""),
+			Decision:       NotSampled,
+		},
+		{
+			Desc:           "matching ordered attribute",
+			SpanConditions: []string{`attributes["http.status_code"] >= 500`},
+			Trace:          newTraceWithNamedSpan("checkout", 503, ""),
+			Decision:       Sampled,
+		},
+		{
+			Desc:           "nonmatching ordered attribute",
+			SpanConditions: []string{`attributes["http.status_code"] >= 500`},
+			Trace:          newTraceWithNamedSpan("checkout", 404, ""),
+			Decision:       NotSampled,
+		},
+		{
+			Desc:           "matching any of the conditions",
+			SpanConditions: []string{`name == "cart"`, `attributes["http.status_code"] >= 500`},
+			Trace:          newTraceWithNamedSpan("checkout", 500, ""),
+			Decision:       Sampled,
+		},
+		{
+			Desc:           "matching compound condition",
+			SpanConditions: []string{`name == "checkout" and resource.attributes["service.name"] == "shop"`},
+			Trace:          newTraceWithNamedSpan("checkout", 200, ""),
+			Decision:       Sampled,
+		},
+		{
+			Desc:           "matching converter",
+			SpanConditions: []string{`IsMatch(name, "^check.*") == true`},
+			Trace:          newTraceWithNamedSpan("checkout", 200, ""),
+			Decision:       Sampled,
+		},
+		{
+			Desc:                "matching span event",
+			SpanEventConditions: []string{`name == "exception"`},
+			Trace:               newTraceWithNamedSpan("checkout", 200, "exception"),
+			Decision:            Sampled,
+		},
+		{
+			Desc:                "nonmatching span event",
+			SpanEventConditions: []string{`name == "exception"`},
+			Trace:               newTraceWithNamedSpan("checkout", 200, "retry"),
+			Decision:            NotSampled,
+		},
+		{
+			Desc:                "span without events",
+			SpanEventConditions: []string{`name == "exception"`},
+			Trace:               newTraceWithNamedSpan("checkout", 200, ""),
+			Decision:            NotSampled,
+		},
+		{
+			Desc:                "matching span event with nonmatching span",
+			SpanConditions:      []string{`name == "cart"`},
+			SpanEventConditions: []string{`name == "exception"`},
+			Trace:               newTraceWithNamedSpan("checkout", 200, "exception"),
+			Decision:            Sampled,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Desc, func(t *testing.T) {
+			filter, err := NewTQLConditionFilter(zap.NewNop(), c.SpanConditions, c.SpanEventConditions)
+			require.NoError(t, err)
+
+			decision, err := filter.Evaluate(pcommon.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}), c.Trace)
+			assert.NoError(t, err)
+			assert.Equal(t, c.Decision, decision)
+		})
+	}
+}
+
+func TestTQLConditionFilterInvalid(t *testing.T) {
+	_, err := NewTQLConditionFilter(zap.NewNop(), nil, nil)
+	assert.Error(t, err)
+
+	_, err = NewTQLConditionFilter(zap.NewNop(), []string{`name ==`}, nil)
+	assert.Error(t, err)
+
+	_, err = NewTQLConditionFilter(zap.NewNop(), nil, []string{`unknown == "value"`})
+	assert.Error(t, err)
+}
+
+func newTraceWithNamedSpan(name string, statusCode int64, eventName string) *TraceData {
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "shop")
+	ils := rs.ScopeSpans().AppendEmpty()
+
+	root := ils.Spans().AppendEmpty()
+	root.SetTraceID(pcommon.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	root.SetSpanID(pcommon.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+	root.SetName("frontend")
+
+	span := ils.Spans().AppendEmpty()
+	span.SetTraceID(pcommon.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	span.SetSpanID(pcommon.NewSpanID([8]byte{2, 2, 3, 4, 5, 6, 7, 8}))
+	span.SetName(name)
+	span.Attributes().InsertInt("http.status_code", statusCode)
+	if eventName != "" {
+		span.Events().AppendEmpty().SetName(eventName)
+	}
+
+	return &TraceData{
+		ReceivedBatches: []ptrace.Traces{traces},
+	}
+}
@@ -87,6 +87,22 @@ func hasSpanWithCondition(batches []ptrace.Traces, shouldSample func(span ptrace
 	return NotSampled
 }
 
+// invertHasSpanWithCondition iterates through all the instrumentation library spans until any callback returns false.
+func invertHasSpanWithCondition(batches []ptrace.Traces, shouldSample func(span ptrace.Span) bool) Decision {
+	for _, batch := range batches {
+		rspans := batch.ResourceSpans()
+
+		for i := 0; i < rspans.Len(); i++ {
+			rs := rspans.At(i)
+
+			if !invertHasInstrumentationLibrarySpanWithCondition(rs.ScopeSpans(), shouldSample) {
+				return InvertNotSampled
+			}
+		}
+	}
+	return InvertSampled
+}
+
 func hasInstrumentationLibrarySpanWithCondition(ilss ptrace.ScopeSpansSlice, check func(span ptrace.Span) bool) bool {
 	for i := 0; i < ilss.Len(); i++ {
 		ils := ilss.At(i)
@@ -33,6 +33,7 @@ var (
 
 	statTraceRemovalAgeSec           = stats.Int64("sampling_trace_removal_age", "Time (in seconds) from arrival of a new trace until its removal from memory", "s")
 	statLateSpanArrivalAfterDecision = stats.Int64("sampling_late_span_age", "Time (in seconds) from the sampling decision was taken and the arrival of a late span", "s")
+	statLateSpansCachedDecision      = stats.Int64("sampling_late_span_cached_decision", "Count of late spans handled with a decision from the decision cache", stats.UnitDimensionless)
 
 	statPolicyEvaluationErrorCount = stats.Int64("sampling_policy_evaluation_error", "Count of sampling policy evaluation errors", stats.UnitDimensionless)
 
@@ -81,6 +82,14 @@ func SamplingProcessorMetricViews(level configtelemetry.Level) []*view.View {
 		Aggregation: ageDistributionAggregation,
 	}
 
+	countLateSpansCachedDecisionView := &view.View{
+		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statLateSpansCachedDecision.Name()),
+		Measure:     statLateSpansCachedDecision,
+		Description: statLateSpansCachedDecision.Description(),
+		TagKeys:     []tag.Key{tagSampledKey},
+		Aggregation: view.Sum(),
+	}
+
 	countPolicyEvaluationErrorView := &view.View{
 		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statPolicyEvaluationErrorCount.Name()),
 		Measure:     statPolicyEvaluationErrorCount,
@@ -122,6 +131,7 @@ func SamplingProcessorMetricViews(level configtelemetry.Level) []*view.View {
 
 		traceRemovalAgeView,
 		lateSpanArrivalView,
+		countLateSpansCachedDecisionView,
 
 		countPolicyEvaluationErrorView,
 
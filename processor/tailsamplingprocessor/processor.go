@@ -24,13 +24,16 @@ import (
 	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/storageutils"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/timeutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/idbatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
 )
@@ -60,6 +63,11 @@ type tailSamplingSpanProcessor struct {
 	decisionBatcher idbatcher.Batcher
 	deleteChan      chan pcommon.TraceID
 	numTracesOnMap  *atomic.Uint64
+
+	id               config.ComponentID
+	decisionCacheCfg DecisionCacheCfg
+	decisions        *cache.DecisionCache
+	decisionsSync    *storageutils.PeriodicSync
 }
 
 const (
@@ -113,6 +121,14 @@ func newTracesProcessor(logger *zap.Logger, nextConsumer consumer.Traces, cfg Co
 	tsp.policyTicker = &timeutils.PolicyTicker{OnTickFunc: tsp.samplingPolicyOnTick}
 	tsp.deleteChan = make(chan pcommon.TraceID, cfg.NumTraces)
 
+	if cfg.DecisionCache.NumTraces > 0 {
+		if tsp.decisions, err = cache.NewDecisionCache(cfg.DecisionCache.NumTraces); err != nil {
+			return nil, err
+		}
+		tsp.id = cfg.ID()
+		tsp.decisionCacheCfg = cfg.DecisionCache
+	}
+
 	return tsp, nil
 }
 
@@ -125,7 +141,7 @@ func getPolicyEvaluator(logger *zap.Logger, cfg *PolicyCfg) (sampling.PolicyEval
 		return sampling.NewLatency(logger, lfCfg.ThresholdMs), nil
 	case NumericAttribute:
 		nafCfg := cfg.NumericAttributeCfg
-		return sampling.NewNumericAttributeFilter(logger, nafCfg.Key, nafCfg.MinValue, nafCfg.MaxValue), nil
+		return sampling.NewNumericAttributeFilter(logger, nafCfg.Key, nafCfg.MinValue, nafCfg.MaxValue, nafCfg.InvertMatch), nil
 	case Probabilistic:
 		pCfg := cfg.ProbabilisticCfg
 		return sampling.NewProbabilisticSampler(logger, pCfg.HashSalt, pCfg.SamplingPercentage), nil
@@ -150,6 +166,9 @@ func getPolicyEvaluator(logger *zap.Logger, cfg *PolicyCfg) (sampling.PolicyEval
 	case TraceState:
 		tsfCfg := cfg.TraceStateCfg
 		return sampling.NewTraceStateFilter(logger, tsfCfg.Key, tsfCfg.Values), nil
+	case TQLCondition:
+		tqlCfg := cfg.TQLConditionCfg
+		return sampling.NewTQLConditionFilter(logger, tqlCfg.SpanConditions, tqlCfg.SpanEventConditions)
 	default:
 		return nil, fmt.Errorf("unknown sampling policy type %s", cfg.Type)
 	}
@@ -176,6 +195,9 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		trace.DecisionTime = time.Now()
 
 		decision, policy := tsp.makeDecision(id, trace, &metrics)
+		if tsp.decisions != nil {
+			tsp.decisions.Put(id, decision)
+		}
 
 		// Sampled or not, remove the batches
 		trace.Lock()
@@ -325,6 +347,16 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 	idToSpans := tsp.groupSpansByTraceKey(resourceSpans)
 	var newTraceIDs int64
 	for id, spans := range idToSpans {
+		if tsp.decisions != nil {
+			// the trace data might have been released already, while its decision is still known
+			if _, ok := tsp.idToTrace.Load(id); !ok {
+				if decision, ok := tsp.decisions.Get(id); ok {
+					tsp.processCachedDecision(resourceSpans, spans, decision)
+					continue
+				}
+			}
+		}
+
 		lenSpans := int64(len(spans))
 		lenPolicies := len(tsp.policies)
 		initialDecisions := make([]sampling.Decision, lenPolicies)
@@ -408,15 +440,26 @@ func (tsp *tailSamplingSpanProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Start is invoked during service startup.
-func (tsp *tailSamplingSpanProcessor) Start(context.Context, component.Host) error {
+func (tsp *tailSamplingSpanProcessor) Start(ctx context.Context, host component.Host) error {
+	if tsp.decisions != nil && tsp.decisionCacheCfg.StorageID != nil {
+		decisionsSync, err := startDecisionCacheSync(ctx, host, tsp.id, tsp.decisionCacheCfg, tsp.logger, tsp.decisions)
+		if err != nil {
+			return err
+		}
+		tsp.decisionsSync = decisionsSync
+	}
+
 	tsp.policyTicker.Start(tsp.tickerFrequency)
 	return nil
 }
 
 // Shutdown is invoked during service shutdown.
-func (tsp *tailSamplingSpanProcessor) Shutdown(context.Context) error {
+func (tsp *tailSamplingSpanProcessor) Shutdown(ctx context.Context) error {
 	tsp.decisionBatcher.Stop()
 	tsp.policyTicker.Stop()
+	if tsp.decisionsSync != nil {
+		return tsp.decisionsSync.Stop(ctx)
+	}
 	return nil
 }
 
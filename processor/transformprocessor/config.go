@@ -15,12 +15,13 @@
 package transformprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor"
 
 import (
+	"fmt"
+
 	"go.opentelemetry.io/collector/config"
 	"go.uber.org/multierr"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqllogs"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlmetrics"
-	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqltraces"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tqlconfig"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/logs"
@@ -38,14 +39,20 @@ var _ config.Processor = (*Config)(nil)
 
 func (c *Config) Validate() error {
 	var errors error
-	_, err := tql.ParseQueries(c.Traces.Queries, traces.Functions(), tqltraces.ParsePath, tqltraces.ParseEnum)
+	_, err := traces.ParseQueries(c.Traces.Queries, c.Traces.Context, traces.Functions())
 	if err != nil {
 		errors = multierr.Append(errors, err)
 	}
+	if c.Metrics.Context != "" {
+		errors = multierr.Append(errors, fmt.Errorf("metrics queries do not support a context, got %q", c.Metrics.Context))
+	}
 	_, err = tql.ParseQueries(c.Metrics.Queries, metrics.Functions(), tqlmetrics.ParsePath, tqlmetrics.ParseEnum)
 	if err != nil {
 		errors = multierr.Append(errors, err)
 	}
+	if c.Logs.Context != "" {
+		errors = multierr.Append(errors, fmt.Errorf("logs queries do not support a context, got %q", c.Logs.Context))
+	}
 	_, err = tql.ParseQueries(c.Logs.Queries, logs.Functions(), tqllogs.ParsePath, tqllogs.ParseEnum)
 	if err != nil {
 		errors = multierr.Append(errors, err)
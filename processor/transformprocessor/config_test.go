@@ -60,6 +60,34 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "span_event_context"),
+			expected: &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				Config: tqlconfig.Config{
+					Traces: tqlconfig.SignalConfig{
+						Context: "span_event",
+						Queries: []string{
+							`set(attributes["exception.stacktrace"], "redacted") where name == "exception"`,
+						},
+					},
+					Metrics: tqlconfig.SignalConfig{
+						Queries: []string{},
+					},
+					Logs: tqlconfig.SignalConfig{
+						Queries: []string{},
+					},
+				},
+			},
+		},
+		{
+			id:           config.NewComponentIDWithName(typeStr, "unknown_context_trace"),
+			errorMessage: `unsupported traces context "span_attribute", must be one of "span", "span_event" or "span_link"`,
+		},
+		{
+			id:           config.NewComponentIDWithName(typeStr, "context_metric"),
+			errorMessage: `metrics queries do not support a context, got "span_event"`,
+		},
 		{
 			id:           config.NewComponentIDWithName(typeStr, "bad_syntax_trace"),
 			errorMessage: "1:18: unexpected token \"where\" (expected \")\")",
@@ -92,7 +92,7 @@ func createTracesProcessor(
 ) (component.TracesProcessor, error) {
 	oCfg := cfg.(*Config)
 
-	proc, err := traces.NewProcessor(oCfg.Traces.Queries, traces.Functions(), set)
+	proc, err := traces.NewProcessor(oCfg.Traces.Queries, oCfg.Traces.Context, traces.Functions(), set)
 	if err != nil {
 		return nil, fmt.Errorf("invalid config for \"transform\" processor %w", err)
 	}
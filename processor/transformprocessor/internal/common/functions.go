@@ -23,6 +23,15 @@ var registry = map[string]interface{}{
 	"TraceID":              tqlotel.TraceID,
 	"SpanID":               tqlotel.SpanID,
 	"IsMatch":              tqlcommon.IsMatch,
+	"Int":                  tqlcommon.Int,
+	"Double":               tqlcommon.Double,
+	"String":               tqlcommon.String,
+	"Concat":               tqlcommon.Concat,
+	"SHA256":               tqlcommon.SHA256,
+	"Split":                tqlcommon.Split,
+	"ParseJSON":            tqlotel.ParseJSON,
+	"ParseSpanID":          tqlotel.ParseSpanID,
+	"ParseTraceID":         tqlotel.ParseTraceID,
 	"keep_keys":            tqlotel.KeepKeys,
 	"set":                  tqlcommon.Set,
 	"truncate_all":         tqlotel.TruncateAll,
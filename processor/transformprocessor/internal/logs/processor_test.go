@@ -61,6 +61,40 @@ func TestProcess(t *testing.T) {
 				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().InsertString("http.method", "get")
 			},
 		},
+		{
+			query: `keep_keys(attributes, ["http.method"]) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().Clear()
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().InsertString("http.method", "get")
+			},
+		},
+		{
+			query: `set(attributes["test"], "pass") where body in ["operationB", "operationC"]`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1).Attributes().InsertString("test", "pass")
+			},
+		},
+		{
+			query: `set(attributes["test"], "pass") where not body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1).Attributes().InsertString("test", "pass")
+			},
+		},
+		{
+			query: `set(attributes["test"], Concat(body, "-", severity_text)) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().InsertString("test", "operationA-")
+			},
+		},
+		{
+			query: `set(attributes["test"], Split(attributes["http.path"], "/")) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				arr := pcommon.NewValueSlice()
+				arr.SliceVal().AppendEmpty().SetStringVal("")
+				arr.SliceVal().AppendEmpty().SetStringVal("health")
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().Insert("test", arr)
+			},
+		},
 		{
 			query: `set(severity_text, "ok") where attributes["http.path"] == "/health"`,
 			want: func(td plog.Logs) {
@@ -16,6 +16,7 @@ package traces // import "github.com/open-telemetry/opentelemetry-collector-cont
 
 import (
 	"context"
+	"fmt"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -25,41 +26,102 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
 )
 
+// The contexts trace queries can be executed in.
+const (
+	// ContextSpan executes the queries once for each span. It is the default.
+	ContextSpan = "span"
+	// ContextSpanEvent executes the queries once for each event of each span.
+	ContextSpanEvent = "span_event"
+	// ContextSpanLink executes the queries once for each link of each span.
+	ContextSpanLink = "span_link"
+)
+
 type Processor struct {
-	queries []tql.Query
-	logger  *zap.Logger
+	queries    []tql.Query
+	tqlContext string
+	logger     *zap.Logger
+}
+
+// ParseQueries parses the statements for the given context.
+func ParseQueries(statements []string, tqlContext string, functions map[string]interface{}) ([]tql.Query, error) {
+	switch tqlContext {
+	case "", ContextSpan:
+		return tql.ParseQueries(statements, functions, tqltraces.ParsePath, tqltraces.ParseEnum)
+	case ContextSpanEvent:
+		return tql.ParseQueries(statements, functions, tqltraces.ParseSpanEventPath, tqltraces.ParseEnum)
+	case ContextSpanLink:
+		return tql.ParseQueries(statements, functions, tqltraces.ParseSpanLinkPath, tqltraces.ParseEnum)
+	}
+	return nil, fmt.Errorf("unsupported traces context %q, must be one of %q, %q or %q", tqlContext, ContextSpan, ContextSpanEvent, ContextSpanLink)
 }
 
-func NewProcessor(statements []string, functions map[string]interface{}, settings component.ProcessorCreateSettings) (*Processor, error) {
-	queries, err := tql.ParseQueries(statements, functions, tqltraces.ParsePath, tqltraces.ParseEnum)
+func NewProcessor(statements []string, tqlContext string, functions map[string]interface{}, settings component.ProcessorCreateSettings) (*Processor, error) {
+	queries, err := ParseQueries(statements, tqlContext, functions)
 	if err != nil {
 		return nil, err
 	}
 	return &Processor{
-		queries: queries,
-		logger:  settings.Logger,
+		queries:    queries,
+		tqlContext: tqlContext,
+		logger:     settings.Logger,
 	}, nil
 }
 
 func (p *Processor) ProcessTraces(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
-	ctx := tqltraces.SpanTransformContext{}
 	for i := 0; i < td.ResourceSpans().Len(); i++ {
 		rspans := td.ResourceSpans().At(i)
-		ctx.Resource = rspans.Resource()
 		for j := 0; j < rspans.ScopeSpans().Len(); j++ {
 			sspan := rspans.ScopeSpans().At(j)
-			ctx.InstrumentationScope = sspan.Scope()
 			spans := sspan.Spans()
 			for k := 0; k < spans.Len(); k++ {
-				ctx.Span = spans.At(k)
-
-				for _, statement := range p.queries {
-					if statement.Condition(ctx) {
-						statement.Function(ctx)
-					}
+				switch p.tqlContext {
+				case ContextSpanEvent:
+					p.processSpanEvents(spans.At(k), sspan, rspans)
+				case ContextSpanLink:
+					p.processSpanLinks(spans.At(k), sspan, rspans)
+				default:
+					p.execute(tqltraces.SpanTransformContext{
+						Span:                 spans.At(k),
+						InstrumentationScope: sspan.Scope(),
+						Resource:             rspans.Resource(),
+					})
 				}
 			}
 		}
 	}
 	return td, nil
 }
+
+func (p *Processor) processSpanEvents(span ptrace.Span, sspan ptrace.ScopeSpans, rspans ptrace.ResourceSpans) {
+	ctx := tqltraces.SpanEventTransformContext{
+		Span:                 span,
+		InstrumentationScope: sspan.Scope(),
+		Resource:             rspans.Resource(),
+	}
+	events := span.Events()
+	for l := 0; l < events.Len(); l++ {
+		ctx.SpanEvent = events.At(l)
+		p.execute(ctx)
+	}
+}
+
+func (p *Processor) processSpanLinks(span ptrace.Span, sspan ptrace.ScopeSpans, rspans ptrace.ResourceSpans) {
+	ctx := tqltraces.SpanLinkTransformContext{
+		Span:                 span,
+		InstrumentationScope: sspan.Scope(),
+		Resource:             rspans.Resource(),
+	}
+	links := span.Links()
+	for l := 0; l < links.Len(); l++ {
+		ctx.SpanLink = links.At(l)
+		p.execute(ctx)
+	}
+}
+
+func (p *Processor) execute(ctx tql.TransformContext) {
+	for _, statement := range p.queries {
+		if statement.Condition(ctx) {
+			statement.Function(ctx)
+		}
+	}
+}
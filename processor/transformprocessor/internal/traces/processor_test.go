@@ -170,7 +170,7 @@ func TestProcess(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.query, func(t *testing.T) {
 			td := constructTraces()
-			processor, err := NewProcessor([]string{tt.query}, Functions(), component.ProcessorCreateSettings{})
+			processor, err := NewProcessor([]string{tt.query}, ContextSpan, Functions(), component.ProcessorCreateSettings{})
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessTraces(context.Background(), td)
@@ -184,6 +184,102 @@ func TestProcess(t *testing.T) {
 	}
 }
 
+func TestProcessSpanEvents(t *testing.T) {
+	tests := []struct {
+		query string
+		want  func(td ptrace.Traces)
+	}{
+		{
+			query: `delete_key(attributes, "exception.stacktrace") where name == "exception"`,
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Events().At(0).Attributes().Remove("exception.stacktrace")
+			},
+		},
+		{
+			query: `set(attributes["span.name"], span.name)`,
+			want: func(td ptrace.Traces) {
+				events := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Events()
+				events.At(0).Attributes().InsertString("span.name", "operationA")
+				events.At(1).Attributes().InsertString("span.name", "operationA")
+			},
+		},
+		{
+			query: `set(name, "attempt") where name == "retry" and resource.attributes["host.name"] == "localhost"`,
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Events().At(1).SetName("attempt")
+			},
+		},
+		{
+			query: `set(span.name, "changed")`,
+			want:  func(td ptrace.Traces) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			td := constructTraces()
+			processor, err := NewProcessor([]string{tt.query}, ContextSpanEvent, Functions(), component.ProcessorCreateSettings{})
+			assert.NoError(t, err)
+
+			_, err = processor.ProcessTraces(context.Background(), td)
+			assert.NoError(t, err)
+
+			exTd := constructTraces()
+			tt.want(exTd)
+
+			assert.Equal(t, exTd, td)
+		})
+	}
+}
+
+func TestProcessSpanLinks(t *testing.T) {
+	tests := []struct {
+		query string
+		want  func(td ptrace.Traces)
+	}{
+		{
+			query: `set(attributes["test"], "pass") where span.name == "operationB"`,
+			want: func(td ptrace.Traces) {
+				links := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).Links()
+				links.At(0).Attributes().InsertString("test", "pass")
+				links.At(1).Attributes().InsertString("test", "pass")
+			},
+		},
+		{
+			query: `set(dropped_attributes_count, 0)`,
+			want: func(td ptrace.Traces) {
+				links := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).Links()
+				links.At(0).SetDroppedAttributesCount(0)
+				links.At(1).SetDroppedAttributesCount(0)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			td := constructTraces()
+			processor, err := NewProcessor([]string{tt.query}, ContextSpanLink, Functions(), component.ProcessorCreateSettings{})
+			assert.NoError(t, err)
+
+			_, err = processor.ProcessTraces(context.Background(), td)
+			assert.NoError(t, err)
+
+			exTd := constructTraces()
+			tt.want(exTd)
+
+			assert.Equal(t, exTd, td)
+		})
+	}
+}
+
+func TestNewProcessor_invalidContext(t *testing.T) {
+	_, err := NewProcessor([]string{`set(name, "bear")`}, "span_attribute", Functions(), component.ProcessorCreateSettings{})
+	assert.EqualError(t, err, `unsupported traces context "span_attribute", must be one of "span", "span_event" or "span_link"`)
+
+	_, err = NewProcessor([]string{`set(kind, 1)`}, ContextSpanEvent, Functions(), component.ProcessorCreateSettings{})
+	assert.Error(t, err)
+}
+
 func BenchmarkTwoSpans(b *testing.B) {
 	tests := []struct {
 		name    string
@@ -220,7 +316,7 @@ func BenchmarkTwoSpans(b *testing.B) {
 
 	for _, tt := range tests {
 		b.Run(tt.name, func(b *testing.B) {
-			processor, err := NewProcessor(tt.queries, Functions(), component.ProcessorCreateSettings{})
+			processor, err := NewProcessor(tt.queries, ContextSpan, Functions(), component.ProcessorCreateSettings{})
 			assert.NoError(b, err)
 			b.ResetTimer()
 			for n := 0; n < b.N; n++ {
@@ -262,7 +358,7 @@ func BenchmarkHundredSpans(b *testing.B) {
 	}
 	for _, tt := range tests {
 		b.Run(tt.name, func(b *testing.B) {
-			processor, err := NewProcessor(tt.queries, Functions(), component.ProcessorCreateSettings{})
+			processor, err := NewProcessor(tt.queries, ContextSpan, Functions(), component.ProcessorCreateSettings{})
 			assert.NoError(b, err)
 			b.ResetTimer()
 			for n := 0; n < b.N; n++ {
@@ -309,6 +405,11 @@ func fillSpanOne(span ptrace.Span) {
 	span.Attributes().InsertString("http.method", "get")
 	span.Attributes().InsertString("http.path", "/health")
 	span.Attributes().InsertString("http.url", "http://localhost/health")
+	event := span.Events().AppendEmpty()
+	event.SetName("exception")
+	event.Attributes().InsertString("exception.type", "error")
+	event.Attributes().InsertString("exception.stacktrace", "error at line 1")
+	span.Events().AppendEmpty().SetName("retry")
 	status := span.Status()
 	status.SetCode(ptrace.StatusCodeError)
 	status.SetMessage("status-cancelled")
@@ -15,6 +15,8 @@
 package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver"
 
 import (
+	"fmt"
+	"regexp"
 	"time"
 
 	"go.opentelemetry.io/collector/config"
@@ -42,6 +44,24 @@ type MessageMarking struct {
 	OnError bool `mapstructure:"on_error"`
 }
 
+// Targets of the attributes extracted from the kafka records.
+const (
+	headersTargetResource  = "resource"
+	headersTargetLogRecord = "log_record"
+)
+
+type HeaderExtraction struct {
+	// The names of the record headers to add as `kafka.header.<name>` attributes.
+	Headers []string `mapstructure:"headers"`
+
+	// Whether to add the record key as the `kafka.key` attribute.
+	ExtractKey bool `mapstructure:"extract_key"`
+
+	// Where the attributes are added: "resource" (default), or "log_record"
+	// to add them to each log record. Traces and metrics only support "resource".
+	Target string `mapstructure:"target"`
+}
+
 // Config defines configuration for Kafka receiver.
 type Config struct {
 	config.ReceiverSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
@@ -51,6 +71,11 @@ type Config struct {
 	ProtocolVersion string `mapstructure:"protocol_version"`
 	// The name of the kafka topic to consume from (default "otlp_spans")
 	Topic string `mapstructure:"topic"`
+	// The regular expression of the names of the kafka topics to consume from.
+	// When set, it is used instead of Topic.
+	TopicRegex string `mapstructure:"topic_regex"`
+	// How frequently the topics matching TopicRegex are looked up (default 1m)
+	TopicRefreshInterval time.Duration `mapstructure:"topic_refresh_interval"`
 	// Encoding of the messages (default "otlp_proto")
 	Encoding string `mapstructure:"encoding"`
 	// The consumer group that receiver will be consuming messages from (default "otel-collector")
@@ -69,11 +94,28 @@ type Config struct {
 
 	// Controls the way the messages are marked as consumed
 	MessageMarking MessageMarking `mapstructure:"message_marking"`
+
+	// Controls the record headers and keys added as attributes
+	HeaderExtraction HeaderExtraction `mapstructure:"header_extraction"`
 }
 
 var _ config.Receiver = (*Config)(nil)
 
 // Validate checks the receiver configuration is valid
 func (cfg *Config) Validate() error {
+	if cfg.TopicRegex != "" {
+		if _, err := regexp.Compile(cfg.TopicRegex); err != nil {
+			return fmt.Errorf("invalid topic_regex: %w", err)
+		}
+		if cfg.TopicRefreshInterval <= 0 {
+			return fmt.Errorf("topic_refresh_interval must be positive")
+		}
+	}
+	switch cfg.HeaderExtraction.Target {
+	case "", headersTargetResource, headersTargetLogRecord:
+	default:
+		return fmt.Errorf("invalid header_extraction target %q, must be %q or %q",
+			cfg.HeaderExtraction.Target, headersTargetResource, headersTargetLogRecord)
+	}
 	return nil
 }
@@ -41,12 +41,13 @@ func TestLoadConfig(t *testing.T) {
 
 	r := cfg.Receivers[config.NewComponentID(typeStr)].(*Config)
 	assert.Equal(t, &Config{
-		ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
-		Topic:            "spans",
-		Encoding:         "otlp_proto",
-		Brokers:          []string{"foo:123", "bar:456"},
-		ClientID:         "otel-collector",
-		GroupID:          "otel-collector",
+		ReceiverSettings:     config.NewReceiverSettings(config.NewComponentID(typeStr)),
+		Topic:                "spans",
+		TopicRefreshInterval: time.Minute,
+		Encoding:             "otlp_proto",
+		Brokers:              []string{"foo:123", "bar:456"},
+		ClientID:             "otel-collector",
+		GroupID:              "otel-collector",
 		Authentication: kafkaexporter.Authentication{
 			TLS: &configtls.TLSClientSetting{
 				TLSSetting: configtls.TLSSetting{
@@ -67,5 +68,67 @@ func TestLoadConfig(t *testing.T) {
 			Enable:   true,
 			Interval: 1 * time.Second,
 		},
+		HeaderExtraction: HeaderExtraction{
+			Target: "resource",
+		},
 	}, r)
 }
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    string
+	}{
+		{
+			name:   "default",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name: "topic_regex",
+			modify: func(cfg *Config) {
+				cfg.TopicRegex = "^team-.*-logs$"
+				cfg.HeaderExtraction = HeaderExtraction{
+					Headers:    []string{"team"},
+					ExtractKey: true,
+					Target:     "log_record",
+				}
+			},
+		},
+		{
+			name: "invalid_topic_regex",
+			modify: func(cfg *Config) {
+				cfg.TopicRegex = "team-("
+			},
+			err: "invalid topic_regex",
+		},
+		{
+			name: "invalid_topic_refresh_interval",
+			modify: func(cfg *Config) {
+				cfg.TopicRegex = "^team-.*-logs$"
+				cfg.TopicRefreshInterval = 0
+			},
+			err: "topic_refresh_interval must be positive",
+		},
+		{
+			name: "invalid_header_extraction_target",
+			modify: func(cfg *Config) {
+				cfg.HeaderExtraction.Target = "scope"
+			},
+			err: `invalid header_extraction target "scope"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			test.modify(cfg)
+			err := cfg.Validate()
+			if test.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.err)
+		})
+	}
+}
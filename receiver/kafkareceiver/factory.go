@@ -30,11 +30,13 @@ const (
 	typeStr   = "kafka"
 	stability = component.StabilityLevelBeta
 
-	defaultTopic    = "otlp_spans"
-	defaultEncoding = "otlp_proto"
-	defaultBroker   = "localhost:9092"
-	defaultClientID = "otel-collector"
-	defaultGroupID  = defaultClientID
+	defaultTopic = "otlp_spans"
+	// default interval between the lookups of the topics matching topic_regex
+	defaultTopicRefreshInterval = time.Minute
+	defaultEncoding             = "otlp_proto"
+	defaultBroker               = "localhost:9092"
+	defaultClientID             = "otel-collector"
+	defaultGroupID              = defaultClientID
 
 	// default from sarama.NewConfig()
 	defaultMetadataRetryMax = 3
@@ -102,12 +104,13 @@ func NewFactory(options ...FactoryOption) component.ReceiverFactory {
 
 func createDefaultConfig() config.Receiver {
 	return &Config{
-		ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
-		Topic:            defaultTopic,
-		Encoding:         defaultEncoding,
-		Brokers:          []string{defaultBroker},
-		ClientID:         defaultClientID,
-		GroupID:          defaultGroupID,
+		ReceiverSettings:     config.NewReceiverSettings(config.NewComponentID(typeStr)),
+		Topic:                defaultTopic,
+		TopicRefreshInterval: defaultTopicRefreshInterval,
+		Encoding:             defaultEncoding,
+		Brokers:              []string{defaultBroker},
+		ClientID:             defaultClientID,
+		GroupID:              defaultGroupID,
 		Metadata: kafkaexporter.Metadata{
 			Full: defaultMetadataFull,
 			Retry: kafkaexporter.MetadataRetry{
@@ -123,6 +126,9 @@ func createDefaultConfig() config.Receiver {
 			After:   false,
 			OnError: false,
 		},
+		HeaderExtraction: HeaderExtraction{
+			Target: headersTargetResource,
+		},
 	}
 }
 
@@ -0,0 +1,115 @@
+// Copyright 2020 The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver"
+
+import (
+	"github.com/Shopify/sarama"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	headerAttributePrefix = "kafka.header."
+	keyAttribute          = "kafka.key"
+)
+
+// headerExtractor adds the configured headers and the key of the kafka
+// records as attributes of the unmarshaled data.
+type headerExtractor struct {
+	headers    []string
+	extractKey bool
+	target     string
+}
+
+func newHeaderExtractor(cfg HeaderExtraction) headerExtractor {
+	return headerExtractor{
+		headers:    cfg.Headers,
+		extractKey: cfg.ExtractKey,
+		target:     cfg.Target,
+	}
+}
+
+func (e headerExtractor) enabled() bool {
+	return len(e.headers) > 0 || e.extractKey
+}
+
+// attributes returns the attributes extracted from the message
+func (e headerExtractor) attributes(message *sarama.ConsumerMessage) pcommon.Map {
+	attrs := pcommon.NewMap()
+	for _, name := range e.headers {
+		for _, header := range message.Headers {
+			if header != nil && string(header.Key) == name {
+				attrs.UpsertString(headerAttributePrefix+name, string(header.Value))
+			}
+		}
+	}
+	if e.extractKey && message.Key != nil {
+		attrs.UpsertString(keyAttribute, string(message.Key))
+	}
+	return attrs
+}
+
+func (e headerExtractor) extractTraces(message *sarama.ConsumerMessage, traces ptrace.Traces) {
+	if !e.enabled() {
+		return
+	}
+	attrs := e.attributes(message)
+	rss := traces.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		upsertAll(attrs, rss.At(i).Resource().Attributes())
+	}
+}
+
+func (e headerExtractor) extractMetrics(message *sarama.ConsumerMessage, metrics pmetric.Metrics) {
+	if !e.enabled() {
+		return
+	}
+	attrs := e.attributes(message)
+	rms := metrics.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		upsertAll(attrs, rms.At(i).Resource().Attributes())
+	}
+}
+
+func (e headerExtractor) extractLogs(message *sarama.ConsumerMessage, logs plog.Logs) {
+	if !e.enabled() {
+		return
+	}
+	attrs := e.attributes(message)
+	rls := logs.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		if e.target != headersTargetLogRecord {
+			upsertAll(attrs, rl.Resource().Attributes())
+			continue
+		}
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				upsertAll(attrs, lrs.At(k).Attributes())
+			}
+		}
+	}
+}
+
+func upsertAll(from pcommon.Map, to pcommon.Map) {
+	from.Range(func(k string, v pcommon.Value) bool {
+		to.Upsert(k, v)
+		return true
+	})
+}
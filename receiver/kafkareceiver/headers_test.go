@@ -0,0 +1,95 @@
+// Copyright 2020 The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkareceiver
+
+import (
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func testHeaderMessage() *sarama.ConsumerMessage {
+	return &sarama.ConsumerMessage{
+		Key: []byte("user-1"),
+		Headers: []*sarama.RecordHeader{
+			{Key: []byte("team"), Value: []byte("payments")},
+			{Key: []byte("ignored"), Value: []byte("foo")},
+		},
+	}
+}
+
+func TestHeaderExtractor_disabled(t *testing.T) {
+	e := newHeaderExtractor(HeaderExtraction{Target: headersTargetResource})
+	traces := ptrace.NewTraces()
+	traces.ResourceSpans().AppendEmpty()
+	e.extractTraces(testHeaderMessage(), traces)
+	assert.Equal(t, 0, traces.ResourceSpans().At(0).Resource().Attributes().Len())
+}
+
+func TestHeaderExtractor_resource(t *testing.T) {
+	e := newHeaderExtractor(HeaderExtraction{
+		Headers:    []string{"team", "missing"},
+		ExtractKey: true,
+		Target:     headersTargetResource,
+	})
+	expected := pcommon.NewMapFromRaw(map[string]interface{}{
+		"kafka.header.team": "payments",
+		"kafka.key":         "user-1",
+	})
+
+	traces := ptrace.NewTraces()
+	traces.ResourceSpans().AppendEmpty()
+	e.extractTraces(testHeaderMessage(), traces)
+	assert.Equal(t, expected.Sort(), traces.ResourceSpans().At(0).Resource().Attributes().Sort())
+
+	metrics := pmetric.NewMetrics()
+	metrics.ResourceMetrics().AppendEmpty()
+	e.extractMetrics(testHeaderMessage(), metrics)
+	assert.Equal(t, expected.Sort(), metrics.ResourceMetrics().At(0).Resource().Attributes().Sort())
+
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	e.extractLogs(testHeaderMessage(), logs)
+	assert.Equal(t, expected.Sort(), rl.Resource().Attributes().Sort())
+	assert.Equal(t, 0, lr.Attributes().Len())
+}
+
+func TestHeaderExtractor_logRecord(t *testing.T) {
+	e := newHeaderExtractor(HeaderExtraction{
+		Headers: []string{"team"},
+		Target:  headersTargetLogRecord,
+	})
+	expected := pcommon.NewMapFromRaw(map[string]interface{}{
+		"kafka.header.team": "payments",
+	})
+
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	lrs := rl.ScopeLogs().AppendEmpty().LogRecords()
+	lrs.AppendEmpty()
+	lrs.AppendEmpty().Attributes().InsertString("foo", "bar")
+	e.extractLogs(testHeaderMessage(), logs)
+
+	assert.Equal(t, 0, rl.Resource().Attributes().Len())
+	assert.Equal(t, expected.Sort(), lrs.At(0).Attributes().Sort())
+	expected.InsertString("foo", "bar")
+	assert.Equal(t, expected.Sort(), lrs.At(1).Attributes().Sort())
+}
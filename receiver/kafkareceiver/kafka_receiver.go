@@ -26,6 +26,7 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
@@ -42,9 +43,10 @@ type kafkaTracesConsumer struct {
 	id                config.ComponentID
 	consumerGroup     sarama.ConsumerGroup
 	nextConsumer      consumer.Traces
-	topics            []string
+	subscription      topicSubscription
 	cancelConsumeLoop context.CancelFunc
 	unmarshaler       TracesUnmarshaler
+	headerExtractor   headerExtractor
 
 	settings component.ReceiverCreateSettings
 
@@ -57,9 +59,10 @@ type kafkaMetricsConsumer struct {
 	id                config.ComponentID
 	consumerGroup     sarama.ConsumerGroup
 	nextConsumer      consumer.Metrics
-	topics            []string
+	subscription      topicSubscription
 	cancelConsumeLoop context.CancelFunc
 	unmarshaler       MetricsUnmarshaler
+	headerExtractor   headerExtractor
 
 	settings component.ReceiverCreateSettings
 
@@ -72,9 +75,10 @@ type kafkaLogsConsumer struct {
 	id                config.ComponentID
 	consumerGroup     sarama.ConsumerGroup
 	nextConsumer      consumer.Logs
-	topics            []string
+	subscription      topicSubscription
 	cancelConsumeLoop context.CancelFunc
 	unmarshaler       LogsUnmarshaler
+	headerExtractor   headerExtractor
 
 	settings component.ReceiverCreateSettings
 
@@ -111,12 +115,18 @@ func newTracesReceiver(config Config, set component.ReceiverCreateSettings, unma
 	if err != nil {
 		return nil, err
 	}
+	subscription, err := newTopicSubscription(config, c)
+	if err != nil {
+		_ = client.Close()
+		return nil, err
+	}
 	return &kafkaTracesConsumer{
 		id:                config.ID(),
 		consumerGroup:     client,
-		topics:            []string{config.Topic},
+		subscription:      subscription,
 		nextConsumer:      nextConsumer,
 		unmarshaler:       unmarshaler,
+		headerExtractor:   newHeaderExtractor(config.HeaderExtraction),
 		settings:          set,
 		autocommitEnabled: config.AutoCommit.Enable,
 		messageMarking:    config.MessageMarking,
@@ -127,11 +137,12 @@ func (c *kafkaTracesConsumer) Start(context.Context, component.Host) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	c.cancelConsumeLoop = cancel
 	consumerGroup := &tracesConsumerGroupHandler{
-		id:           c.id,
-		logger:       c.settings.Logger,
-		unmarshaler:  c.unmarshaler,
-		nextConsumer: c.nextConsumer,
-		ready:        make(chan bool),
+		id:              c.id,
+		logger:          c.settings.Logger,
+		unmarshaler:     c.unmarshaler,
+		headerExtractor: c.headerExtractor,
+		nextConsumer:    c.nextConsumer,
+		ready:           make(chan bool),
 		obsrecv: obsreport.NewReceiver(obsreport.ReceiverSettings{
 			ReceiverID:             c.id,
 			Transport:              transport,
@@ -145,14 +156,20 @@ func (c *kafkaTracesConsumer) Start(context.Context, component.Host) error {
 	return nil
 }
 
-func (c *kafkaTracesConsumer) consumeLoop(ctx context.Context, handler sarama.ConsumerGroupHandler) error {
+func (c *kafkaTracesConsumer) consumeLoop(ctx context.Context, handler readyConsumerGroupHandler) error {
 	for {
+		topics, sessionCtx, cancelSession, err := c.subscription.session(ctx, c.settings.Logger, handler.setReady)
+		if err != nil {
+			c.settings.Logger.Info("Consumer stopped", zap.Error(err))
+			return err
+		}
 		// `Consume` should be called inside an infinite loop, when a
 		// server-side rebalance happens, the consumer session will need to be
 		// recreated to get the new claims
-		if err := c.consumerGroup.Consume(ctx, c.topics, handler); err != nil {
+		if err := c.consumerGroup.Consume(sessionCtx, topics, handler); err != nil {
 			c.settings.Logger.Error("Error from consumer", zap.Error(err))
 		}
+		cancelSession()
 		// check if context was cancelled, signaling that the consumer should stop
 		if ctx.Err() != nil {
 			c.settings.Logger.Info("Consumer stopped", zap.Error(ctx.Err()))
@@ -163,7 +180,7 @@ func (c *kafkaTracesConsumer) consumeLoop(ctx context.Context, handler sarama.Co
 
 func (c *kafkaTracesConsumer) Shutdown(context.Context) error {
 	c.cancelConsumeLoop()
-	return c.consumerGroup.Close()
+	return multierr.Append(c.consumerGroup.Close(), c.subscription.close())
 }
 
 func newMetricsReceiver(config Config, set component.ReceiverCreateSettings, unmarshalers map[string]MetricsUnmarshaler, nextConsumer consumer.Metrics) (*kafkaMetricsConsumer, error) {
@@ -194,12 +211,18 @@ func newMetricsReceiver(config Config, set component.ReceiverCreateSettings, unm
 	if err != nil {
 		return nil, err
 	}
+	subscription, err := newTopicSubscription(config, c)
+	if err != nil {
+		_ = client.Close()
+		return nil, err
+	}
 	return &kafkaMetricsConsumer{
 		id:                config.ID(),
 		consumerGroup:     client,
-		topics:            []string{config.Topic},
+		subscription:      subscription,
 		nextConsumer:      nextConsumer,
 		unmarshaler:       unmarshaler,
+		headerExtractor:   newHeaderExtractor(config.HeaderExtraction),
 		settings:          set,
 		autocommitEnabled: config.AutoCommit.Enable,
 		messageMarking:    config.MessageMarking,
@@ -210,11 +233,12 @@ func (c *kafkaMetricsConsumer) Start(context.Context, component.Host) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	c.cancelConsumeLoop = cancel
 	metricsConsumerGroup := &metricsConsumerGroupHandler{
-		id:           c.id,
-		logger:       c.settings.Logger,
-		unmarshaler:  c.unmarshaler,
-		nextConsumer: c.nextConsumer,
-		ready:        make(chan bool),
+		id:              c.id,
+		logger:          c.settings.Logger,
+		unmarshaler:     c.unmarshaler,
+		headerExtractor: c.headerExtractor,
+		nextConsumer:    c.nextConsumer,
+		ready:           make(chan bool),
 		obsrecv: obsreport.NewReceiver(obsreport.ReceiverSettings{
 			ReceiverID:             c.id,
 			Transport:              transport,
@@ -228,14 +252,20 @@ func (c *kafkaMetricsConsumer) Start(context.Context, component.Host) error {
 	return nil
 }
 
-func (c *kafkaMetricsConsumer) consumeLoop(ctx context.Context, handler sarama.ConsumerGroupHandler) error {
+func (c *kafkaMetricsConsumer) consumeLoop(ctx context.Context, handler readyConsumerGroupHandler) error {
 	for {
+		topics, sessionCtx, cancelSession, err := c.subscription.session(ctx, c.settings.Logger, handler.setReady)
+		if err != nil {
+			c.settings.Logger.Info("Consumer stopped", zap.Error(err))
+			return err
+		}
 		// `Consume` should be called inside an infinite loop, when a
 		// server-side rebalance happens, the consumer session will need to be
 		// recreated to get the new claims
-		if err := c.consumerGroup.Consume(ctx, c.topics, handler); err != nil {
+		if err := c.consumerGroup.Consume(sessionCtx, topics, handler); err != nil {
 			c.settings.Logger.Error("Error from consumer", zap.Error(err))
 		}
+		cancelSession()
 		// check if context was cancelled, signaling that the consumer should stop
 		if ctx.Err() != nil {
 			c.settings.Logger.Info("Consumer stopped", zap.Error(ctx.Err()))
@@ -246,7 +276,7 @@ func (c *kafkaMetricsConsumer) consumeLoop(ctx context.Context, handler sarama.C
 
 func (c *kafkaMetricsConsumer) Shutdown(context.Context) error {
 	c.cancelConsumeLoop()
-	return c.consumerGroup.Close()
+	return multierr.Append(c.consumerGroup.Close(), c.subscription.close())
 }
 
 func newLogsReceiver(config Config, set component.ReceiverCreateSettings, unmarshalers map[string]LogsUnmarshaler, nextConsumer consumer.Logs) (*kafkaLogsConsumer, error) {
@@ -274,12 +304,18 @@ func newLogsReceiver(config Config, set component.ReceiverCreateSettings, unmars
 	if err != nil {
 		return nil, err
 	}
+	subscription, err := newTopicSubscription(config, c)
+	if err != nil {
+		_ = client.Close()
+		return nil, err
+	}
 	return &kafkaLogsConsumer{
 		id:                config.ID(),
 		consumerGroup:     client,
-		topics:            []string{config.Topic},
+		subscription:      subscription,
 		nextConsumer:      nextConsumer,
 		unmarshaler:       unmarshaler,
+		headerExtractor:   newHeaderExtractor(config.HeaderExtraction),
 		settings:          set,
 		autocommitEnabled: config.AutoCommit.Enable,
 		messageMarking:    config.MessageMarking,
@@ -290,11 +326,12 @@ func (c *kafkaLogsConsumer) Start(context.Context, component.Host) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	c.cancelConsumeLoop = cancel
 	logsConsumerGroup := &logsConsumerGroupHandler{
-		id:           c.id,
-		logger:       c.settings.Logger,
-		unmarshaler:  c.unmarshaler,
-		nextConsumer: c.nextConsumer,
-		ready:        make(chan bool),
+		id:              c.id,
+		logger:          c.settings.Logger,
+		unmarshaler:     c.unmarshaler,
+		headerExtractor: c.headerExtractor,
+		nextConsumer:    c.nextConsumer,
+		ready:           make(chan bool),
 		obsrecv: obsreport.NewReceiver(obsreport.ReceiverSettings{
 			ReceiverID:             c.id,
 			Transport:              transport,
@@ -308,14 +345,20 @@ func (c *kafkaLogsConsumer) Start(context.Context, component.Host) error {
 	return nil
 }
 
-func (c *kafkaLogsConsumer) consumeLoop(ctx context.Context, handler sarama.ConsumerGroupHandler) error {
+func (c *kafkaLogsConsumer) consumeLoop(ctx context.Context, handler readyConsumerGroupHandler) error {
 	for {
+		topics, sessionCtx, cancelSession, err := c.subscription.session(ctx, c.settings.Logger, handler.setReady)
+		if err != nil {
+			c.settings.Logger.Info("Consumer stopped", zap.Error(err))
+			return err
+		}
 		// `Consume` should be called inside an infinite loop, when a
 		// server-side rebalance happens, the consumer session will need to be
 		// recreated to get the new claims
-		if err := c.consumerGroup.Consume(ctx, c.topics, handler); err != nil {
+		if err := c.consumerGroup.Consume(sessionCtx, topics, handler); err != nil {
 			c.settings.Logger.Error("Error from consumer", zap.Error(err))
 		}
+		cancelSession()
 		// check if context was cancelled, signaling that the consumer should stop
 		if ctx.Err() != nil {
 			c.settings.Logger.Info("Consumer stopped", zap.Error(ctx.Err()))
@@ -326,15 +369,16 @@ func (c *kafkaLogsConsumer) consumeLoop(ctx context.Context, handler sarama.Cons
 
 func (c *kafkaLogsConsumer) Shutdown(context.Context) error {
 	c.cancelConsumeLoop()
-	return c.consumerGroup.Close()
+	return multierr.Append(c.consumerGroup.Close(), c.subscription.close())
 }
 
 type tracesConsumerGroupHandler struct {
-	id           config.ComponentID
-	unmarshaler  TracesUnmarshaler
-	nextConsumer consumer.Traces
-	ready        chan bool
-	readyCloser  sync.Once
+	id              config.ComponentID
+	unmarshaler     TracesUnmarshaler
+	headerExtractor headerExtractor
+	nextConsumer    consumer.Traces
+	ready           chan bool
+	readyCloser     sync.Once
 
 	logger *zap.Logger
 
@@ -345,11 +389,12 @@ type tracesConsumerGroupHandler struct {
 }
 
 type metricsConsumerGroupHandler struct {
-	id           config.ComponentID
-	unmarshaler  MetricsUnmarshaler
-	nextConsumer consumer.Metrics
-	ready        chan bool
-	readyCloser  sync.Once
+	id              config.ComponentID
+	unmarshaler     MetricsUnmarshaler
+	headerExtractor headerExtractor
+	nextConsumer    consumer.Metrics
+	ready           chan bool
+	readyCloser     sync.Once
 
 	logger *zap.Logger
 
@@ -360,11 +405,12 @@ type metricsConsumerGroupHandler struct {
 }
 
 type logsConsumerGroupHandler struct {
-	id           config.ComponentID
-	unmarshaler  LogsUnmarshaler
-	nextConsumer consumer.Logs
-	ready        chan bool
-	readyCloser  sync.Once
+	id              config.ComponentID
+	unmarshaler     LogsUnmarshaler
+	headerExtractor headerExtractor
+	nextConsumer    consumer.Logs
+	ready           chan bool
+	readyCloser     sync.Once
 
 	logger *zap.Logger
 
@@ -374,14 +420,34 @@ type logsConsumerGroupHandler struct {
 	messageMarking    MessageMarking
 }
 
-var _ sarama.ConsumerGroupHandler = (*tracesConsumerGroupHandler)(nil)
-var _ sarama.ConsumerGroupHandler = (*metricsConsumerGroupHandler)(nil)
-var _ sarama.ConsumerGroupHandler = (*logsConsumerGroupHandler)(nil)
+// readyConsumerGroupHandler is a consumer group handler that can signal the
+// consumer is started before its first session, when no topic matches yet
+type readyConsumerGroupHandler interface {
+	sarama.ConsumerGroupHandler
+	setReady()
+}
 
-func (c *tracesConsumerGroupHandler) Setup(session sarama.ConsumerGroupSession) error {
+var _ readyConsumerGroupHandler = (*tracesConsumerGroupHandler)(nil)
+var _ readyConsumerGroupHandler = (*metricsConsumerGroupHandler)(nil)
+var _ readyConsumerGroupHandler = (*logsConsumerGroupHandler)(nil)
+
+// recordUnmarshalFailure counts a message of the topic failing to be unmarshaled
+func recordUnmarshalFailure(ctx context.Context, id config.ComponentID, topic string) {
+	_ = stats.RecordWithTags(
+		ctx,
+		[]tag.Mutator{tag.Insert(tagInstanceName, id.String()), tag.Insert(tagTopic, topic)},
+		statUnmarshalFailures.M(1))
+}
+
+// setReady signals the consumer is started
+func (c *tracesConsumerGroupHandler) setReady() {
 	c.readyCloser.Do(func() {
 		close(c.ready)
 	})
+}
+
+func (c *tracesConsumerGroupHandler) Setup(session sarama.ConsumerGroupSession) error {
+	c.setReady()
 	statsTags := []tag.Mutator{tag.Insert(tagInstanceName, c.id.Name())}
 	_ = stats.RecordWithTags(session.Context(), statsTags, statPartitionStart.M(1))
 	return nil
@@ -417,11 +483,13 @@ func (c *tracesConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSe
 		traces, err := c.unmarshaler.Unmarshal(message.Value)
 		if err != nil {
 			c.logger.Error("failed to unmarshal message", zap.Error(err))
+			recordUnmarshalFailure(ctx, c.id, message.Topic)
 			if c.messageMarking.After && c.messageMarking.OnError {
 				session.MarkMessage(message, "")
 			}
 			return err
 		}
+		c.headerExtractor.extractTraces(message, traces)
 
 		spanCount := traces.SpanCount()
 		err = c.nextConsumer.ConsumeTraces(session.Context(), traces)
@@ -442,10 +510,15 @@ func (c *tracesConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSe
 	return nil
 }
 
-func (c *metricsConsumerGroupHandler) Setup(session sarama.ConsumerGroupSession) error {
+// setReady signals the consumer is started
+func (c *metricsConsumerGroupHandler) setReady() {
 	c.readyCloser.Do(func() {
 		close(c.ready)
 	})
+}
+
+func (c *metricsConsumerGroupHandler) Setup(session sarama.ConsumerGroupSession) error {
+	c.setReady()
 	statsTags := []tag.Mutator{tag.Insert(tagInstanceName, c.id.Name())}
 	_ = stats.RecordWithTags(session.Context(), statsTags, statPartitionStart.M(1))
 	return nil
@@ -482,11 +555,13 @@ func (c *metricsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupS
 		metrics, err := c.unmarshaler.Unmarshal(message.Value)
 		if err != nil {
 			c.logger.Error("failed to unmarshal message", zap.Error(err))
+			recordUnmarshalFailure(ctx, c.id, message.Topic)
 			if c.messageMarking.After && c.messageMarking.OnError {
 				session.MarkMessage(message, "")
 			}
 			return err
 		}
+		c.headerExtractor.extractMetrics(message, metrics)
 
 		dataPointCount := metrics.DataPointCount()
 		err = c.nextConsumer.ConsumeMetrics(session.Context(), metrics)
@@ -507,10 +582,15 @@ func (c *metricsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupS
 	return nil
 }
 
-func (c *logsConsumerGroupHandler) Setup(session sarama.ConsumerGroupSession) error {
+// setReady signals the consumer is started
+func (c *logsConsumerGroupHandler) setReady() {
 	c.readyCloser.Do(func() {
 		close(c.ready)
 	})
+}
+
+func (c *logsConsumerGroupHandler) Setup(session sarama.ConsumerGroupSession) error {
+	c.setReady()
 	_ = stats.RecordWithTags(
 		session.Context(),
 		[]tag.Mutator{tag.Insert(tagInstanceName, c.id.String())},
@@ -551,11 +631,13 @@ func (c *logsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSess
 		logs, err := c.unmarshaler.Unmarshal(message.Value)
 		if err != nil {
 			c.logger.Error("failed to unmarshal message", zap.Error(err))
+			recordUnmarshalFailure(ctx, c.id, message.Topic)
 			if c.messageMarking.After && c.messageMarking.OnError {
 				session.MarkMessage(message, "")
 			}
 			return err
 		}
+		c.headerExtractor.extractLogs(message, logs)
 
 		err = c.nextConsumer.ConsumeLogs(session.Context(), logs)
 		// TODO
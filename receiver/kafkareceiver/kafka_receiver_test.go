@@ -25,6 +25,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/consumer/consumertest"
@@ -550,6 +551,11 @@ func TestLogsConsumerGroupHandler(t *testing.T) {
 }
 
 func TestLogsConsumerGroupHandler_error_unmarshal(t *testing.T) {
+	view.Unregister(MetricViews()...)
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
 	c := logsConsumerGroupHandler{
 		unmarshaler:  newPdataLogsUnmarshaler(plog.NewProtoUnmarshaler(), defaultEncoding),
 		logger:       zap.NewNop(),
@@ -568,9 +574,58 @@ func TestLogsConsumerGroupHandler_error_unmarshal(t *testing.T) {
 		require.Error(t, err)
 		wg.Done()
 	}()
-	groupClaim.messageChan <- &sarama.ConsumerMessage{Value: []byte("!@#")}
+	groupClaim.messageChan <- &sarama.ConsumerMessage{Value: []byte("!@#"), Topic: "team-a-logs"}
+	close(groupClaim.messageChan)
+	wg.Wait()
+
+	viewData, err := view.RetrieveData(statUnmarshalFailures.Name())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(viewData))
+	assert.Contains(t, viewData[0].Tags, tag.Tag{Key: tagTopic, Value: "team-a-logs"})
+	assert.Equal(t, float64(1), viewData[0].Data.(*view.SumData).Value)
+}
+
+func TestLogsConsumerGroupHandler_headerExtraction(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	c := logsConsumerGroupHandler{
+		unmarshaler: textLogsUnmarshaler{},
+		headerExtractor: newHeaderExtractor(HeaderExtraction{
+			Headers:    []string{"team"},
+			ExtractKey: true,
+			Target:     headersTargetLogRecord,
+		}),
+		logger:       zap.NewNop(),
+		ready:        make(chan bool),
+		nextConsumer: sink,
+		obsrecv:      obsreport.NewReceiver(obsreport.ReceiverSettings{ReceiverCreateSettings: componenttest.NewNopReceiverCreateSettings()}),
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	groupClaim := &testConsumerGroupClaim{
+		messageChan: make(chan *sarama.ConsumerMessage),
+	}
+	go func() {
+		assert.NoError(t, c.ConsumeClaim(testConsumerGroupSession{}, groupClaim))
+		wg.Done()
+	}()
+	groupClaim.messageChan <- &sarama.ConsumerMessage{
+		Value:   []byte("foo"),
+		Key:     []byte("user-1"),
+		Headers: []*sarama.RecordHeader{{Key: []byte("team"), Value: []byte("payments")}},
+	}
 	close(groupClaim.messageChan)
 	wg.Wait()
+
+	require.Equal(t, 1, len(sink.AllLogs()))
+	lr := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, "foo", lr.Body().StringVal())
+	team, ok := lr.Attributes().Get("kafka.header.team")
+	require.True(t, ok)
+	assert.Equal(t, "payments", team.StringVal())
+	key, ok := lr.Attributes().Get("kafka.key")
+	require.True(t, ok)
+	assert.Equal(t, "user-1", key.StringVal())
 }
 
 func TestLogsConsumerGroupHandler_error_nextConsumer(t *testing.T) {
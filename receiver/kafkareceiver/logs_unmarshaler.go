@@ -0,0 +1,68 @@
+// Copyright 2020 The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver"
+
+import (
+	"encoding/json"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// jsonLogsUnmarshaler creates a log record per message, its body being the
+// JSON object of the message.
+type jsonLogsUnmarshaler struct {
+}
+
+var _ LogsUnmarshaler = (*jsonLogsUnmarshaler)(nil)
+
+func (j jsonLogsUnmarshaler) Unmarshal(data []byte) (plog.Logs, error) {
+	var object map[string]interface{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return plog.NewLogs(), err
+	}
+	logs, lr := newSingleRecordLogs()
+	body := pcommon.NewValueMap()
+	pcommon.NewMapFromRaw(object).CopyTo(body.MapVal())
+	body.CopyTo(lr.Body())
+	return logs, nil
+}
+
+func (j jsonLogsUnmarshaler) Encoding() string {
+	return "json"
+}
+
+// textLogsUnmarshaler creates a log record per message, its body being the
+// message as a string.
+type textLogsUnmarshaler struct {
+}
+
+var _ LogsUnmarshaler = (*textLogsUnmarshaler)(nil)
+
+func (t textLogsUnmarshaler) Unmarshal(data []byte) (plog.Logs, error) {
+	logs, lr := newSingleRecordLogs()
+	lr.Body().SetStringVal(string(data))
+	return logs, nil
+}
+
+func (t textLogsUnmarshaler) Encoding() string {
+	return "text"
+}
+
+func newSingleRecordLogs() (plog.Logs, plog.LogRecord) {
+	logs := plog.NewLogs()
+	lr := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	return logs, lr
+}
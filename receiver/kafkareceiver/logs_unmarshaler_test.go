@@ -0,0 +1,63 @@
+// Copyright 2020 The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkareceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestUnmarshalJSONLogs(t *testing.T) {
+	expected := plog.NewLogs()
+	body := pcommon.NewValueMap()
+	pcommon.NewMapFromRaw(map[string]interface{}{
+		"message": "foo",
+		"count":   float64(3),
+		"nested": map[string]interface{}{
+			"ok": true,
+		},
+	}).CopyTo(body.MapVal())
+	body.CopyTo(expected.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body())
+
+	u := jsonLogsUnmarshaler{}
+	got, err := u.Unmarshal([]byte(`{"message": "foo", "count": 3, "nested": {"ok": true}}`))
+	require.NoError(t, err)
+	assert.Equal(t, expected, got)
+	assert.Equal(t, "json", u.Encoding())
+}
+
+func TestUnmarshalJSONLogs_error(t *testing.T) {
+	u := jsonLogsUnmarshaler{}
+	for _, data := range []string{"+$%", `["not", "an", "object"]`} {
+		got, err := u.Unmarshal([]byte(data))
+		assert.Error(t, err)
+		assert.Equal(t, plog.NewLogs(), got)
+	}
+}
+
+func TestUnmarshalTextLogs(t *testing.T) {
+	expected := plog.NewLogs()
+	expected.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStringVal("foo bar")
+
+	u := textLogsUnmarshaler{}
+	got, err := u.Unmarshal([]byte("foo bar"))
+	require.NoError(t, err)
+	assert.Equal(t, expected, got)
+	assert.Equal(t, "text", u.Encoding())
+}
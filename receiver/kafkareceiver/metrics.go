@@ -22,6 +22,7 @@ import (
 
 var (
 	tagInstanceName, _ = tag.NewKey("name")
+	tagTopic, _        = tag.NewKey("topic")
 
 	statMessageCount     = stats.Int64("kafka_receiver_messages", "Number of received messages", stats.UnitDimensionless)
 	statMessageOffset    = stats.Int64("kafka_receiver_current_offset", "Current message offset", stats.UnitDimensionless)
@@ -29,6 +30,8 @@ var (
 
 	statPartitionStart = stats.Int64("kafka_receiver_partition_start", "Number of started partitions", stats.UnitDimensionless)
 	statPartitionClose = stats.Int64("kafka_receiver_partition_close", "Number of finished partitions", stats.UnitDimensionless)
+
+	statUnmarshalFailures = stats.Int64("kafka_receiver_unmarshal_failures", "Number of messages failing to be unmarshaled", stats.UnitDimensionless)
 )
 
 // MetricViews return metric views for Kafka receiver.
@@ -75,11 +78,20 @@ func MetricViews() []*view.View {
 		Aggregation: view.Sum(),
 	}
 
+	countUnmarshalFailures := &view.View{
+		Name:        statUnmarshalFailures.Name(),
+		Measure:     statUnmarshalFailures,
+		Description: statUnmarshalFailures.Description(),
+		TagKeys:     []tag.Key{tagInstanceName, tagTopic},
+		Aggregation: view.Sum(),
+	}
+
 	return []*view.View{
 		countMessages,
 		lastValueOffset,
 		lastValueOffsetLag,
 		countPartitionStart,
 		countPartitionClose,
+		countUnmarshalFailures,
 	}
 }
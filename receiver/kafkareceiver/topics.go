@@ -0,0 +1,151 @@
+// Copyright 2020 The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver"
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+)
+
+// topicSubscription resolves the topics consumed by the consumer group,
+// either the configured topic, or the topics matching the configured regex.
+type topicSubscription struct {
+	topics []string
+
+	// only set when subscribing to the topics matching a regex
+	regex           *regexp.Regexp
+	client          sarama.Client
+	refreshInterval time.Duration
+}
+
+func newTopicSubscription(config Config, c *sarama.Config) (topicSubscription, error) {
+	if config.TopicRegex == "" {
+		return topicSubscription{topics: []string{config.Topic}}, nil
+	}
+	regex, err := regexp.Compile(config.TopicRegex)
+	if err != nil {
+		return topicSubscription{}, err
+	}
+	client, err := sarama.NewClient(config.Brokers, c)
+	if err != nil {
+		return topicSubscription{}, err
+	}
+	return topicSubscription{
+		regex:           regex,
+		client:          client,
+		refreshInterval: config.TopicRefreshInterval,
+	}, nil
+}
+
+// session returns the topics of the next consumer group session, and a
+// context cancelled when the topics matching the regex change, ending the
+// session. While no topic matches the regex, it waits, calling idle once.
+func (s topicSubscription) session(ctx context.Context, logger *zap.Logger, idle func()) ([]string, context.Context, context.CancelFunc, error) {
+	if s.regex == nil {
+		sessionCtx, cancel := context.WithCancel(ctx)
+		return s.topics, sessionCtx, cancel, nil
+	}
+
+	ticker := time.NewTicker(s.refreshInterval)
+	defer ticker.Stop()
+	for {
+		topics, err := s.matchingTopics()
+		switch {
+		case err != nil:
+			logger.Error("Failed to list the topics matching the regex", zap.Error(err))
+		case len(topics) > 0:
+			sessionCtx, cancel := context.WithCancel(ctx)
+			go s.watch(sessionCtx, cancel, topics, logger)
+			return topics, sessionCtx, cancel, nil
+		default:
+			logger.Debug("No topic matches the regex", zap.String("regex", s.regex.String()))
+		}
+		if idle != nil {
+			idle()
+			idle = nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, nil, nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// watch cancels the session when the topics matching the regex change
+func (s topicSubscription) watch(ctx context.Context, cancel context.CancelFunc, topics []string, logger *zap.Logger) {
+	ticker := time.NewTicker(s.refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		latest, err := s.matchingTopics()
+		if err != nil {
+			logger.Error("Failed to list the topics matching the regex", zap.Error(err))
+			continue
+		}
+		if !equalTopics(topics, latest) {
+			logger.Info("The topics matching the regex changed", zap.Strings("topics", latest))
+			cancel()
+			return
+		}
+	}
+}
+
+func (s topicSubscription) matchingTopics() ([]string, error) {
+	if err := s.client.RefreshMetadata(); err != nil {
+		return nil, err
+	}
+	all, err := s.client.Topics()
+	if err != nil {
+		return nil, err
+	}
+	var topics []string
+	for _, topic := range all {
+		if s.regex.MatchString(topic) {
+			topics = append(topics, topic)
+		}
+	}
+	sort.Strings(topics)
+	return topics, nil
+}
+
+func (s topicSubscription) close() error {
+	if s.client == nil {
+		return nil
+	}
+	return s.client.Close()
+}
+
+func equalTopics(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,125 @@
+// Copyright 2020 The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkareceiver
+
+import (
+	"context"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestTopicSubscription_static(t *testing.T) {
+	s, err := newTopicSubscription(Config{Topic: "spans"}, sarama.NewConfig())
+	require.NoError(t, err)
+
+	topics, sessionCtx, cancel, err := s.session(context.Background(), zap.NewNop(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"spans"}, topics)
+	cancel()
+	assert.Error(t, sessionCtx.Err())
+	assert.NoError(t, s.close())
+}
+
+func TestTopicSubscription_regex(t *testing.T) {
+	client := &testMetadataClient{topics: []string{"team-b-logs", "other", "team-a-logs"}}
+	s := topicSubscription{
+		regex:           regexp.MustCompile("^team-.*-logs$"),
+		client:          client,
+		refreshInterval: 10 * time.Millisecond,
+	}
+
+	topics, sessionCtx, cancel, err := s.session(context.Background(), zap.NewNop(), nil)
+	require.NoError(t, err)
+	defer cancel()
+	assert.Equal(t, []string{"team-a-logs", "team-b-logs"}, topics)
+
+	// the session ends when the matching topics change
+	client.setTopics([]string{"team-b-logs", "other", "team-a-logs", "team-c-logs"})
+	select {
+	case <-sessionCtx.Done():
+	case <-time.After(10 * time.Second):
+		t.Fatal("the session was not cancelled")
+	}
+
+	topics, _, cancel, err = s.session(context.Background(), zap.NewNop(), nil)
+	require.NoError(t, err)
+	defer cancel()
+	assert.Equal(t, []string{"team-a-logs", "team-b-logs", "team-c-logs"}, topics)
+
+	require.NoError(t, s.close())
+	assert.True(t, client.closed)
+}
+
+func TestTopicSubscription_regexNoMatch(t *testing.T) {
+	client := &testMetadataClient{topics: []string{"other"}}
+	s := topicSubscription{
+		regex:           regexp.MustCompile("^team-.*-logs$"),
+		client:          client,
+		refreshInterval: 10 * time.Millisecond,
+	}
+
+	idle := make(chan struct{})
+	go func() {
+		<-idle
+		client.setTopics([]string{"other", "team-a-logs"})
+	}()
+	topics, _, cancel, err := s.session(context.Background(), zap.NewNop(), func() { close(idle) })
+	require.NoError(t, err)
+	defer cancel()
+	assert.Equal(t, []string{"team-a-logs"}, topics)
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	client.setTopics(nil)
+	cancelCtx()
+	_, _, _, err = s.session(ctx, zap.NewNop(), nil)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// testMetadataClient implements the metadata lookups of the sarama client
+type testMetadataClient struct {
+	sarama.Client
+
+	mu     sync.Mutex
+	topics []string
+	closed bool
+}
+
+func (c *testMetadataClient) setTopics(topics []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.topics = topics
+}
+
+func (c *testMetadataClient) RefreshMetadata(...string) error {
+	return nil
+}
+
+func (c *testMetadataClient) Topics() ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.topics...), nil
+}
+
+func (c *testMetadataClient) Close() error {
+	c.closed = true
+	return nil
+}
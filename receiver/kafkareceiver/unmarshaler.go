@@ -77,7 +77,11 @@ func defaultMetricsUnmarshalers() map[string]MetricsUnmarshaler {
 
 func defaultLogsUnmarshalers() map[string]LogsUnmarshaler {
 	otlpPb := newPdataLogsUnmarshaler(plog.NewProtoUnmarshaler(), defaultEncoding)
+	logsJSON := jsonLogsUnmarshaler{}
+	logsText := textLogsUnmarshaler{}
 	return map[string]LogsUnmarshaler{
-		otlpPb.Encoding(): otlpPb,
+		otlpPb.Encoding():   otlpPb,
+		logsJSON.Encoding(): logsJSON,
+		logsText.Encoding(): logsText,
 	}
 }